@@ -1,9 +1,7 @@
 package api
 
 import (
-	"bytes"
 	"encoding/json"
-	"io"
 	"log"
 	"net/http"
 	"time"
@@ -17,6 +15,7 @@ import (
 type ContextKey string
 
 const ContextUserKey ContextKey = "user-key"
+const ContextApiKeyKey ContextKey = "api-key-key"
 
 type Config struct {
 	Domain    domain.Config
@@ -28,7 +27,7 @@ func (c *Config) Serve() {
 	r := chi.NewRouter()
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"https://*", "http://*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"*"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: false,
@@ -44,26 +43,102 @@ func (c *Config) Serve() {
 
 	v1.Post("/recipes", c.middlewareExtractUser(c.handlePostRecipe()))
 	v1.Get("/recipes", c.middlewareExtractUser(c.handleGetRecipes()))
+	v1.Post("/recipes/import", c.middlewareExtractUser(c.handlePostRecipeDocument()))
+	v1.Get("/recipes/search", c.middlewareExtractUser(c.handleSearchRecipes()))
 	v1.Get("/recipes/{recipe_id}", c.middlewareExtractUser(c.handleGetRecipe()))
+	v1.Put("/recipes/{recipe_id}", c.middlewareExtractUser(c.handlePutRecipe()))
+	v1.Patch("/recipes/{recipe_id}", c.middlewareExtractUser(c.handlePatchRecipe()))
+	v1.Delete("/recipes/{recipe_id}", c.middlewareExtractUser(c.handleDeleteRecipe()))
 
 	v1.Get("/recipes/{recipe_id}/ingredients", c.middlewareExtractUser(c.handleGetIngredients()))
+	v1.Post("/recipes/{recipe_id}/ingredients", c.middlewareExtractUser(c.handlePostIngredient()))
+
+	v1.Get("/recipes/{recipe_id}/instructions", c.middlewareExtractUser(c.handleGetInstructions()))
+
+	v1.Get("/recipes/{recipe_id}/tags", c.middlewareExtractUser(c.handleGetRecipeTags()))
+	v1.Post("/recipes/{recipe_id}/tags", c.middlewareExtractUser(c.handlePostRecipeTag()))
+	v1.Delete("/recipes/{recipe_id}/tags/{tag}", c.middlewareExtractUser(c.handleDeleteRecipeTag()))
+	v1.Get("/tags", c.middlewareExtractUser(c.handleGetTags()))
+
+	v1.Get("/recipes/{recipe_id}/image", c.middlewareExtractUser(c.handleGetRecipeImage()))
+	v1.Put("/recipes/{recipe_id}/image", c.middlewareExtractUser(c.handlePutRecipeImage()))
+	v1.Delete("/recipes/{recipe_id}/image", c.middlewareExtractUser(c.handleDeleteRecipeImage()))
+
+	v1.Put("/recipes/{recipe_id}/household", c.middlewareExtractUser(c.handlePutRecipeHousehold()))
+
+	v1.Post("/recipe-imports", c.middlewareExtractUser(c.handlePostRecipeImport()))
+	v1.Post("/recipe-imports/batch", c.middlewareExtractUser(c.handlePostRecipeImportBatch()))
+	v1.Get("/recipe-imports", c.middlewareExtractUser(c.handleGetRecipeImports()))
+	v1.Get("/recipe-imports/{recipe_import_id}", c.middlewareExtractUser(c.handleGetRecipeImport()))
 
 	v1.Post("/grocery-lists", c.middlewareExtractUser(c.handlePostGroceryList()))
 	v1.Get("/grocery-lists", c.middlewareExtractUser(c.handleGetGroceryLists()))
 	v1.Get("/grocery-lists/{grocery_list_id}", c.middlewareExtractUser(c.handleGetGroceryList()))
+	v1.Delete("/grocery-lists/{grocery_list_id}", c.middlewareExtractUser(c.handleDeleteGroceryList()))
+	v1.Put("/grocery-lists/{grocery_list_id}/household", c.middlewareExtractUser(c.handlePutGroceryListHousehold()))
+	v1.Get("/grocery-lists/{grocery_list_id}/events", c.middlewareExtractUser(c.handleGetGroceryListEvents()))
+	v1.Get("/grocery-lists/{grocery_list_id}/changes", c.middlewareExtractUser(c.handleGetGroceryListChanges()))
+	v1.Post("/grocery-lists/{grocery_list_id}/changes", c.middlewareExtractUser(c.handlePostGroceryListChanges()))
 
 	v1.Post("/grocery-lists/{grocery_list_id}/meals", c.middlewareExtractUser(c.handlePostMealInGroceryList()))
 	v1.Get("/grocery-lists/{grocery_list_id}/meals", c.middlewareExtractUser(c.handleGetMealsInGroceryList()))
 
+	v1.Delete("/meals/{meal_id}", c.middlewareExtractUser(c.handleDeleteMeal()))
+	v1.Put("/meals/{meal_id}/servings", c.middlewareExtractUser(c.handlePutMealServings()))
+
 	v1.Get("/grocery-lists/{grocery_list_id}/items", c.middlewareExtractUser(c.handleGetItemsForGroceryList()))
 	v1.Post("/grocery-lists/{grocery_list_id}/items", c.middlewareExtractUser(c.handlePostItem()))
 	v1.Get("/grocery-lists/{grocery_list_id}/items/{item_name}", c.middlewareExtractUser(c.handleGetItemsForGroceryListByName()))
 
 	v1.Get("/items/{item_id}", c.middlewareExtractUser(c.handleGetItem()))
+	v1.Delete("/items/{item_id}", c.middlewareExtractUser(c.handleDeleteItem()))
 	v1.Put("/items/{item_id}/status", c.middlewareExtractUser(c.handleMarkItemStatus()))
+	v1.Put("/items/{item_id}/category", c.middlewareExtractUser(c.handlePutItemCategory()))
+
+	v1.Get("/pantry", c.middlewareExtractUser(c.handleGetPantry()))
+	v1.Post("/pantry", c.middlewareExtractUser(c.handlePostPantryItem()))
+	v1.Get("/pantry/{pantry_item_id}", c.middlewareExtractUser(c.handleGetPantryItem()))
+	v1.Put("/pantry/{pantry_item_id}", c.middlewareExtractUser(c.handlePutPantryItem()))
+	v1.Delete("/pantry/{pantry_item_id}", c.middlewareExtractUser(c.handleDeletePantryItem()))
+	v1.Get("/households/{household_id}/pantry", c.middlewareExtractUser(c.handleGetPantry()))
+	v1.Post("/households/{household_id}/pantry", c.middlewareExtractUser(c.handlePostPantryItem()))
+
+	v1.Get("/meal-plan", c.middlewareExtractUser(c.handleGetMealPlan()))
+	v1.Post("/meal-plan", c.middlewareExtractUser(c.handlePostPlannedMeal()))
+	v1.Post("/meal-plan/grocery-list", c.middlewareExtractUser(c.handlePostMealPlanGroceryList()))
+	v1.Get("/meal-plan/calendar-feed", c.middlewareExtractUser(c.handleGetCalendarFeed()))
+	v1.Post("/meal-plan/calendar-feed", c.middlewareExtractUser(c.middlewareRejectApiKey(c.handlePostCalendarFeed())))
+	v1.Delete("/meal-plan/calendar-feed", c.middlewareExtractUser(c.middlewareRejectApiKey(c.handleDeleteCalendarFeed())))
+	v1.Get("/meal-plan/calendar-feed/{token}.ics", c.handleGetCalendarFeedICS())
+	v1.Get("/meal-plan/{planned_meal_id}", c.middlewareExtractUser(c.handleGetPlannedMeal()))
+	v1.Put("/meal-plan/{planned_meal_id}", c.middlewareExtractUser(c.handlePutPlannedMeal()))
+	v1.Delete("/meal-plan/{planned_meal_id}", c.middlewareExtractUser(c.handleDeletePlannedMeal()))
+
+	v1.Post("/stores", c.middlewareExtractUser(c.handlePostStore()))
+	v1.Get("/stores", c.middlewareExtractUser(c.handleGetStores()))
+	v1.Get("/stores/{store_id}", c.middlewareExtractUser(c.handleGetStore()))
+	v1.Put("/stores/{store_id}", c.middlewareExtractUser(c.handlePutStore()))
+	v1.Delete("/stores/{store_id}", c.middlewareExtractUser(c.handleDeleteStore()))
+
+	v1.Post("/households", c.middlewareExtractUser(c.handlePostHousehold()))
+	v1.Get("/households", c.middlewareExtractUser(c.handleGetHouseholds()))
+	v1.Get("/households/{household_id}", c.middlewareExtractUser(c.handleGetHousehold()))
+	v1.Delete("/households/{household_id}", c.middlewareExtractUser(c.handleDeleteHousehold()))
+	v1.Post("/households/{household_id}/members", c.middlewareExtractUser(c.handlePostHouseholdMember()))
+	v1.Put("/households/{household_id}/members/{user_id}", c.middlewareExtractUser(c.handlePutHouseholdMember()))
+	v1.Delete("/households/{household_id}/members/{user_id}", c.middlewareExtractUser(c.handleDeleteHouseholdMember()))
+
+	v1.Post("/api-keys", c.middlewareExtractUser(c.middlewareRejectApiKey(c.handlePostApiKey())))
+	v1.Get("/api-keys", c.middlewareExtractUser(c.middlewareRejectApiKey(c.handleGetApiKeys())))
+	v1.Delete("/api-keys/{api_key_id}", c.middlewareExtractUser(c.middlewareRejectApiKey(c.handleDeleteApiKey())))
 
 	v1.Post("/users", c.handlePostUser())
 	v1.Post("/login", c.handleLogin())
+	v1.Post("/refresh", c.handleRefresh())
+	v1.Post("/logout", c.middlewareExtractUser(c.middlewareRejectApiKey(c.handleLogout())))
+	v1.Put("/users/me/password", c.middlewareExtractUser(c.middlewareRejectApiKey(c.handlePutPassword())))
+	v1.Post("/password-reset", c.handlePostPasswordReset())
+	v1.Post("/password-reset/confirm", c.handlePostPasswordResetConfirm())
 
 	server := &http.Server{
 		Addr:              "0.0.0.0:" + c.Port,
@@ -88,13 +163,50 @@ func (c *Config) handleIndex() http.HandlerFunc {
 		<ul>
 		<li>POST /v1/users</li>
 		<li>POST /v1/login</li>
+		<li>POST /v1/refresh</li>
+		<li>POST /v1/logout</li>
+		<li>PUT /v1/users/me/password</li>
+		<li>POST /v1/password-reset</li>
+		<li>POST /v1/password-reset/confirm</li>
+		<li>GET/POST /v1/api-keys</li>
+		<li>DELETE /v1/api-keys/{id}</li>
 		<li>GET/POST /v1/recipes</li>
-		<li>GET /v1/recipes{id}</li>
+		<li>POST /v1/recipes/import</li>
+		<li>GET /v1/recipes/search?q=</li>
+		<li>GET/PUT/PATCH/DELETE /v1/recipes{id}</li>
 		<li>GET/POST /v1/recipes/{id}/ingredients</li>
+		<li>GET /v1/recipes/{id}/instructions</li>
+		<li>GET/POST /v1/recipes/{id}/tags</li>
+		<li>DELETE /v1/recipes/{id}/tags/{tag}</li>
+		<li>GET /v1/tags</li>
+		<li>GET/PUT/DELETE /v1/recipes/{id}/image</li>
+		<li>GET/POST /v1/recipe-imports</li>
+		<li>POST /v1/recipe-imports/batch</li>
+		<li>GET /v1/recipe-imports/{id}</li>
 		<li>GET/POST /v1/grocery-lists</li>
-		<li>GET /v1/grocery-lists{id}</li>
+		<li>GET/DELETE /v1/grocery-lists{id}</li>
+		<li>GET /v1/grocery-lists/{id}/events</li>
+		<li>GET/POST /v1/grocery-lists/{id}/changes</li>
 		<li>GET/POST /v1/grocery-lists/recipes</li>
 		<li>GET /v1/grocery-lists/ingredients</li>
+		<li>PUT /v1/meals/{id}/servings</li>
+		<li>GET/POST /v1/households</li>
+		<li>GET/DELETE /v1/households/{id}</li>
+		<li>POST /v1/households/{id}/members</li>
+		<li>PUT/DELETE /v1/households/{id}/members/{user_id}</li>
+		<li>PUT /v1/recipes/{id}/household</li>
+		<li>PUT /v1/grocery-lists/{id}/household</li>
+		<li>PUT /v1/items/{id}/category</li>
+		<li>GET/POST /v1/pantry</li>
+		<li>GET/PUT/DELETE /v1/pantry/{id}</li>
+		<li>GET/POST /v1/households/{id}/pantry</li>
+		<li>GET/POST /v1/stores</li>
+		<li>GET/PUT/DELETE /v1/stores/{id}</li>
+		<li>GET/POST /v1/meal-plan?from=&to=</li>
+		<li>GET/PUT/DELETE /v1/meal-plan/{id}</li>
+		<li>POST /v1/meal-plan/grocery-list</li>
+		<li>GET/POST/DELETE /v1/meal-plan/calendar-feed</li>
+		<li>GET /v1/meal-plan/calendar-feed/{token}.ics</li>
 		</ul>
 
 		</body>
@@ -154,6 +266,16 @@ func respondWithDomainError(w http.ResponseWriter, err error) {
 		code = http.StatusBadRequest
 	case domerr.Forbidden:
 		code = http.StatusForbidden
+	case domerr.InvalidInput:
+		code = http.StatusBadRequest
+	case domerr.Conflict:
+		code = http.StatusConflict
+	case domerr.UpstreamFailure:
+		code = http.StatusBadGateway
+	case domerr.UpstreamTimeout:
+		code = http.StatusGatewayTimeout
+	case domerr.Unauthorized:
+		code = http.StatusUnauthorized
 	case domerr.Internal:
 		code = http.StatusInternalServerError
 	default:
@@ -171,18 +293,30 @@ func respondWithError(w http.ResponseWriter, code int, err string) {
 	respondWithJSON(w, code, response{Error: err})
 }
 
+// redactedHeaders are the headers that carry credentials, which are kept out
+// of the request log.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+}
+
+// middlewareLogRequest logs the method, url and headers of a request, without
+// the credentials in them. Bodies are not logged, since they carry tokens and
+// passwords and may be large.
 func middlewareLogRequest(next http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("REQUEST: %v %v\n", r.Method, r.URL)
+		u := *r.URL
+		u.Path = redactCalendarFeedPath(u.Path)
+		u.RawPath = redactCalendarFeedPath(u.RawPath)
+		log.Printf("REQUEST: %v %v\n", r.Method, &u)
 		log.Print("\tHeaders:\n")
 		for key, val := range r.Header {
+			if redactedHeaders[key] {
+				log.Printf("\t\t%v: [REDACTED]\n", key)
+				continue
+			}
 			log.Printf("\t\t%v: %v\n", key, val)
 		}
-		bodyBytes, err := io.ReadAll(r.Body)
-		r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-		if err == nil {
-			log.Printf("\tBody: %v\n", string(bodyBytes))
-		}
 		next.ServeHTTP(w, r)
 	}
 }
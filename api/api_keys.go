@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/domerr"
+)
+
+type apiKeyResponse struct {
+	ID         int64              `json:"id"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+	Name       string             `json:"name"`
+	Prefix     string             `json:"prefix"`
+	Scope      domain.ApiKeyScope `json:"scope"`
+	LastUsedAt *time.Time         `json:"last_used_at,omitempty"`
+}
+
+func domainApiKeyToResponse(key domain.ApiKey) apiKeyResponse {
+	res := apiKeyResponse{
+		ID:        key.ID,
+		CreatedAt: key.CreatedAt,
+		UpdatedAt: key.UpdatedAt,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scope:     key.Scope,
+	}
+	if !key.LastUsedAt.IsZero() {
+		res.LastUsedAt = &key.LastUsedAt
+	}
+	return res
+}
+
+func (c *Config) handlePostApiKey() http.HandlerFunc {
+	type request struct {
+		Name  string `json:"name"`
+		Scope string `json:"scope"`
+	}
+
+	type response struct {
+		apiKeyResponse
+		Key string `json:"key"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		scope, err := domain.ApiKeyScopeFromString(reqBody.Scope)
+		if err != nil {
+			respondWithDomainError(w, domerr.ErrInvalidInput)
+			return
+		}
+
+		apiKey, key, err := c.Domain.CreateApiKey(r.Context(), user, reqBody.Name, scope)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, response{
+			apiKeyResponse: domainApiKeyToResponse(apiKey),
+			Key:            key,
+		})
+	}
+}
+
+func (c *Config) handleGetApiKeys() http.HandlerFunc {
+	type response = []apiKeyResponse
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		keys, err := c.Domain.GetApiKeysForUser(r.Context(), user)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		res := make(response, len(keys))
+		for i, key := range keys {
+			res[i] = domainApiKeyToResponse(key)
+		}
+
+		respondWithJSON(w, http.StatusOK, res)
+	}
+}
+
+func (c *Config) handleDeleteApiKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idString := chi.URLParam(r, "api_key_id")
+
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		key, err := c.Domain.GetApiKey(r.Context(), user, id)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		err = c.Domain.DeleteApiKey(r.Context(), user, key)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
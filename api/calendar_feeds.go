@@ -0,0 +1,257 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/internal/misc"
+)
+
+// the days around today the calendar feed covers
+const (
+	calendarFeedDaysBefore = 56
+	calendarFeedDaysAfter  = 182
+)
+
+type calendarFeedResponse struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+func domainCalendarFeedToResponse(feed domain.CalendarFeed) calendarFeedResponse {
+	res := calendarFeedResponse{
+		CreatedAt: feed.CreatedAt,
+		UpdatedAt: feed.UpdatedAt,
+	}
+	if !feed.LastUsedAt.IsZero() {
+		res.LastUsedAt = &feed.LastUsedAt
+	}
+	return res
+}
+
+const calendarFeedPathPrefix = "/v1/meal-plan/calendar-feed/"
+
+// calendarFeedPath is where the feed with the token is served.
+func calendarFeedPath(token string) string {
+	return calendarFeedPathPrefix + token + ".ics"
+}
+
+// redactCalendarFeedPath hides the token of a calendar feed path, which is
+// enough to read the meal plan of its user, and returns other paths as is.
+func redactCalendarFeedPath(path string) string {
+	if !strings.HasPrefix(path, calendarFeedPathPrefix) || !strings.HasSuffix(path, ".ics") {
+		return path
+	}
+	return calendarFeedPath("REDACTED")
+}
+
+func (c *Config) handlePostCalendarFeed() http.HandlerFunc {
+	type response struct {
+		calendarFeedResponse
+		Token string `json:"token"`
+		Path  string `json:"path"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		feed, token, err := c.Domain.CreateCalendarFeed(r.Context(), user)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, response{
+			calendarFeedResponse: domainCalendarFeedToResponse(feed),
+			Token:                token,
+			Path:                 calendarFeedPath(token),
+		})
+	}
+}
+
+func (c *Config) handleGetCalendarFeed() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		feed, err := c.Domain.GetCalendarFeed(r.Context(), user)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainCalendarFeedToResponse(feed))
+	}
+}
+
+func (c *Config) handleDeleteCalendarFeed() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		err := c.Domain.DeleteCalendarFeed(r.Context(), user)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handleGetCalendarFeedICS serves the meal plan as an iCalendar file. Calendar
+// apps cannot send an Authorization header, so the token in the path
+// authenticates the request instead.
+func (c *Config) handleGetCalendarFeedICS() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, err := c.Domain.AuthenticateCalendarFeed(r.Context(), chi.URLParam(r, "token"))
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		today, err := time.Parse(domain.DateLayout, time.Now().Format(domain.DateLayout))
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		plannedMeals, err := c.Domain.GetPlannedMeals(r.Context(), user, today.AddDate(0, 0, -calendarFeedDaysBefore), today.AddDate(0, 0, calendarFeedDaysAfter))
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Cache-Control", "private, no-cache")
+		w.WriteHeader(http.StatusOK)
+
+		_, err = w.Write([]byte(renderMealPlanCalendar(plannedMeals)))
+		if err != nil {
+			log.Println("Could not write calendar feed: ", err)
+		}
+	}
+}
+
+// renderMealPlanCalendar writes planned meals as all day VEVENTs of an
+// iCalendar (RFC 5545) file.
+func renderMealPlanCalendar(plannedMeals []domain.PlannedMeal) string {
+	var b strings.Builder
+
+	line := func(name string, value string) {
+		b.WriteString(foldICSLine(name + ":" + value))
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Recipe Wizard//Meal Plan//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "Meal plan")
+
+	for _, plannedMeal := range plannedMeals {
+		recipe := plannedMeal.Recipe
+
+		var description []string
+		if total := recipe.TotalDuration(); total > 0 {
+			description = append(description, "Total time: "+formatDuration(total))
+		}
+		if plannedMeal.Servings != 0 {
+			description = append(description, "Servings: "+strconv.FormatInt(plannedMeal.Servings, 10))
+		}
+		// recipes saved before urls were checked may link to anything, and
+		// a line break in a url would end the property it is written in
+		webURL := misc.IsWebURL(recipe.Url)
+		if webURL {
+			description = append(description, recipe.Url)
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("planned-meal-%d@recipe-wizard", plannedMeal.ID))
+		line("DTSTAMP", plannedMeal.UpdatedAt.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", plannedMeal.Date.Format("20060102"))
+		line("DTEND;VALUE=DATE", plannedMeal.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escapeICSText(slotTitle(plannedMeal)+": "+recipe.Name))
+		if len(description) > 0 {
+			line("DESCRIPTION", escapeICSText(strings.Join(description, "\n")))
+		}
+		if webURL {
+			line("URL", recipe.Url)
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+
+	return b.String()
+}
+
+// slotTitle names the slot of a planned meal for people, like "Dinner".
+func slotTitle(plannedMeal domain.PlannedMeal) string {
+	if plannedMeal.Slot == domain.SlotCustom {
+		return plannedMeal.SlotName
+	}
+	name := plannedMeal.Slot.String()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// formatDuration writes a duration in hours and minutes, like "1 hr 30 min".
+func formatDuration(d time.Duration) string {
+	hours := int64(d / time.Hour)
+	minutes := int64((d%time.Hour + time.Minute/2) / time.Minute)
+	if minutes == 60 {
+		hours, minutes = hours+1, 0
+	}
+
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%d min", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%d hr", hours)
+	default:
+		return fmt.Sprintf("%d hr %d min", hours, minutes)
+	}
+}
+
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICSLine ends a content line with CRLF, breaking it into lines of at most
+// 75 octets without splitting a UTF-8 sequence.
+func foldICSLine(s string) string {
+	var b strings.Builder
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// the leading space of a continuation line counts toward its length
+		limit = 74
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	return b.String()
+}
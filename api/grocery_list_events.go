@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/events"
+)
+
+// heartbeatInterval is how often an idle event stream sends a comment, which
+// keeps proxies from closing it and rechecks that the user may still see the
+// grocery list.
+const heartbeatInterval = 15 * time.Second
+
+// eventData converts the domain value carried by an event to the json the
+// rest of the api returns for it.
+func eventData(e events.Event) any {
+	switch data := e.Data.(type) {
+	case domain.Item:
+		return domainItemToResponse(data)
+	case domain.Meal:
+		return domainMealToResponse(data, nil)
+	case domain.GroceryList:
+		return domainGroceryListToResponse(data)
+	}
+	return e.Data
+}
+
+func writeEvent(w http.ResponseWriter, e events.Event) error {
+	data, err := json.Marshal(eventData(e))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
+
+func (c *Config) handleGetGroceryListEvents() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "grocery_list_id"), 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Streaming is not supported")
+			return
+		}
+
+		groceryList, err := c.Domain.GetGroceryList(r.Context(), user, id)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		sub, err := c.Domain.SubscribeToGroceryList(r.Context(), user, groceryList)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+		defer sub.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+
+			case <-heartbeat.C:
+				// access may have been taken away since the stream started
+				_, err := c.Domain.GetGroceryList(r.Context(), user, id)
+				if err != nil {
+					return
+				}
+
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+				flusher.Flush()
+
+			case e, ok := <-sub.Events():
+				if !ok {
+					// the subscriber fell behind, so the client has to
+					// reconnect and refetch the list
+					return
+				}
+
+				if err := writeEvent(w, e); err != nil {
+					log.Println("Could not write grocery list event: ", err)
+					return
+				}
+				flusher.Flush()
+
+				if e.Type == domain.EventGroceryListDeleted {
+					return
+				}
+			}
+		}
+	}
+}
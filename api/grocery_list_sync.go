@@ -0,0 +1,173 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/domerr"
+)
+
+func (c *Config) handleGetGroceryListChanges() http.HandlerFunc {
+	type response struct {
+		Full           bool           `json:"full"`
+		Items          []itemResponse `json:"items"`
+		Meals          []mealResponse `json:"meals"`
+		DeletedItemIDs []int64        `json:"deleted_item_ids"`
+		DeletedMealIDs []int64        `json:"deleted_meal_ids"`
+		SyncToken      string         `json:"sync_token"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "grocery_list_id"), 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		groceryList, err := c.Domain.GetGroceryList(r.Context(), user, id)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		changes, err := c.Domain.GetGroceryListChanges(r.Context(), groceryList, r.URL.Query().Get("since"))
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		res := response{
+			Full:           changes.Full,
+			Items:          make([]itemResponse, len(changes.Items)),
+			Meals:          make([]mealResponse, len(changes.Meals)),
+			DeletedItemIDs: changes.DeletedItemIDs,
+			DeletedMealIDs: changes.DeletedMealIDs,
+			SyncToken:      changes.SyncToken,
+		}
+		for i, item := range changes.Items {
+			res.Items[i] = domainItemToResponse(item)
+		}
+		for i, meal := range changes.Meals {
+			res.Meals[i] = domainMealToResponse(meal, nil)
+		}
+
+		respondWithJSON(w, http.StatusOK, res)
+	}
+}
+
+func (c *Config) handlePostGroceryListChanges() http.HandlerFunc {
+	type operation struct {
+		Op          string    `json:"op"`
+		ClientID    string    `json:"client_id"`
+		ItemID      int64     `json:"item_id"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		Amount      float64   `json:"amount"`
+		Units       string    `json:"units"`
+		Status      string    `json:"status"`
+		Restock     bool      `json:"restock"`
+		ChangedAt   time.Time `json:"changed_at"`
+	}
+
+	type request struct {
+		Operations []operation `json:"operations"`
+	}
+
+	type result struct {
+		ClientID string             `json:"client_id,omitempty"`
+		Outcome  domain.SyncOutcome `json:"outcome"`
+		Item     *itemResponse      `json:"item,omitempty"`
+	}
+
+	type response struct {
+		Results []result `json:"results"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "grocery_list_id"), 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		reqBody := request{}
+		err = json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		ops := make([]domain.SyncOperation, len(reqBody.Operations))
+		for i, op := range reqBody.Operations {
+			syncOp, err := domain.SyncOpFromString(op.Op)
+			if err != nil {
+				respondWithDomainError(w, domerr.ErrInvalidInput)
+				return
+			}
+
+			var status domain.ItemStatus
+			if op.Status != "" {
+				status, err = domain.ItemStatusFromString(op.Status)
+				if err != nil {
+					respondWithDomainError(w, domerr.ErrInvalidInput)
+					return
+				}
+			}
+
+			ops[i] = domain.SyncOperation{
+				Op:          syncOp,
+				ClientID:    op.ClientID,
+				ItemID:      op.ItemID,
+				Name:        op.Name,
+				Description: op.Description,
+				Amount:      op.Amount,
+				Units:       op.Units,
+				Status:      status,
+				Restock:     op.Restock,
+				ChangedAt:   op.ChangedAt,
+			}
+		}
+
+		groceryList, err := c.Domain.GetGroceryList(r.Context(), user, id)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		results, err := c.Domain.ApplyGroceryListOperations(r.Context(), user, groceryList, ops)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		res := response{Results: make([]result, len(results))}
+		for i, syncResult := range results {
+			res.Results[i] = result{
+				ClientID: syncResult.ClientID,
+				Outcome:  syncResult.Outcome,
+			}
+			if syncResult.Item.ID != 0 {
+				item := domainItemToResponse(syncResult.Item)
+				res.Results[i].Item = &item
+			}
+		}
+
+		respondWithJSON(w, http.StatusOK, res)
+	}
+}
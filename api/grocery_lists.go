@@ -11,20 +11,22 @@ import (
 )
 
 type groceryListResponse struct {
-	ID        int64     `json:"id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Name      string    `json:"name"`
-	OwnerID   int64     `json:"owner_id"`
+	ID          int64     `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name"`
+	OwnerID     int64     `json:"owner_id"`
+	HouseholdID int64     `json:"household_id,omitempty"`
 }
 
 func domainGroceryListToResponse(gl domain.GroceryList) groceryListResponse {
 	return groceryListResponse{
-		ID:        gl.ID,
-		CreatedAt: gl.CreatedAt,
-		UpdatedAt: gl.UpdatedAt,
-		Name:      gl.Name,
-		OwnerID:   gl.OwnerID,
+		ID:          gl.ID,
+		CreatedAt:   gl.CreatedAt,
+		UpdatedAt:   gl.UpdatedAt,
+		Name:        gl.Name,
+		OwnerID:     gl.OwnerID,
+		HouseholdID: gl.HouseholdID,
 	}
 }
 
@@ -99,7 +101,13 @@ func (c *Config) handleGetGroceryLists() http.HandlerFunc {
 			return
 		}
 
-		groceryLists, err := c.Domain.GetGroceryListsForUser(r.Context(), user)
+		params, err := listParamsFromQuery(r)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		groceryLists, next, err := c.Domain.ListGroceryListsForUser(r.Context(), user, params)
 		if err != nil {
 			respondWithDomainError(w, err)
 			return
@@ -112,6 +120,39 @@ func (c *Config) handleGetGroceryLists() http.HandlerFunc {
 			resBody[i] = r
 		}
 
+		setNextLink(w, r, next)
 		respondWithJSON(w, http.StatusOK, resBody)
 	}
 }
+
+func (c *Config) handleDeleteGroceryList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idString := chi.URLParam(r, "grocery_list_id")
+
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		groceryList, err := c.Domain.GetGroceryList(r.Context(), user, id)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		err = c.Domain.DeleteGroceryList(r.Context(), user, groceryList)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,381 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/domerr"
+)
+
+type householdResponse struct {
+	ID        int64                     `json:"id"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+	Name      string                    `json:"name"`
+	Role      domain.HouseholdRole      `json:"role"`
+	Members   []householdMemberResponse `json:"members,omitempty"`
+}
+
+type householdMemberResponse struct {
+	UserID    int64                `json:"user_id"`
+	Username  string               `json:"username"`
+	Role      domain.HouseholdRole `json:"role"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+func domainHouseholdToResponse(household domain.Household, members []domain.HouseholdMember) householdResponse {
+	var responseMembers []householdMemberResponse
+
+	if members != nil {
+		responseMembers = make([]householdMemberResponse, 0, len(members))
+		for _, member := range members {
+			responseMembers = append(responseMembers, domainHouseholdMemberToResponse(member))
+		}
+	}
+
+	return householdResponse{
+		ID:        household.ID,
+		CreatedAt: household.CreatedAt,
+		UpdatedAt: household.UpdatedAt,
+		Name:      household.Name,
+		Role:      household.Role,
+		Members:   responseMembers,
+	}
+}
+
+func domainHouseholdMemberToResponse(member domain.HouseholdMember) householdMemberResponse {
+	return householdMemberResponse{
+		UserID:    member.UserID,
+		Username:  member.Username,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+		UpdatedAt: member.UpdatedAt,
+	}
+}
+
+// householdFromRequest fetches the household named by the household_id url
+// parameter, responding with an error if that fails.
+func (c *Config) householdFromRequest(w http.ResponseWriter, r *http.Request, user domain.User) (domain.Household, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "household_id"), 10, 64)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+		return domain.Household{}, false
+	}
+
+	household, err := c.Domain.GetHousehold(r.Context(), user, id)
+	if err != nil {
+		respondWithDomainError(w, err)
+		return domain.Household{}, false
+	}
+
+	return household, true
+}
+
+func (c *Config) handlePostHousehold() http.HandlerFunc {
+	type request struct {
+		Name string `json:"name"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		household, err := c.Domain.CreateHousehold(r.Context(), user, reqBody.Name)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, domainHouseholdToResponse(household, nil))
+	}
+}
+
+func (c *Config) handleGetHouseholds() http.HandlerFunc {
+	type response = []householdResponse
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		households, err := c.Domain.GetHouseholdsForUser(r.Context(), user)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		res := make(response, len(households))
+		for i, household := range households {
+			res[i] = domainHouseholdToResponse(household, nil)
+		}
+
+		respondWithJSON(w, http.StatusOK, res)
+	}
+}
+
+func (c *Config) handleGetHousehold() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		household, ok := c.householdFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		members, err := c.Domain.GetHouseholdMembers(r.Context(), household)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainHouseholdToResponse(household, members))
+	}
+}
+
+func (c *Config) handleDeleteHousehold() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		household, ok := c.householdFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		err := c.Domain.DeleteHousehold(r.Context(), user, household)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (c *Config) handlePostHouseholdMember() http.HandlerFunc {
+	type request struct {
+		Username string `json:"username"`
+		Role     string `json:"role"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		household, ok := c.householdFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		role, err := domain.HouseholdRoleFromString(reqBody.Role)
+		if err != nil {
+			respondWithDomainError(w, domerr.ErrInvalidInput)
+			return
+		}
+
+		member, err := c.Domain.AddHouseholdMember(r.Context(), user, household, reqBody.Username, role)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, domainHouseholdMemberToResponse(member))
+	}
+}
+
+func (c *Config) handlePutHouseholdMember() http.HandlerFunc {
+	type request struct {
+		Role string `json:"role"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		memberID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		household, ok := c.householdFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		reqBody := request{}
+		err = json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		role, err := domain.HouseholdRoleFromString(reqBody.Role)
+		if err != nil {
+			respondWithDomainError(w, domerr.ErrInvalidInput)
+			return
+		}
+
+		member, err := c.Domain.SetHouseholdMemberRole(r.Context(), user, household, memberID, role)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainHouseholdMemberToResponse(member))
+	}
+}
+
+func (c *Config) handleDeleteHouseholdMember() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		memberID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		household, ok := c.householdFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		err = c.Domain.RemoveHouseholdMember(r.Context(), user, household, memberID)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+type putHouseholdRequest struct {
+	HouseholdID *int64 `json:"household_id"` // null stops sharing
+}
+
+func (c *Config) handlePutRecipeHousehold() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "recipe_id"), 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		reqBody := putHouseholdRequest{}
+		err = json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		var householdID int64
+		if reqBody.HouseholdID != nil {
+			householdID = *reqBody.HouseholdID
+		}
+
+		recipe, err := c.Domain.GetRecipe(r.Context(), user, id)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		recipe, err = c.Domain.SetRecipeHousehold(r.Context(), user, recipe, householdID)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainRecipeToResponse(recipe, nil, nil))
+	}
+}
+
+func (c *Config) handlePutGroceryListHousehold() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "grocery_list_id"), 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		reqBody := putHouseholdRequest{}
+		err = json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		var householdID int64
+		if reqBody.HouseholdID != nil {
+			householdID = *reqBody.HouseholdID
+		}
+
+		groceryList, err := c.Domain.GetGroceryList(r.Context(), user, id)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		groceryList, err = c.Domain.SetGroceryListHousehold(r.Context(), user, groceryList, householdID)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainGroceryListToResponse(groceryList))
+	}
+}
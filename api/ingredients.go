@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 	"time"
@@ -10,13 +11,14 @@ import (
 )
 
 type ingredientResponse struct {
-	ID          int64           `json:"id"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
-	Name        string          `json:"name"`
-	Measure     measureResponse `json:"measure"`
-	Description string          `json:"description,omitempty"`
-	RecipeID    int64           `json:"recipe_id"`
+	ID                    int64           `json:"id"`
+	CreatedAt             time.Time       `json:"created_at"`
+	UpdatedAt             time.Time       `json:"updated_at"`
+	Name                  string          `json:"name"`
+	Measure               measureResponse `json:"measure"`
+	Description           string          `json:"description,omitempty"`
+	RecipeID              int64           `json:"recipe_id"`
+	CanonicalIngredientID int64           `json:"canonical_ingredient_id,omitempty"`
 }
 
 type measureResponse struct {
@@ -38,8 +40,9 @@ func domainIngredientToReponse(ingredient domain.Ingredient) ingredientResponse
 			StandardAmount: ingredient.StandardAmount,
 			StandardUnits:  ingredient.StandardUnits.String(),
 		},
-		Description: ingredient.Description,
-		RecipeID:    ingredient.RecipeID,
+		Description:           ingredient.Description,
+		RecipeID:              ingredient.RecipeID,
+		CanonicalIngredientID: ingredient.CanonicalIngredientID,
 	}
 }
 
@@ -85,3 +88,46 @@ func (c *Config) handleGetIngredients() http.HandlerFunc {
 	}
 
 }
+
+func (c *Config) handlePostIngredient() http.HandlerFunc {
+	type request struct {
+		Line string `json:"line"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		idString := chi.URLParam(r, "recipe_id")
+
+		recipeID, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Recipe id is not an integer")
+			return
+		}
+
+		recipe, err := c.Domain.GetRecipe(r.Context(), user, recipeID)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		ingredient, err := c.Domain.CreateIngredient(r.Context(), user, recipe, reqBody.Line)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, domainIngredientToReponse(ingredient))
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+)
+
+type instructionResponse struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	RecipeID  int64     `json:"recipe_id"`
+	Step      int64     `json:"step"`
+	Text      string    `json:"text"`
+}
+
+func domainInstructionToResponse(instruction domain.Instruction) instructionResponse {
+	return instructionResponse{
+		ID:        instruction.ID,
+		CreatedAt: instruction.CreatedAt,
+		UpdatedAt: instruction.UpdatedAt,
+		RecipeID:  instruction.RecipeID,
+		Step:      instruction.Step,
+		Text:      instruction.Text,
+	}
+}
+
+func (c *Config) handleGetInstructions() http.HandlerFunc {
+	type response []instructionResponse
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		idString := chi.URLParam(r, "recipe_id")
+
+		recipeID, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Recipe id is not an integer")
+			return
+		}
+
+		recipe, err := c.Domain.GetRecipe(r.Context(), user, recipeID)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		instructions, err := c.Domain.GetInstructionsForRecipe(r.Context(), user, recipe)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		resBody := make(response, len(instructions))
+
+		for i, instruction := range instructions {
+			resBody[i] = domainInstructionToResponse(instruction)
+		}
+
+		respondWithJSON(w, http.StatusOK, resBody)
+	}
+}
@@ -12,41 +12,61 @@ import (
 )
 
 type itemResponse struct {
-	ID            int64           `json:"id"`
-	CreatedAt     time.Time       `json:"created_at"`
-	UpdatedAt     time.Time       `json:"updated_at"`
-	GroceryListID int64           `json:"grocery_list_id"`
-	MealID        int64           `json:"meal_id,omitempty"` // 0 is never a sql id, so we can treat 0 as "no meal"
-	IngredientID  int64           `json:"ingredient_id,omitempty"`
-	Name          string          `json:"name"`
-	Description   string          `json:"description,omitempty"`
-	Measure       measureResponse `json:"measure"`
-	Status        string          `json:"status"`
+	ID                    int64               `json:"id"`
+	CreatedAt             time.Time           `json:"created_at"`
+	UpdatedAt             time.Time           `json:"updated_at"`
+	GroceryListID         int64               `json:"grocery_list_id"`
+	MealID                int64               `json:"meal_id,omitempty"` // 0 is never a sql id, so we can treat 0 as "no meal"
+	IngredientID          int64               `json:"ingredient_id,omitempty"`
+	CanonicalIngredientID int64               `json:"canonical_ingredient_id,omitempty"`
+	Name                  string              `json:"name"`
+	Description           string              `json:"description,omitempty"`
+	Measure               measureResponse     `json:"measure"`
+	Status                string              `json:"status"`
+	Category              domain.ItemCategory `json:"category"`
 }
 
 type itemGroupResponse struct {
-	Name   string                            `json:"name"`
-	Totals map[ingparse.StandardUnit]float64 `json:"totals,omitempty"`
-	Items  []itemResponse                    `json:"items,omitempty"`
+	Name                  string                            `json:"name"`
+	CanonicalIngredientID int64                             `json:"canonical_ingredient_id,omitempty"`
+	Category              domain.ItemCategory               `json:"category"`
+	Totals                map[ingparse.StandardUnit]float64 `json:"totals,omitempty"`
+	Display               string                            `json:"display"` // e.g. "2 ¾ cups + 3 cloves"
+	Quantities            []quantityResponse                `json:"quantities"`
+	Items                 []itemResponse                    `json:"items,omitempty"`
+}
+
+type itemSectionResponse struct {
+	Category domain.ItemCategory `json:"category"`
+	Aisle    string              `json:"aisle,omitempty"`
+	Groups   []itemGroupResponse `json:"item_groups"`
+}
+
+type quantityResponse struct {
+	Amount  float64 `json:"amount"`
+	Units   string  `json:"units,omitempty"`
+	Display string  `json:"display"`
 }
 
 func domainItemToResponse(it domain.Item) itemResponse {
 	return itemResponse{
-		ID:            it.ID,
-		CreatedAt:     it.CreatedAt,
-		UpdatedAt:     it.UpdatedAt,
-		GroceryListID: it.GroceryListID,
-		MealID:        it.MealID,
-		IngredientID:  it.IngredientID,
-		Name:          it.Name,
-		Description:   it.Description,
+		ID:                    it.ID,
+		CreatedAt:             it.CreatedAt,
+		UpdatedAt:             it.UpdatedAt,
+		GroceryListID:         it.GroceryListID,
+		MealID:                it.MealID,
+		IngredientID:          it.IngredientID,
+		CanonicalIngredientID: it.CanonicalIngredientID,
+		Name:                  it.Name,
+		Description:           it.Description,
 		Measure: measureResponse{
 			OriginalAmount: it.Amount,
 			OriginalUnits:  it.Units,
 			StandardAmount: it.StandardAmount,
 			StandardUnits:  it.StandardUnits.String(),
 		},
-		Status: it.Status.String(),
+		Status:   it.Status.String(),
+		Category: it.Category,
 	}
 }
 
@@ -55,10 +75,34 @@ func domainItemGroupToResponse(ig domain.ItemGroup) itemGroupResponse {
 	for i, it := range ig.Items {
 		items[i] = domainItemToResponse(it)
 	}
+	quantities := make([]quantityResponse, len(ig.Quantities))
+	for i, q := range ig.Quantities {
+		quantities[i] = quantityResponse{
+			Amount:  q.Amount,
+			Units:   q.Units,
+			Display: q.String(),
+		}
+	}
 	return itemGroupResponse{
-		Name:   ig.Name,
-		Totals: ig.Totals,
-		Items:  items,
+		Name:                  ig.Name,
+		CanonicalIngredientID: ig.CanonicalIngredientID,
+		Category:              ig.Category,
+		Totals:                ig.Totals,
+		Display:               ingparse.FormatQuantities(ig.Quantities),
+		Quantities:            quantities,
+		Items:                 items,
+	}
+}
+
+func domainItemSectionToResponse(section domain.ItemSection) itemSectionResponse {
+	groups := make([]itemGroupResponse, len(section.Groups))
+	for i, group := range section.Groups {
+		groups[i] = domainItemGroupToResponse(group)
+	}
+	return itemSectionResponse{
+		Category: section.Category,
+		Aisle:    section.Aisle,
+		Groups:   groups,
 	}
 }
 
@@ -99,7 +143,7 @@ func (c *Config) handlePostItem() http.HandlerFunc {
 			return
 		}
 
-		item, err := c.Domain.CreateItem(r.Context(), groceryList, reqBody.Name, reqBody.Description, float64(reqBody.Amount), reqBody.Units)
+		item, err := c.Domain.CreateItem(r.Context(), user, groceryList, reqBody.Name, reqBody.Description, float64(reqBody.Amount), reqBody.Units)
 		if err != nil {
 			respondWithDomainError(w, err)
 			return
@@ -140,8 +184,9 @@ func (c *Config) handleGetItem() http.HandlerFunc {
 
 func (c *Config) handleGetItemsForGroceryList() http.HandlerFunc {
 	type response struct {
-		Items  []itemResponse      `json:"items,omitempty"`
-		Groups []itemGroupResponse `json:"item_groups,omitempty"`
+		Items    []itemResponse        `json:"items,omitempty"`
+		Groups   []itemGroupResponse   `json:"item_groups,omitempty"`
+		Sections []itemSectionResponse `json:"sections,omitempty"`
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -166,13 +211,36 @@ func (c *Config) handleGetItemsForGroceryList() http.HandlerFunc {
 			return
 		}
 
-		if r.URL.Query().Has("grouped") && r.URL.Query().Has("ungrouped") {
+		if r.URL.Query().Has("ungrouped") && (r.URL.Query().Has("grouped") || r.URL.Query().Has("store")) {
 			respondWithError(w, http.StatusConflict, "Conflicting query parameters")
 			return
 		}
 
 		resBody := response{}
-		if r.URL.Query().Has("grouped") {
+		if r.URL.Query().Has("store") {
+			// groups sorted into the sections of the store, in walking order
+			storeID, err := strconv.ParseInt(r.URL.Query().Get("store"), 10, 64)
+			if err != nil {
+				respondWithError(w, http.StatusBadRequest, "Store id is not an integer")
+				return
+			}
+
+			store, err := c.Domain.GetStore(r.Context(), user, storeID)
+			if err != nil {
+				respondWithDomainError(w, err)
+				return
+			}
+
+			sections, err := c.Domain.GetItemSectionsForGroceryList(r.Context(), groceryList, store)
+			if err != nil {
+				respondWithDomainError(w, err)
+				return
+			}
+
+			for _, section := range sections {
+				resBody.Sections = append(resBody.Sections, domainItemSectionToResponse(section))
+			}
+		} else if r.URL.Query().Has("grouped") {
 			itemGroups, err := c.Domain.GetItemGroupsForGroceryList(r.Context(), groceryList)
 			if err != nil {
 				respondWithDomainError(w, err)
@@ -184,7 +252,23 @@ func (c *Config) handleGetItemsForGroceryList() http.HandlerFunc {
 				resBody.Groups = append(resBody.Groups, r)
 			}
 		} else {
-			items, err := c.Domain.GetItemsForGroceryList(r.Context(), groceryList)
+			// only the ungrouped items are paginated; groups span the whole list
+			listParams, err := listParamsFromQuery(r)
+			if err != nil {
+				respondWithDomainError(w, err)
+				return
+			}
+
+			params := domain.ItemListParams{ListParams: listParams}
+			if s := r.URL.Query().Get("status"); s != "" {
+				params.Status, err = domain.ItemStatusFromString(s)
+				if err != nil {
+					respondWithError(w, http.StatusBadRequest, err.Error())
+					return
+				}
+			}
+
+			items, next, err := c.Domain.ListItemsForGroceryList(r.Context(), groceryList, params)
 			if err != nil {
 				respondWithDomainError(w, err)
 				return
@@ -194,6 +278,8 @@ func (c *Config) handleGetItemsForGroceryList() http.HandlerFunc {
 				r := domainItemToResponse(item)
 				resBody.Items = append(resBody.Items, r)
 			}
+
+			setNextLink(w, r, next)
 		}
 		respondWithJSON(w, http.StatusOK, resBody)
 	}
@@ -236,7 +322,8 @@ func (c *Config) handleGetItemsForGroceryListByName() http.HandlerFunc {
 
 func (c *Config) handleMarkItemStatus() http.HandlerFunc {
 	type request struct {
-		Status string `json:"status"`
+		Status  string `json:"status"`
+		Restock bool   `json:"restock"`
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -274,7 +361,7 @@ func (c *Config) handleMarkItemStatus() http.HandlerFunc {
 			return
 		}
 
-		item, err = c.Domain.MarkItemStatus(r.Context(), item, status)
+		item, err = c.Domain.MarkItemStatus(r.Context(), user, item, status, reqBody.Restock)
 		if err != nil {
 			respondWithDomainError(w, err)
 			return
@@ -283,3 +370,82 @@ func (c *Config) handleMarkItemStatus() http.HandlerFunc {
 		respondWithJSON(w, http.StatusOK, domainItemToResponse(item))
 	}
 }
+
+func (c *Config) handlePutItemCategory() http.HandlerFunc {
+	type request struct {
+		Category string `json:"category"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		category, err := domain.ItemCategoryFromString(reqBody.Category)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		itemID, err := strconv.ParseInt(chi.URLParam(r, "item_id"), 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		item, err := c.Domain.GetItem(r.Context(), user, itemID)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		item, err = c.Domain.SetItemCategory(r.Context(), user, item, category)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainItemToResponse(item))
+	}
+}
+
+func (c *Config) handleDeleteItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		idString := chi.URLParam(r, "item_id")
+
+		itemID, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		item, err := c.Domain.GetItem(r.Context(), user, itemID)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		err = c.Domain.DeleteItem(r.Context(), user, item)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/domerr"
+)
+
+type plannedMealResponse struct {
+	ID          int64           `json:"id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	OwnerID     int64           `json:"owner_id"`
+	HouseholdID int64           `json:"household_id,omitempty"`
+	Date        string          `json:"date"`
+	Slot        domain.MealSlot `json:"slot"`
+	Name        string          `json:"name,omitempty"`
+	RecipeID    int64           `json:"recipe_id"`
+	RecipeName  string          `json:"recipe_name"`
+	Servings    int64           `json:"servings,omitempty"`
+	MealID      int64           `json:"meal_id,omitempty"`
+}
+
+func domainPlannedMealToResponse(pm domain.PlannedMeal) plannedMealResponse {
+	return plannedMealResponse{
+		ID:          pm.ID,
+		CreatedAt:   pm.CreatedAt,
+		UpdatedAt:   pm.UpdatedAt,
+		OwnerID:     pm.OwnerID,
+		HouseholdID: pm.HouseholdID,
+		Date:        pm.Date.Format(domain.DateLayout),
+		Slot:        pm.Slot,
+		Name:        pm.SlotName,
+		RecipeID:    pm.Recipe.ID,
+		RecipeName:  pm.Recipe.Name,
+		Servings:    pm.Servings,
+		MealID:      pm.MealID,
+	}
+}
+
+// plannedMealRequest is the body of requests that create or replace a planned
+// meal.
+type plannedMealRequest struct {
+	HouseholdID int64  `json:"household_id"`
+	Date        string `json:"date"`
+	Slot        string `json:"slot"`
+	Name        string `json:"name"`
+	RecipeID    int64  `json:"recipe_id"`
+	Servings    int64  `json:"servings"`
+}
+
+func (p plannedMealRequest) params() (domain.PlannedMealParams, error) {
+	date, err := time.Parse(domain.DateLayout, p.Date)
+	if err != nil {
+		return domain.PlannedMealParams{}, domerr.ErrInvalidInput
+	}
+
+	slot, err := domain.MealSlotFromString(p.Slot)
+	if err != nil {
+		return domain.PlannedMealParams{}, domerr.ErrInvalidInput
+	}
+
+	return domain.PlannedMealParams{
+		HouseholdID: p.HouseholdID,
+		Date:        date,
+		Slot:        slot,
+		SlotName:    p.Name,
+		RecipeID:    p.RecipeID,
+		Servings:    p.Servings,
+	}, nil
+}
+
+// parseDateRange parses the first and last day of a range of the meal plan.
+// Without a first day the range starts today, and without a last day it lasts
+// a week.
+func parseDateRange(from string, to string) (time.Time, time.Time, error) {
+	start, err := time.Parse(domain.DateLayout, time.Now().Format(domain.DateLayout))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if from != "" {
+		start, err = time.Parse(domain.DateLayout, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, domerr.ErrInvalidInput
+		}
+	}
+
+	end := start.AddDate(0, 0, 6)
+	if to != "" {
+		end, err = time.Parse(domain.DateLayout, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, domerr.ErrInvalidInput
+		}
+	}
+
+	return start, end, nil
+}
+
+// plannedMealFromRequest fetches the planned meal named by the planned_meal_id
+// url parameter, responding with an error if that fails.
+func (c *Config) plannedMealFromRequest(w http.ResponseWriter, r *http.Request, user domain.User) (domain.PlannedMeal, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "planned_meal_id"), 10, 64)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+		return domain.PlannedMeal{}, false
+	}
+
+	plannedMeal, err := c.Domain.GetPlannedMeal(r.Context(), user, id)
+	if err != nil {
+		respondWithDomainError(w, err)
+		return domain.PlannedMeal{}, false
+	}
+
+	return plannedMeal, true
+}
+
+func (c *Config) handleGetMealPlan() http.HandlerFunc {
+	type response = []plannedMealResponse
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		from, to, err := parseDateRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		plannedMeals, err := c.Domain.GetPlannedMeals(r.Context(), user, from, to)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		res := make(response, len(plannedMeals))
+		for i, plannedMeal := range plannedMeals {
+			res[i] = domainPlannedMealToResponse(plannedMeal)
+		}
+
+		respondWithJSON(w, http.StatusOK, res)
+	}
+}
+
+func (c *Config) handlePostPlannedMeal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := plannedMealRequest{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		params, err := reqBody.params()
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		plannedMeal, err := c.Domain.CreatePlannedMeal(r.Context(), user, params)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, domainPlannedMealToResponse(plannedMeal))
+	}
+}
+
+func (c *Config) handleGetPlannedMeal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		plannedMeal, ok := c.plannedMealFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainPlannedMealToResponse(plannedMeal))
+	}
+}
+
+func (c *Config) handlePutPlannedMeal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := plannedMealRequest{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		params, err := reqBody.params()
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		plannedMeal, ok := c.plannedMealFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		plannedMeal, err = c.Domain.UpdatePlannedMeal(r.Context(), user, plannedMeal, params)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainPlannedMealToResponse(plannedMeal))
+	}
+}
+
+func (c *Config) handleDeletePlannedMeal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		plannedMeal, ok := c.plannedMealFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		err := c.Domain.DeletePlannedMeal(r.Context(), user, plannedMeal)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handlePostMealPlanGroceryList puts the meals planned in a range of days on a
+// grocery list. Without a grocery list id, a new list is created for them.
+func (c *Config) handlePostMealPlanGroceryList() http.HandlerFunc {
+	type request struct {
+		From          string `json:"from"`
+		To            string `json:"to"`
+		GroceryListID int64  `json:"grocery_list_id"`
+		Name          string `json:"name"`
+	}
+
+	type response struct {
+		GroceryList   groceryListResponse `json:"grocery_list"`
+		AddedMeals    []mealResponse      `json:"added_meals"`
+		RescaledMeals []mealResponse      `json:"rescaled_meals"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		from, to, err := parseDateRange(reqBody.From, reqBody.To)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		var groceryList domain.GroceryList
+		status := http.StatusOK
+		if reqBody.GroceryListID != 0 {
+			groceryList, err = c.Domain.GetGroceryList(r.Context(), user, reqBody.GroceryListID)
+		} else {
+			name := reqBody.Name
+			if name == "" {
+				name = "Meal plan " + from.Format(domain.DateLayout) + " to " + to.Format(domain.DateLayout)
+			}
+			groceryList, err = c.Domain.CreateGroceryList(r.Context(), user, name)
+			status = http.StatusCreated
+		}
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		added, rescaled, err := c.Domain.BuildGroceryListFromMealPlan(r.Context(), user, groceryList, from, to)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		res := response{
+			GroceryList:   domainGroceryListToResponse(groceryList),
+			AddedMeals:    make([]mealResponse, len(added)),
+			RescaledMeals: make([]mealResponse, len(rescaled)),
+		}
+		for i, meal := range added {
+			res.AddedMeals[i] = domainMealToResponse(meal, nil)
+		}
+		for i, meal := range rescaled {
+			res.RescaledMeals[i] = domainMealToResponse(meal, nil)
+		}
+
+		respondWithJSON(w, status, res)
+	}
+}
@@ -16,6 +16,7 @@ type mealResponse struct {
 	UpdatedAt     time.Time      `json:"updated_at"`
 	GroceryListID int64          `json:"grocery_list_id"`
 	RecipeID      int64          `json:"recipe_id"`
+	Servings      int64          `json:"servings,omitempty"`
 	Items         []itemResponse `json:"items"`
 }
 
@@ -30,6 +31,7 @@ func domainMealToResponse(m domain.Meal, its []domain.Item) mealResponse {
 		UpdatedAt:     m.UpdatedAt,
 		GroceryListID: m.GroceryListID,
 		RecipeID:      m.Recipe.ID,
+		Servings:      m.Servings,
 		Items:         items,
 	}
 }
@@ -38,6 +40,7 @@ func (c *Config) handlePostMealInGroceryList() http.HandlerFunc {
 
 	type request struct {
 		RecipeID int64 `json:"recipe_id"`
+		Servings int64 `json:"servings"` // 0 makes the recipe as written
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -69,7 +72,7 @@ func (c *Config) handlePostMealInGroceryList() http.HandlerFunc {
 			return
 		}
 
-		meal, err := c.Domain.CreateMeal(r.Context(), user, groceryList, reqBody.RecipeID)
+		meal, err := c.Domain.CreateMeal(r.Context(), user, groceryList, reqBody.RecipeID, reqBody.Servings)
 		if err != nil {
 			respondWithDomainError(w, err)
 			return
@@ -136,3 +139,85 @@ func (c *Config) handleGetMealsInGroceryList() http.HandlerFunc {
 		respondWithJSON(w, http.StatusOK, resBody)
 	}
 }
+
+func (c *Config) handlePutMealServings() http.HandlerFunc {
+	type request struct {
+		Servings int64 `json:"servings"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := request{}
+
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		idString := chi.URLParam(r, "meal_id")
+
+		mealID, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		meal, err := c.Domain.GetMeal(r.Context(), user, mealID)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		meal, err = c.Domain.SetMealServings(r.Context(), user, meal, reqBody.Servings)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		items, err := c.Domain.GetItemsForMeal(r.Context(), meal)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainMealToResponse(meal, items))
+	}
+}
+
+func (c *Config) handleDeleteMeal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		idString := chi.URLParam(r, "meal_id")
+
+		mealID, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		meal, err := c.Domain.GetMeal(r.Context(), user, mealID)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		err = c.Domain.DeleteMeal(r.Context(), user, meal)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/domerr"
+)
+
+// listParamsFromQuery reads the pagination, filter and sort parameters shared
+// by the collection endpoints.
+func listParamsFromQuery(r *http.Request) (domain.ListParams, error) {
+	query := r.URL.Query()
+
+	params := domain.ListParams{
+		Cursor:       query.Get("cursor"),
+		NameContains: query.Get("name"),
+	}
+
+	if s := query.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil {
+			return domain.ListParams{}, domerr.ErrInvalidPageLimit
+		}
+		params.Limit = limit
+	}
+
+	if s := query.Get("sort"); s != "" {
+		sort, err := domain.SortOrderFromString(s)
+		if err != nil {
+			return domain.ListParams{}, err
+		}
+		params.Sort = sort
+	}
+
+	if s := query.Get("created_after"); s != "" {
+		createdAfter, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return domain.ListParams{}, domerr.ErrInvalidInput
+		}
+		params.CreatedAfter = createdAfter
+	}
+
+	return params, nil
+}
+
+// setNextLink points the Link header at the page after the current one. The
+// link repeats the request with the cursor replaced, so filters and sort
+// carry over.
+func setNextLink(w http.ResponseWriter, r *http.Request, next string) {
+	if next == "" {
+		return
+	}
+
+	query := r.URL.Query()
+	query.Set("cursor", next)
+
+	u := *r.URL
+	u.RawQuery = query.Encode()
+
+	w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, u.RequestURI()))
+}
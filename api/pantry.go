@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/ingparse"
+)
+
+type pantryItemResponse struct {
+	ID                    int64                 `json:"id"`
+	CreatedAt             time.Time             `json:"created_at"`
+	UpdatedAt             time.Time             `json:"updated_at"`
+	HouseholdID           int64                 `json:"household_id,omitempty"`
+	CanonicalIngredientID int64                 `json:"canonical_ingredient_id"`
+	Name                  string                `json:"name"`
+	StandardAmount        float64               `json:"standard_amount"`
+	StandardUnits         ingparse.StandardUnit `json:"standard_units"`
+	Reserved              float64               `json:"reserved"`
+	AlwaysStocked         bool                  `json:"always_stocked"`
+}
+
+func domainPantryItemToResponse(item domain.PantryItem) pantryItemResponse {
+	return pantryItemResponse{
+		ID:                    item.ID,
+		CreatedAt:             item.CreatedAt,
+		UpdatedAt:             item.UpdatedAt,
+		HouseholdID:           item.HouseholdID,
+		CanonicalIngredientID: item.CanonicalIngredientID,
+		Name:                  item.Name,
+		StandardAmount:        item.StandardAmount,
+		StandardUnits:         item.StandardUnits,
+		Reserved:              item.Reserved,
+		AlwaysStocked:         item.AlwaysStocked,
+	}
+}
+
+// pantryHouseholdID returns the id in the household_id url parameter, or 0 on
+// routes for the pantry of the user, responding with an error if it is not an
+// integer.
+func pantryHouseholdID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	param := chi.URLParam(r, "household_id")
+	if param == "" {
+		return 0, true
+	}
+
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+		return 0, false
+	}
+
+	return id, true
+}
+
+// pantryItemFromRequest fetches the pantry item named by the pantry_item_id url
+// parameter, responding with an error if that fails.
+func (c *Config) pantryItemFromRequest(w http.ResponseWriter, r *http.Request, user domain.User) (domain.PantryItem, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "pantry_item_id"), 10, 64)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+		return domain.PantryItem{}, false
+	}
+
+	item, err := c.Domain.GetPantryItem(r.Context(), user, id)
+	if err != nil {
+		respondWithDomainError(w, err)
+		return domain.PantryItem{}, false
+	}
+
+	return item, true
+}
+
+// handleGetPantry serves the pantry of the user, and on the household routes,
+// the pantry of the household.
+func (c *Config) handleGetPantry() http.HandlerFunc {
+	type response = []pantryItemResponse
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		householdID, ok := pantryHouseholdID(w, r)
+		if !ok {
+			return
+		}
+
+		items, err := c.Domain.GetPantry(r.Context(), user, householdID)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		res := make(response, len(items))
+		for i, item := range items {
+			res[i] = domainPantryItemToResponse(item)
+		}
+
+		respondWithJSON(w, http.StatusOK, res)
+	}
+}
+
+func (c *Config) handlePostPantryItem() http.HandlerFunc {
+	type request struct {
+		Name          string  `json:"name"`
+		Amount        float64 `json:"amount"`
+		Units         string  `json:"units"`
+		AlwaysStocked bool    `json:"always_stocked"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		householdID, ok := pantryHouseholdID(w, r)
+		if !ok {
+			return
+		}
+
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		item, err := c.Domain.CreatePantryItem(r.Context(), user, householdID, reqBody.Name, reqBody.Amount, reqBody.Units, reqBody.AlwaysStocked)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, domainPantryItemToResponse(item))
+	}
+}
+
+func (c *Config) handleGetPantryItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		item, ok := c.pantryItemFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainPantryItemToResponse(item))
+	}
+}
+
+func (c *Config) handlePutPantryItem() http.HandlerFunc {
+	type request struct {
+		Amount        float64 `json:"amount"`
+		Units         string  `json:"units"`
+		AlwaysStocked bool    `json:"always_stocked"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		item, ok := c.pantryItemFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		item, err = c.Domain.UpdatePantryItem(r.Context(), user, item, reqBody.Amount, reqBody.Units, reqBody.AlwaysStocked)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainPantryItemToResponse(item))
+	}
+}
+
+func (c *Config) handleDeletePantryItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		item, ok := c.pantryItemFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		err := c.Domain.DeletePantryItem(r.Context(), user, item)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domain"
+)
+
+type recipeImageResponse struct {
+	Url         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	SourceUrl   string    `json:"source_url,omitempty"`
+}
+
+func domainRecipeImageToResponse(image domain.RecipeImage) recipeImageResponse {
+	return recipeImageResponse{
+		Url:         fmt.Sprintf("/v1/recipes/%d/image", image.RecipeID),
+		CreatedAt:   image.CreatedAt,
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		SourceUrl:   image.SourceURL,
+	}
+}
+
+// imageETag is the entity tag of an image. Every image gets a new key, so the
+// last element of the key is enough to tell them apart.
+func imageETag(image domain.RecipeImage) string {
+	return `"` + path.Base(image.Key) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header lists the etag.
+func etagMatches(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// handleGetRecipeImage serves the image of a recipe. Clients may cache it, but
+// have to revalidate it with its ETag, since the url stays the same when the
+// image is replaced.
+func (c *Config) handleGetRecipeImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		recipe, ok := c.recipeFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		image, err := c.Domain.GetRecipeImage(r.Context(), user, recipe)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		etag := imageETag(image)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "private, no-cache")
+
+		// answered before the image is read from the blob store
+		if match := r.Header.Get("If-None-Match"); match != "" && etagMatches(match, etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		blob, err := c.Domain.OpenRecipeImage(r.Context(), image)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+		defer blob.Close()
+
+		data, err := io.ReadAll(io.LimitReader(blob, domain.MaxImageSize+1))
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Unable to read image")
+			return
+		}
+
+		w.Header().Set("Content-Type", image.ContentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		// ServeContent answers the other conditional and range requests
+		http.ServeContent(w, r, "", image.CreatedAt, bytes.NewReader(data))
+	}
+}
+
+// handlePutRecipeImage replaces the image of a recipe with the request body.
+func (c *Config) handlePutRecipeImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		recipe, ok := c.recipeFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		body := http.MaxBytesReader(w, r.Body, domain.MaxImageSize)
+		image, err := c.Domain.SetRecipeImage(r.Context(), user, recipe, body)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondWithError(w, http.StatusRequestEntityTooLarge, "Unable to read request body")
+			return
+		}
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainRecipeImageToResponse(image))
+	}
+}
+
+func (c *Config) handleDeleteRecipeImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		recipe, ok := c.recipeFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		err := c.Domain.DeleteRecipeImage(r.Context(), user, recipe)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
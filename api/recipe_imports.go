@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+)
+
+type recipeImportResponse struct {
+	ID        int64                     `json:"id"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+	Url       string                    `json:"url"`
+	Status    domain.RecipeImportStatus `json:"status"`
+	RecipeID  int64                     `json:"recipe_id,omitempty"`
+	ErrorCode string                    `json:"error_code,omitempty"`
+}
+
+func domainRecipeImportToResponse(ri domain.RecipeImport) recipeImportResponse {
+	return recipeImportResponse{
+		ID:        ri.ID,
+		CreatedAt: ri.CreatedAt,
+		UpdatedAt: ri.UpdatedAt,
+		Url:       ri.Url,
+		Status:    ri.Status,
+		RecipeID:  ri.RecipeID,
+		ErrorCode: ri.ErrorCode,
+	}
+}
+
+func (c *Config) handlePostRecipeImport() http.HandlerFunc {
+	type request struct {
+		Url string `json:"url"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		imports, err := c.Domain.CreateRecipeImports(r.Context(), user, []string{reqBody.Url})
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusAccepted, domainRecipeImportToResponse(imports[0]))
+	}
+}
+
+func (c *Config) handlePostRecipeImportBatch() http.HandlerFunc {
+	type request struct {
+		Urls []string `json:"urls"`
+	}
+
+	type response = []recipeImportResponse
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		imports, err := c.Domain.CreateRecipeImports(r.Context(), user, reqBody.Urls)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		var resBody response = make([]recipeImportResponse, len(imports))
+		for i, ri := range imports {
+			resBody[i] = domainRecipeImportToResponse(ri)
+		}
+
+		respondWithJSON(w, http.StatusAccepted, resBody)
+	}
+}
+
+func (c *Config) handleGetRecipeImports() http.HandlerFunc {
+	type response = []recipeImportResponse
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		imports, err := c.Domain.GetRecipeImportsForUser(r.Context(), user)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		var resBody response = make([]recipeImportResponse, len(imports))
+		for i, ri := range imports {
+			resBody[i] = domainRecipeImportToResponse(ri)
+		}
+
+		respondWithJSON(w, http.StatusOK, resBody)
+	}
+}
+
+func (c *Config) handleGetRecipeImport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		idString := chi.URLParam(r, "recipe_import_id")
+
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		ri, err := c.Domain.GetRecipeImport(r.Context(), user, id)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainRecipeImportToResponse(ri))
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/domerr"
+)
+
+type recipeSearchResultResponse struct {
+	Recipe     recipeResponse         `json:"recipe"`
+	Highlights recipeSearchHighlights `json:"highlights"`
+	Score      float64                `json:"score"`
+}
+
+type recipeSearchHighlights struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Ingredients string `json:"ingredients,omitempty"`
+}
+
+func domainRecipeSearchResultToResponse(result domain.RecipeSearchResult) recipeSearchResultResponse {
+	return recipeSearchResultResponse{
+		Recipe: domainRecipeToResponse(result.Recipe, nil, nil),
+		Highlights: recipeSearchHighlights{
+			Name:        result.NameHighlight,
+			Description: result.DescriptionSnippet,
+			Ingredients: result.IngredientsSnippet,
+		},
+		Score: result.Score,
+	}
+}
+
+func (c *Config) handleSearchRecipes() http.HandlerFunc {
+	type response []recipeSearchResultResponse
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		q := r.URL.Query().Get("q")
+		if q == "" {
+			respondWithError(w, http.StatusBadRequest, "Missing search query q")
+			return
+		}
+
+		var limit int
+		if s := r.URL.Query().Get("limit"); s != "" {
+			var err error
+			limit, err = strconv.Atoi(s)
+			if err != nil {
+				respondWithDomainError(w, domerr.ErrInvalidPageLimit)
+				return
+			}
+		}
+
+		results, err := c.Domain.SearchRecipesForUser(r.Context(), user, q, limit)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		resBody := make(response, len(results))
+		for i, result := range results {
+			resBody[i] = domainRecipeSearchResultToResponse(result)
+		}
+
+		respondWithJSON(w, http.StatusOK, resBody)
+	}
+}
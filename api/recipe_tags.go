@@ -0,0 +1,174 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+)
+
+type recipeTagResponse struct {
+	Name      string         `json:"name"`
+	Kind      domain.TagKind `json:"kind"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+func domainRecipeTagToResponse(tag domain.RecipeTag) recipeTagResponse {
+	return recipeTagResponse{
+		Name:      tag.Name,
+		Kind:      tag.Kind,
+		CreatedAt: tag.CreatedAt,
+	}
+}
+
+// recipeFromRequest fetches the recipe named by the recipe_id url parameter,
+// responding with an error if that fails.
+func (c *Config) recipeFromRequest(w http.ResponseWriter, r *http.Request, user domain.User) (domain.Recipe, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "recipe_id"), 10, 64)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Recipe id is not an integer")
+		return domain.Recipe{}, false
+	}
+
+	recipe, err := c.Domain.GetRecipe(r.Context(), user, id)
+	if err != nil {
+		respondWithDomainError(w, err)
+		return domain.Recipe{}, false
+	}
+
+	return recipe, true
+}
+
+func (c *Config) handleGetRecipeTags() http.HandlerFunc {
+	type response = []recipeTagResponse
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		recipe, ok := c.recipeFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		tags, err := c.Domain.GetTagsForRecipe(r.Context(), user, recipe)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		res := make(response, len(tags))
+		for i, tag := range tags {
+			res[i] = domainRecipeTagToResponse(tag)
+		}
+
+		respondWithJSON(w, http.StatusOK, res)
+	}
+}
+
+func (c *Config) handlePostRecipeTag() http.HandlerFunc {
+	type request struct {
+		Name string `json:"name"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		recipe, ok := c.recipeFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		tag, err := c.Domain.AddRecipeTag(r.Context(), user, recipe, reqBody.Name)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, domainRecipeTagToResponse(tag))
+	}
+}
+
+func (c *Config) handleDeleteRecipeTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		// the router matches the escaped path when it has characters that are
+		// escaped in more than one way, so the tag is left escaped too
+		name := chi.URLParam(r, "tag")
+		if r.URL.RawPath != "" {
+			unescaped, err := url.PathUnescape(name)
+			if err != nil {
+				respondWithError(w, http.StatusBadRequest, "Tag is not escaped correctly")
+				return
+			}
+			name = unescaped
+		}
+
+		recipe, ok := c.recipeFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		err := c.Domain.DeleteRecipeTag(r.Context(), user, recipe, name)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handleGetTags serves the tags of the recipes the user can see, with how many
+// recipes have each.
+func (c *Config) handleGetTags() http.HandlerFunc {
+	type tagCount struct {
+		Name  string `json:"name"`
+		Count int64  `json:"count"`
+	}
+	type response = []tagCount
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		tags, err := c.Domain.GetTagsForUser(r.Context(), user)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		res := make(response, len(tags))
+		for i, tag := range tags {
+			res[i] = tagCount{Name: tag.Name, Count: tag.Count}
+		}
+
+		respondWithJSON(w, http.StatusOK, res)
+	}
+}
@@ -2,29 +2,89 @@ package api
 
 import (
 	"encoding/json"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/misc"
 )
 
 type recipeResponse struct {
-	ID          int64                `json:"id"`
-	CreatedAt   time.Time            `json:"created_at"`
-	UpdatedAt   time.Time            `json:"updated_at"`
-	Name        string               `json:"name"`
-	Description string               `json:"description,omitempty"`
-	Url         string               `json:"url,omitempty"`
-	PrepTime    string               `json:"prep_time,omitempty"`
-	CookTime    string               `json:"cook_time,omitempty"`
-	TotalTime   string               `json:"total_time,omitempty"`
-	OwnerId     int64                `json:"owner_id"`
-	Ingredients []ingredientResponse `json:"ingredients,omitempty"`
+	ID           int64                 `json:"id"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	Name         string                `json:"name"`
+	Description  string                `json:"description,omitempty"`
+	Url          string                `json:"url,omitempty"`
+	PrepTime     string                `json:"prep_time,omitempty"`
+	CookTime     string                `json:"cook_time,omitempty"`
+	TotalTime    string                `json:"total_time,omitempty"`
+	Servings     int64                 `json:"servings,omitempty"`
+	OwnerId      int64                 `json:"owner_id"`
+	HouseholdID  int64                 `json:"household_id,omitempty"`
+	Ingredients  []ingredientResponse  `json:"ingredients,omitempty"`
+	Instructions []instructionResponse `json:"instructions,omitempty"`
 }
 
-func domainRecipeToResponse(recipe domain.Recipe, ingredients []domain.Ingredient) recipeResponse {
+// recipeTimes are the times of a recipe in a request body, written as ISO 8601
+// durations. Times that are missing or empty are nil.
+type recipeTimes struct {
+	PrepTime  *string `json:"prep_time"`
+	CookTime  *string `json:"cook_time"`
+	TotalTime *string `json:"total_time"`
+}
+
+func (t recipeTimes) parse() (prep *time.Duration, cook *time.Duration, total *time.Duration, err error) {
+	parse := func(s *string) (*time.Duration, error) {
+		if s == nil {
+			return nil, nil
+		}
+		if *s == "" {
+			d := time.Duration(0)
+			return &d, nil
+		}
+		d, err := misc.ParseISO8601Duration(*s)
+		if err != nil {
+			return nil, domerr.ErrInvalidDuration
+		}
+		return &d, nil
+	}
+
+	if prep, err = parse(t.PrepTime); err != nil {
+		return nil, nil, nil, err
+	}
+	if cook, err = parse(t.CookTime); err != nil {
+		return nil, nil, nil, err
+	}
+	if total, err = parse(t.TotalTime); err != nil {
+		return nil, nil, nil, err
+	}
+	return prep, cook, total, nil
+}
+
+// formatRecipeTime writes a recipe time as an ISO 8601 duration, or "" if the
+// recipe does not say.
+func formatRecipeTime(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return misc.FormatISO8601Duration(d)
+}
+
+// durationOrZero dereferences an optional recipe time.
+func durationOrZero(d *time.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return *d
+}
+
+func domainRecipeToResponse(recipe domain.Recipe, ingredients []domain.Ingredient, instructions []domain.Instruction) recipeResponse {
 	var responseIngredients []ingredientResponse
 
 	if ingredients != nil {
@@ -34,24 +94,42 @@ func domainRecipeToResponse(recipe domain.Recipe, ingredients []domain.Ingredien
 		}
 	}
 
+	var responseInstructions []instructionResponse
+
+	if instructions != nil {
+		responseInstructions = make([]instructionResponse, 0, len(instructions))
+		for _, instruction := range instructions {
+			responseInstructions = append(responseInstructions, domainInstructionToResponse(instruction))
+		}
+	}
+
 	return recipeResponse{
-		ID:          recipe.ID,
-		CreatedAt:   recipe.CreatedAt,
-		UpdatedAt:   recipe.UpdatedAt,
-		Name:        recipe.Name,
-		Description: (recipe.Description),
-		Url:         (recipe.Url),
-		PrepTime:    (recipe.PrepTime),
-		CookTime:    (recipe.CookTime),
-		TotalTime:   (recipe.TotalTime),
-		OwnerId:     recipe.OwnerID,
-		Ingredients: responseIngredients,
+		ID:           recipe.ID,
+		CreatedAt:    recipe.CreatedAt,
+		UpdatedAt:    recipe.UpdatedAt,
+		Name:         recipe.Name,
+		Description:  (recipe.Description),
+		Url:          (recipe.Url),
+		PrepTime:     formatRecipeTime(recipe.PrepTime),
+		CookTime:     formatRecipeTime(recipe.CookTime),
+		TotalTime:    formatRecipeTime(recipe.TotalTime),
+		Servings:     recipe.Servings,
+		OwnerId:      recipe.OwnerID,
+		HouseholdID:  recipe.HouseholdID,
+		Ingredients:  responseIngredients,
+		Instructions: responseInstructions,
 	}
 }
 
 func (c *Config) handlePostRecipe() http.HandlerFunc {
 	type request struct {
-		Url string `json:"url"`
+		recipeTimes
+		Url          string   `json:"url"`
+		Name         string   `json:"name"`
+		Description  string   `json:"description"`
+		Servings     int64    `json:"servings"`
+		Ingredients  []string `json:"ingredients"`
+		Instructions []string `json:"instructions"`
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -69,7 +147,94 @@ func (c *Config) handlePostRecipe() http.HandlerFunc {
 			return
 		}
 
-		recipe, err := c.Domain.CreateRecipeFromUrl(r.Context(), user, reqBody.Url)
+		var recipe domain.Recipe
+		if reqBody.Name == "" {
+			// without a name, the recipe is scraped from the url
+			if reqBody.Url == "" {
+				respondWithError(w, http.StatusBadRequest, "Either a url or a name is required")
+				return
+			}
+			recipe, err = c.Domain.CreateRecipeFromUrl(r.Context(), user, reqBody.Url)
+		} else {
+			var prepTime, cookTime, totalTime *time.Duration
+			prepTime, cookTime, totalTime, err = reqBody.recipeTimes.parse()
+			if err != nil {
+				respondWithDomainError(w, err)
+				return
+			}
+
+			recipe, err = c.Domain.CreateRecipe(r.Context(), user, domain.CreateRecipeParams{
+				Name:         reqBody.Name,
+				Description:  reqBody.Description,
+				Url:          reqBody.Url,
+				PrepTime:     durationOrZero(prepTime),
+				CookTime:     durationOrZero(cookTime),
+				TotalTime:    durationOrZero(totalTime),
+				Servings:     reqBody.Servings,
+				Ingredients:  reqBody.Ingredients,
+				Instructions: reqBody.Instructions,
+			})
+		}
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		ingredients, err := c.Domain.GetIngredientsForRecipe(r.Context(), user, recipe)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		instructions, err := c.Domain.GetInstructionsForRecipe(r.Context(), user, recipe)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		resBody := domainRecipeToResponse(recipe, ingredients, instructions)
+
+		respondWithJSON(w, http.StatusCreated, &resBody)
+	}
+}
+
+// maxRecipeDocumentSize limits the size of documents sent to the recipe import
+// endpoint.
+const maxRecipeDocumentSize = 5 << 20
+
+func (c *Config) handlePostRecipeDocument() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			respondWithError(w, http.StatusUnsupportedMediaType, "Content-Type must be text/html or application/ld+json")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRecipeDocumentSize))
+		if err != nil {
+			respondWithError(w, http.StatusRequestEntityTooLarge, "Unable to read request body")
+			return
+		}
+
+		// the url is optional; it picks a site specific scraper and is stored with the recipe
+		url := r.URL.Query().Get("url")
+
+		var recipe domain.Recipe
+		switch mediaType {
+		case "text/html":
+			recipe, err = c.Domain.CreateRecipeFromHTML(r.Context(), user, url, body)
+		case "application/ld+json", "application/json":
+			recipe, err = c.Domain.CreateRecipeFromJSONLD(r.Context(), user, url, body)
+		default:
+			respondWithError(w, http.StatusUnsupportedMediaType, "Content-Type must be text/html or application/ld+json")
+			return
+		}
 		if err != nil {
 			respondWithDomainError(w, err)
 			return
@@ -81,7 +246,13 @@ func (c *Config) handlePostRecipe() http.HandlerFunc {
 			return
 		}
 
-		resBody := domainRecipeToResponse(recipe, ingredients)
+		instructions, err := c.Domain.GetInstructionsForRecipe(r.Context(), user, recipe)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		resBody := domainRecipeToResponse(recipe, ingredients, instructions)
 
 		respondWithJSON(w, http.StatusCreated, &resBody)
 	}
@@ -118,7 +289,13 @@ func (c *Config) handleGetRecipe() http.HandlerFunc {
 			}
 		}
 
-		resBody := domainRecipeToResponse(recipe, ingredients)
+		instructions, err := c.Domain.GetInstructionsForRecipe(r.Context(), user, recipe)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		resBody := domainRecipeToResponse(recipe, ingredients, instructions)
 
 		respondWithJSON(w, http.StatusOK, resBody)
 	}
@@ -135,7 +312,32 @@ func (c *Config) handleGetRecipes() http.HandlerFunc {
 			return
 		}
 
-		recipes, err := c.Domain.GetRecipesForUser(r.Context(), user)
+		listParams, err := listParamsFromQuery(r)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		params := domain.RecipeListParams{ListParams: listParams}
+		if s := r.URL.Query().Get("max_total_time"); s != "" {
+			params.MaxTotalTime, err = misc.ParseISO8601Duration(s)
+			if err != nil || params.MaxTotalTime == 0 {
+				respondWithDomainError(w, domerr.ErrInvalidDuration)
+				return
+			}
+		}
+
+		params.Tags = r.URL.Query()["tag"]
+		switch r.URL.Query().Get("tag_match") {
+		case "", "any":
+		case "all":
+			params.MatchAllTags = true
+		default:
+			respondWithError(w, http.StatusBadRequest, "tag_match must be any or all")
+			return
+		}
+
+		recipes, next, err := c.Domain.ListRecipesForUser(r.Context(), user, params)
 		if err != nil {
 			respondWithDomainError(w, err)
 			return
@@ -154,10 +356,176 @@ func (c *Config) handleGetRecipes() http.HandlerFunc {
 				}
 			}
 
-			r := domainRecipeToResponse(recipe, ingredients)
+			r := domainRecipeToResponse(recipe, ingredients, nil)
 			resBody[i] = r
 		}
 
+		setNextLink(w, r, next)
 		respondWithJSON(w, http.StatusOK, resBody)
 	}
 }
+
+func (c *Config) handlePutRecipe() http.HandlerFunc {
+	type request struct {
+		recipeTimes
+		Name         string   `json:"name"`
+		Description  string   `json:"description"`
+		Url          string   `json:"url"`
+		Servings     int64    `json:"servings"`
+		Ingredients  []string `json:"ingredients"`
+		Instructions []string `json:"instructions"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		prepTime, cookTime, totalTime, err := reqBody.recipeTimes.parse()
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		// a PUT replaces the whole recipe, so missing fields are cleared
+		prep, cook, total := durationOrZero(prepTime), durationOrZero(cookTime), durationOrZero(totalTime)
+		ingredients := reqBody.Ingredients
+		if ingredients == nil {
+			ingredients = []string{}
+		}
+		instructions := reqBody.Instructions
+		if instructions == nil {
+			instructions = []string{}
+		}
+
+		c.updateRecipe(w, r, domain.UpdateRecipeParams{
+			Name:         &reqBody.Name,
+			Description:  &reqBody.Description,
+			Url:          &reqBody.Url,
+			PrepTime:     &prep,
+			CookTime:     &cook,
+			TotalTime:    &total,
+			Servings:     &reqBody.Servings,
+			Ingredients:  &ingredients,
+			Instructions: &instructions,
+		})
+	}
+}
+
+func (c *Config) handlePatchRecipe() http.HandlerFunc {
+	type request struct {
+		recipeTimes
+		Name         *string   `json:"name"`
+		Description  *string   `json:"description"`
+		Url          *string   `json:"url"`
+		Servings     *int64    `json:"servings"`
+		Ingredients  *[]string `json:"ingredients"`
+		Instructions *[]string `json:"instructions"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqBody := request{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		prepTime, cookTime, totalTime, err := reqBody.recipeTimes.parse()
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		c.updateRecipe(w, r, domain.UpdateRecipeParams{
+			Name:         reqBody.Name,
+			Description:  reqBody.Description,
+			Url:          reqBody.Url,
+			PrepTime:     prepTime,
+			CookTime:     cookTime,
+			TotalTime:    totalTime,
+			Servings:     reqBody.Servings,
+			Ingredients:  reqBody.Ingredients,
+			Instructions: reqBody.Instructions,
+		})
+	}
+}
+
+func (c *Config) updateRecipe(w http.ResponseWriter, r *http.Request, params domain.UpdateRecipeParams) {
+	idString := chi.URLParam(r, "recipe_id")
+
+	id, err := strconv.ParseInt(idString, 10, 64)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+		return
+	}
+
+	user, ok := r.Context().Value(ContextUserKey).(domain.User)
+	if !ok {
+		respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+		return
+	}
+
+	recipe, err := c.Domain.GetRecipe(r.Context(), user, id)
+	if err != nil {
+		respondWithDomainError(w, err)
+		return
+	}
+
+	recipe, err = c.Domain.UpdateRecipe(r.Context(), user, recipe, params)
+	if err != nil {
+		respondWithDomainError(w, err)
+		return
+	}
+
+	ingredients, err := c.Domain.GetIngredientsForRecipe(r.Context(), user, recipe)
+	if err != nil {
+		respondWithDomainError(w, err)
+		return
+	}
+
+	instructions, err := c.Domain.GetInstructionsForRecipe(r.Context(), user, recipe)
+	if err != nil {
+		respondWithDomainError(w, err)
+		return
+	}
+
+	resBody := domainRecipeToResponse(recipe, ingredients, instructions)
+
+	respondWithJSON(w, http.StatusOK, resBody)
+}
+
+func (c *Config) handleDeleteRecipe() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idString := chi.URLParam(r, "recipe_id")
+
+		id, err := strconv.ParseInt(idString, 10, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+			return
+		}
+
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		recipe, err := c.Domain.GetRecipe(r.Context(), user, id)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		err = c.Domain.DeleteRecipe(r.Context(), user, recipe)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/domerr"
+)
+
+type storeResponse struct {
+	ID        int64                `json:"id"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	Name      string               `json:"name"`
+	Aisles    []storeAisleResponse `json:"aisles"`
+}
+
+type storeAisleResponse struct {
+	Category domain.ItemCategory `json:"category"`
+	Name     string              `json:"name,omitempty"`
+}
+
+// storeRequest is the body of requests that create or replace a store.
+type storeRequest struct {
+	Name   string `json:"name"`
+	Aisles []struct {
+		Category string `json:"category"`
+		Name     string `json:"name"`
+	} `json:"aisles"`
+}
+
+func (s storeRequest) aisles() ([]domain.StoreAisle, error) {
+	aisles := make([]domain.StoreAisle, len(s.Aisles))
+	for i, aisle := range s.Aisles {
+		category, err := domain.ItemCategoryFromString(aisle.Category)
+		if err != nil {
+			return nil, domerr.ErrInvalidInput
+		}
+		aisles[i] = domain.StoreAisle{Category: category, Name: aisle.Name}
+	}
+	return aisles, nil
+}
+
+func domainStoreToResponse(store domain.Store) storeResponse {
+	aisles := make([]storeAisleResponse, len(store.Aisles))
+	for i, aisle := range store.Aisles {
+		aisles[i] = storeAisleResponse{
+			Category: aisle.Category,
+			Name:     aisle.Name,
+		}
+	}
+
+	return storeResponse{
+		ID:        store.ID,
+		CreatedAt: store.CreatedAt,
+		UpdatedAt: store.UpdatedAt,
+		Name:      store.Name,
+		Aisles:    aisles,
+	}
+}
+
+// storeFromRequest fetches the store named by the store_id url parameter,
+// responding with an error if that fails.
+func (c *Config) storeFromRequest(w http.ResponseWriter, r *http.Request, user domain.User) (domain.Store, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "store_id"), 10, 64)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Id is not an integer")
+		return domain.Store{}, false
+	}
+
+	store, err := c.Domain.GetStore(r.Context(), user, id)
+	if err != nil {
+		respondWithDomainError(w, err)
+		return domain.Store{}, false
+	}
+
+	return store, true
+}
+
+func (c *Config) handlePostStore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := storeRequest{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		aisles, err := reqBody.aisles()
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		store, err := c.Domain.CreateStore(r.Context(), user, reqBody.Name, aisles)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, domainStoreToResponse(store))
+	}
+}
+
+func (c *Config) handleGetStores() http.HandlerFunc {
+	type response = []storeResponse
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		stores, err := c.Domain.GetStoresForUser(r.Context(), user)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		res := make(response, len(stores))
+		for i, store := range stores {
+			res[i] = domainStoreToResponse(store)
+		}
+
+		respondWithJSON(w, http.StatusOK, res)
+	}
+}
+
+func (c *Config) handleGetStore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		store, ok := c.storeFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainStoreToResponse(store))
+	}
+}
+
+func (c *Config) handlePutStore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		reqBody := storeRequest{}
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to parse json body")
+			return
+		}
+
+		aisles, err := reqBody.aisles()
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		store, ok := c.storeFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		store, err = c.Domain.UpdateStore(r.Context(), user, store, reqBody.Name, aisles)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, domainStoreToResponse(store))
+	}
+}
+
+func (c *Config) handleDeleteStore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		store, ok := c.storeFromRequest(w, r, user)
+		if !ok {
+			return
+		}
+
+		err := c.Domain.DeleteStore(r.Context(), user, store)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -5,18 +5,51 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/domerr"
 	"golang.org/x/crypto/bcrypt"
 )
 
 const maxJwtDuration = time.Hour * 24
 
+// accessClaims are the claims of an access token. TokenVersion must match the
+// user's current token version for the token to be accepted, so bumping the
+// version revokes every access token issued before it.
+type accessClaims struct {
+	jwt.RegisteredClaims
+	TokenVersion int64 `json:"ver"`
+}
+
+func (c *Config) createAccessToken(user domain.User, expiresInSeconds int) (string, error) {
+	jwtDuration := time.Second * time.Duration(expiresInSeconds)
+	if expiresInSeconds <= 0 || jwtDuration > maxJwtDuration {
+		jwtDuration = maxJwtDuration
+	}
+
+	issuedAt := jwt.NewNumericDate(time.Now())
+	expiresAt := jwt.NewNumericDate(issuedAt.Add(jwtDuration))
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "recipe-wizard",
+			IssuedAt:  issuedAt,
+			ExpiresAt: expiresAt,
+			Subject:   fmt.Sprint(user.ID),
+		},
+		TokenVersion: user.TokenVersion,
+	})
+
+	return token.SignedString(c.JwtSecret)
+}
+
 func (c *Config) handlePostUser() http.HandlerFunc {
 
 	type request struct {
@@ -24,17 +57,20 @@ func (c *Config) handlePostUser() http.HandlerFunc {
 		Password         string `json:"password"`
 		FirstName        string `json:"first_name"`
 		LastName         string `json:"last_name"`
+		Email            string `json:"email"`
 		ExpiresInSeconds int    `json:"expires_in_seconds"`
 	}
 
 	type response struct {
-		ID        int64     `json:"id"`
-		CreatedAt time.Time `json:"created_at"`
-		UpdatedAt time.Time `json:"updated_at"`
-		Username  string    `json:"username"`
-		FirstName string    `json:"first_name,omitempty"`
-		LastName  string    `json:"last_name,omitempty"`
-		Token     string    `json:"token"`
+		ID           int64     `json:"id"`
+		CreatedAt    time.Time `json:"created_at"`
+		UpdatedAt    time.Time `json:"updated_at"`
+		Username     string    `json:"username"`
+		FirstName    string    `json:"first_name,omitempty"`
+		LastName     string    `json:"last_name,omitempty"`
+		Email        string    `json:"email,omitempty"`
+		Token        string    `json:"token"`
+		RefreshToken string    `json:"refresh_token"`
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -62,42 +98,35 @@ func (c *Config) handlePostUser() http.HandlerFunc {
 			HashedPassword: string(hashedPasswordBytes),
 			FirstName:      req.FirstName,
 			LastName:       req.LastName,
+			Email:          req.Email,
 		})
 		if err != nil {
 			respondWithDomainError(w, err)
 			return
 		}
 
-		jwtDuration := time.Second * time.Duration(req.ExpiresInSeconds)
-		if req.ExpiresInSeconds <= 0 || jwtDuration > maxJwtDuration {
-			jwtDuration = maxJwtDuration
+		tokenString, err := c.createAccessToken(user, req.ExpiresInSeconds)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+			return
 		}
 
-		issuedAt := jwt.NewNumericDate(time.Now())
-		expiresAt := jwt.NewNumericDate(issuedAt.Add(jwtDuration))
-
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-			Issuer:    "recipe-wizard",
-			IssuedAt:  issuedAt,
-			ExpiresAt: expiresAt,
-			Subject:   fmt.Sprint(user.ID),
-		})
-
-		tokenString, err := token.SignedString(c.JwtSecret)
-
+		refreshToken, err := c.Domain.CreateRefreshToken(r.Context(), user)
 		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			respondWithDomainError(w, err)
 			return
 		}
 
 		res := response{
-			ID:        user.ID,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-			Username:  user.Username,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Token:     tokenString,
+			ID:           user.ID,
+			CreatedAt:    user.CreatedAt,
+			UpdatedAt:    user.UpdatedAt,
+			Username:     user.Username,
+			FirstName:    user.FirstName,
+			LastName:     user.LastName,
+			Email:        user.Email,
+			Token:        tokenString,
+			RefreshToken: refreshToken,
 		}
 
 		respondWithJSON(w, http.StatusCreated, &res)
@@ -112,11 +141,10 @@ func (c *Config) handleLogin() http.HandlerFunc {
 	}
 
 	type response struct {
-		Token string `json:"token"`
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
 	}
 
-	maxJwtDuration := time.Hour * 24
-
 	return func(w http.ResponseWriter, r *http.Request) {
 		req := request{}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -135,36 +163,94 @@ func (c *Config) handleLogin() http.HandlerFunc {
 			return
 		}
 
-		jwtDuration := time.Second * time.Duration(req.ExpiresInSeconds)
-		if req.ExpiresInSeconds <= 0 || jwtDuration > maxJwtDuration {
-			jwtDuration = maxJwtDuration
+		tokenString, err := c.createAccessToken(user, req.ExpiresInSeconds)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+			return
 		}
 
-		issuedAt := jwt.NewNumericDate(time.Now())
-		expiresAt := jwt.NewNumericDate(issuedAt.Add(jwtDuration))
+		refreshToken, err := c.Domain.CreateRefreshToken(r.Context(), user)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
 
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-			Issuer:    "recipe-wizard",
-			IssuedAt:  issuedAt,
-			ExpiresAt: expiresAt,
-			Subject:   fmt.Sprint(user.ID),
-		})
+		res := response{
+			Token:        tokenString,
+			RefreshToken: refreshToken,
+		}
 
-		tokenString, err := token.SignedString(c.JwtSecret)
+		respondWithJSON(w, http.StatusOK, &res)
+	}
+}
 
+func (c *Config) handleRefresh() http.HandlerFunc {
+	type request struct {
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresInSeconds int    `json:"expires_in_seconds"`
+	}
+
+	type response struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		user, refreshToken, err := c.Domain.RotateRefreshToken(r.Context(), req.RefreshToken)
+		if err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		tokenString, err := c.createAccessToken(user, req.ExpiresInSeconds)
 		if err != nil {
 			respondWithError(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
 		res := response{
-			Token: tokenString,
+			Token:        tokenString,
+			RefreshToken: refreshToken,
 		}
 
 		respondWithJSON(w, http.StatusOK, &res)
 	}
 }
 
+func (c *Config) handleLogout() http.HandlerFunc {
+	type request struct {
+		RefreshToken string `json:"refresh_token"`
+		All          bool   `json:"all"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(ContextUserKey).(domain.User)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unable to retrieve user")
+			return
+		}
+
+		req := request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if err := c.Domain.Logout(r.Context(), user, req.RefreshToken, req.All); err != nil {
+			respondWithDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
 func (c *Config) middlewareExtractUser(next http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		authString := r.Header.Get("Authorization")
@@ -183,15 +269,21 @@ func (c *Config) middlewareExtractUser(next http.Handler) http.HandlerFunc {
 
 		tokenString := authList[1]
 
-		token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		if strings.HasPrefix(tokenString, domain.ApiKeyPrefix) {
+			c.serveWithApiKey(next, w, r, tokenString)
+			return
+		}
+
+		claims := accessClaims{}
+		_, err := jwt.ParseWithClaims(tokenString, &claims, func(t *jwt.Token) (interface{}, error) {
 			return []byte(c.JwtSecret), nil
-		})
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 		if err != nil {
 			respondWithError(w, http.StatusUnauthorized, err.Error())
 			return
 		}
 
-		userIdString, err := token.Claims.GetSubject()
+		userIdString, err := claims.GetSubject()
 		if err != nil {
 			respondWithError(w, http.StatusUnauthorized, "No user specified")
 			return
@@ -209,9 +301,46 @@ func (c *Config) middlewareExtractUser(next http.Handler) http.HandlerFunc {
 			return
 		}
 
+		if claims.TokenVersion != user.TokenVersion {
+			respondWithError(w, http.StatusUnauthorized, "Token has been revoked")
+			return
+		}
+
 		ctx := context.WithValue(r.Context(), ContextUserKey, user)
 		r = r.WithContext(ctx)
 
 		next.ServeHTTP(w, r)
 	}
 }
+
+func (c *Config) serveWithApiKey(next http.Handler, w http.ResponseWriter, r *http.Request, key string) {
+	user, apiKey, err := c.Domain.AuthenticateApiKey(r.Context(), key)
+	if err != nil {
+		respondWithDomainError(w, err)
+		return
+	}
+
+	if apiKey.Scope == domain.ScopeRead && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		respondWithDomainError(w, domerr.ErrApiKeyReadOnly)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), ContextUserKey, user)
+	ctx = context.WithValue(ctx, ContextApiKeyKey, apiKey)
+	r = r.WithContext(ctx)
+
+	next.ServeHTTP(w, r)
+}
+
+// middlewareRejectApiKey keeps api keys away from actions that manage the
+// account's credentials, so a leaked key cannot be used to mint new ones.
+func (c *Config) middlewareRejectApiKey(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(ContextApiKeyKey).(domain.ApiKey); ok {
+			respondWithDomainError(w, domerr.ErrApiKeyNotAllowed)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+}
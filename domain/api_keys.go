@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+)
+
+// ApiKeyPrefix starts every api key, so they can be told apart from access
+// tokens.
+const ApiKeyPrefix = "rwk_"
+
+// how long a key's last use time may lag behind, to save a write per request
+const apiKeyLastUsedResolution = time.Minute
+
+func databaseToDomainApiKey(key database.ApiKey) ApiKey {
+	scope, _ := ApiKeyScopeFromString(key.Scope)
+	return ApiKey{
+		ID:         key.ID,
+		CreatedAt:  key.CreatedAt,
+		UpdatedAt:  key.UpdatedAt,
+		OwnerID:    key.OwnerID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scope:      scope,
+		LastUsedAt: key.LastUsedAt.Time,
+	}
+}
+
+// CreateApiKey creates an api key for the user. The key itself is only
+// returned here; afterwards only its hash is stored.
+func (c *Config) CreateApiKey(ctx context.Context, user User, name string, scope ApiKeyScope) (ApiKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || (scope != ScopeRead && scope != ScopeWrite) {
+		return ApiKey{}, "", domerr.ErrInvalidInput
+	}
+
+	secret, _, err := newSecretToken()
+	if err != nil {
+		return ApiKey{}, "", err
+	}
+	key := ApiKeyPrefix + secret
+
+	now := time.Now()
+	apiKey, err := c.Querier().CreateApiKey(ctx, database.CreateApiKeyParams{
+		CreatedAt: now,
+		UpdatedAt: now,
+		OwnerID:   user.ID,
+		Name:      name,
+		Prefix:    key[:len(ApiKeyPrefix)+6],
+		KeyHash:   hashSecretToken(key),
+		Scope:     scope.String(),
+	})
+	if err != nil {
+		return ApiKey{}, "", err
+	}
+
+	return databaseToDomainApiKey(apiKey), key, nil
+}
+
+func (c *Config) GetApiKey(ctx context.Context, user User, id int64) (ApiKey, error) {
+	key, err := c.Querier().GetApiKey(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ApiKey{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return ApiKey{}, err
+	}
+
+	if user.ID != key.OwnerID {
+		return ApiKey{}, domerr.ErrForbidden
+	}
+
+	return databaseToDomainApiKey(key), nil
+}
+
+func (c *Config) GetApiKeysForUser(ctx context.Context, user User) ([]ApiKey, error) {
+	keys, err := c.Querier().GetApiKeysForUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	domainKeys := make([]ApiKey, len(keys))
+	for i, key := range keys {
+		domainKeys[i] = databaseToDomainApiKey(key)
+	}
+
+	return domainKeys, nil
+}
+
+// DeleteApiKey revokes the key. Requests made with it are rejected from then on.
+func (c *Config) DeleteApiKey(ctx context.Context, user User, key ApiKey) error {
+	if user.ID != key.OwnerID {
+		return domerr.ErrForbidden
+	}
+
+	return c.Querier().DeleteApiKey(ctx, key.ID)
+}
+
+// AuthenticateApiKey returns the api key matching key, and the user who owns it.
+func (c *Config) AuthenticateApiKey(ctx context.Context, key string) (User, ApiKey, error) {
+	apiKey, err := c.Querier().GetApiKeyByHash(ctx, hashSecretToken(key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, ApiKey{}, domerr.ErrInvalidApiKey
+	} else if err != nil {
+		return User{}, ApiKey{}, err
+	}
+
+	user, err := c.GetUser(ctx, apiKey.OwnerID)
+	if err != nil {
+		return User{}, ApiKey{}, err
+	}
+
+	now := time.Now()
+	if !apiKey.LastUsedAt.Valid || now.Sub(apiKey.LastUsedAt.Time) >= apiKeyLastUsedResolution {
+		apiKey.LastUsedAt = sql.NullTime{Time: now, Valid: true}
+		err = c.Querier().SetApiKeyLastUsed(ctx, database.SetApiKeyLastUsedParams{
+			LastUsedAt: apiKey.LastUsedAt,
+			ID:         apiKey.ID,
+		})
+		if err != nil {
+			return User{}, ApiKey{}, err
+		}
+	}
+
+	return user, databaseToDomainApiKey(apiKey), nil
+}
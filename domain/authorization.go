@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+)
+
+// Action is something a user may want to do with a recipe or grocery list.
+type Action int
+
+const (
+	_ Action = iota
+	ActionView
+	ActionEdit
+	ActionDelete // also covers moving the resource to another household
+)
+
+// allows reports whether a household member with the role may take the action
+// on the recipes and grocery lists of the household.
+func (r HouseholdRole) allows(action Action) bool {
+	switch action {
+	case ActionView:
+		return r >= RoleViewer
+	case ActionEdit:
+		return r >= RoleEditor
+	case ActionDelete:
+		return r >= RoleOwner
+	}
+	return false
+}
+
+// authorize decides whether the user may take the action on a resource with
+// the given owner and household. Every access check on recipes, grocery lists
+// and pantries goes through here.
+//
+// The owner of a resource may do anything with it. Members of the household it
+// belongs to may do what their role allows.
+func (c *Config) authorize(ctx context.Context, user User, ownerID int64, householdID int64, action Action) error {
+	if user.ID == ownerID {
+		return nil
+	}
+
+	if householdID == 0 {
+		return domerr.ErrForbidden
+	}
+
+	role, err := c.householdRole(ctx, user, householdID)
+	if err != nil {
+		return err
+	}
+
+	if !role.allows(action) {
+		return domerr.ErrForbidden
+	}
+
+	return nil
+}
+
+func (c *Config) authorizeRecipe(ctx context.Context, user User, recipe Recipe, action Action) error {
+	return c.authorize(ctx, user, recipe.OwnerID, recipe.HouseholdID, action)
+}
+
+func (c *Config) authorizeGroceryList(ctx context.Context, user User, groceryList GroceryList, action Action) error {
+	return c.authorize(ctx, user, groceryList.OwnerID, groceryList.HouseholdID, action)
+}
+
+func (c *Config) authorizePantryItem(ctx context.Context, user User, item PantryItem, action Action) error {
+	return c.authorize(ctx, user, item.OwnerID, item.HouseholdID, action)
+}
+
+// authorizeGroceryListID is authorizeGroceryList for things that only know the
+// id of their grocery list, like items and meals.
+func (c *Config) authorizeGroceryListID(ctx context.Context, user User, id int64, action Action) error {
+	groceryList, err := c.Querier().GetGroceryList(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domerr.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.authorizeGroceryList(ctx, user, databaseToDomainGroceryList(groceryList), action)
+}
+
+// householdRole returns the role of the user in the household, failing with
+// ErrForbidden if they are not a member.
+func (c *Config) householdRole(ctx context.Context, user User, householdID int64) (HouseholdRole, error) {
+	member, err := c.Querier().GetHouseholdMember(ctx, database.GetHouseholdMemberParams{
+		HouseholdID: householdID,
+		UserID:      user.ID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domerr.ErrForbidden
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return HouseholdRoleFromString(member.Role)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+)
+
+// how long a feed's last use time may lag behind; calendar apps poll often
+const calendarFeedLastUsedResolution = time.Hour
+
+func databaseToDomainCalendarFeed(feed database.CalendarFeed) CalendarFeed {
+	return CalendarFeed{
+		ID:         feed.ID,
+		CreatedAt:  feed.CreatedAt,
+		UpdatedAt:  feed.UpdatedAt,
+		OwnerID:    feed.OwnerID,
+		LastUsedAt: feed.LastUsedAt.Time,
+	}
+}
+
+// CreateCalendarFeed gives the user a new calendar feed token, revoking the one
+// they had. The token itself is only returned here; afterwards only its hash is
+// stored.
+func (c *Config) CreateCalendarFeed(ctx context.Context, user User) (CalendarFeed, string, error) {
+	token, hash, err := newSecretToken()
+	if err != nil {
+		return CalendarFeed{}, "", err
+	}
+
+	now := time.Now()
+	feed, err := c.Querier().UpsertCalendarFeed(ctx, database.UpsertCalendarFeedParams{
+		CreatedAt: now,
+		UpdatedAt: now,
+		OwnerID:   user.ID,
+		TokenHash: hash,
+	})
+	if err != nil {
+		return CalendarFeed{}, "", err
+	}
+
+	return databaseToDomainCalendarFeed(feed), token, nil
+}
+
+func (c *Config) GetCalendarFeed(ctx context.Context, user User) (CalendarFeed, error) {
+	feed, err := c.Querier().GetCalendarFeedForUser(ctx, user.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return CalendarFeed{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return CalendarFeed{}, err
+	}
+
+	return databaseToDomainCalendarFeed(feed), nil
+}
+
+// DeleteCalendarFeed revokes the user's calendar feed token, if they have one.
+func (c *Config) DeleteCalendarFeed(ctx context.Context, user User) error {
+	return c.Querier().DeleteCalendarFeedForUser(ctx, user.ID)
+}
+
+// AuthenticateCalendarFeed returns the user whose calendar feed token is token.
+func (c *Config) AuthenticateCalendarFeed(ctx context.Context, token string) (User, error) {
+	feed, err := c.Querier().GetCalendarFeedByHash(ctx, hashSecretToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, domerr.ErrInvalidCalendarToken
+	} else if err != nil {
+		return User{}, err
+	}
+
+	user, err := c.GetUser(ctx, feed.OwnerID)
+	if err != nil {
+		return User{}, err
+	}
+
+	now := time.Now()
+	if !feed.LastUsedAt.Valid || now.Sub(feed.LastUsedAt.Time) >= calendarFeedLastUsedResolution {
+		err = c.Querier().SetCalendarFeedLastUsed(ctx, database.SetCalendarFeedLastUsedParams{
+			LastUsedAt: sql.NullTime{Time: now, Valid: true},
+			ID:         feed.ID,
+		})
+		if err != nil {
+			return User{}, err
+		}
+	}
+
+	return user, nil
+}
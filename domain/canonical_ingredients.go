@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/snorman7384/recipe-wizard/ingparse"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+)
+
+// resolveCanonicalIngredient links an ingredient name to its entry in the
+// ingredient catalog. The catalog is shared by every user, so names it does not
+// know are not added to it; they are not linked, and stay the name of the
+// ingredient or item they came from.
+func resolveCanonicalIngredient(ctx context.Context, q *database.Queries, name string) (sql.NullInt64, error) {
+	canonical, found, err := findCanonicalIngredient(ctx, q, name)
+	if err != nil || !found {
+		return sql.NullInt64{}, err
+	}
+
+	return sql.NullInt64{Int64: canonical.ID, Valid: true}, nil
+}
+
+// findCanonicalIngredient looks up the catalog entry an ingredient name stands
+// for, without adding one. It reports false if the catalog does not know the
+// name.
+func findCanonicalIngredient(ctx context.Context, q *database.Queries, name string) (database.CanonicalIngredient, bool, error) {
+	key := ingparse.NormalizeName(name)
+	if key == "" {
+		return database.CanonicalIngredient{}, false, nil
+	}
+
+	canonical, err := q.GetCanonicalIngredientBySynonym(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.CanonicalIngredient{}, false, nil
+	}
+	if err != nil {
+		return database.CanonicalIngredient{}, false, err
+	}
+
+	return canonical, true, nil
+}
+
+// canonicalCategory returns the category of a catalog entry, which items linked
+// to it start out in.
+func canonicalCategory(ctx context.Context, q *database.Queries, canonicalID sql.NullInt64) (sql.NullString, error) {
+	if !canonicalID.Valid {
+		return sql.NullString{}, nil
+	}
+
+	canonical, err := q.GetCanonicalIngredient(ctx, canonicalID.Int64)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return canonical.Category, nil
+}
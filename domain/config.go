@@ -3,13 +3,22 @@ package domain
 import (
 	"database/sql"
 
+	"github.com/snorman7384/recipe-wizard/blobstore"
+	"github.com/snorman7384/recipe-wizard/events"
 	"github.com/snorman7384/recipe-wizard/ingparse"
 	"github.com/snorman7384/recipe-wizard/internal/database"
+	"github.com/snorman7384/recipe-wizard/mailer"
+	"github.com/snorman7384/recipe-wizard/recscrape"
 )
 
 type Config struct {
 	DB               *sql.DB
 	IngredientParser ingparse.IngredientParser
+	RecipeScraper    recscrape.RecipeScraper
+	Mailer           mailer.Mailer
+	PasswordResetURL string // the reset token is appended to this url in reset emails
+	Events           *events.Broker
+	Blobs            blobstore.BlobStore // holds recipe images; without one, recipes have none
 }
 
 func (c *Config) Querier() *database.Queries {
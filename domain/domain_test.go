@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/snorman7384/recipe-wizard/events"
+	"github.com/snorman7384/recipe-wizard/ingparse"
+	"github.com/snorman7384/recipe-wizard/internal/dbconn"
+
+	_ "modernc.org/sqlite"
+)
+
+// newTestConfig returns a Config backed by a new SQLite database with every
+// migration applied.
+func newTestConfig(t *testing.T) *Config {
+	t.Helper()
+
+	db := newTestDB(t)
+	for _, migration := range testMigrations(t) {
+		applyTestMigration(t, db, migration)
+	}
+
+	return &Config{
+		DB:               db,
+		IngredientParser: ingparse.SchollzParser{},
+		Events:           &events.Broker{},
+	}
+}
+
+// newTestDB returns a new, empty SQLite database.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := dbconn.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// testMigrations returns the paths of the migrations, in the order they are
+// applied.
+func testMigrations(t *testing.T) []string {
+	t.Helper()
+
+	migrations, err := filepath.Glob("../sql/schema/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(migrations)
+
+	return migrations
+}
+
+// applyTestMigration runs the up part of a migration, the way goose would.
+func applyTestMigration(t *testing.T, db *sql.DB, migration string) {
+	t.Helper()
+
+	b, err := os.ReadFile(migration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	up, _, _ := strings.Cut(string(b), "-- +goose Down")
+	if _, err := db.Exec(up); err != nil {
+		t.Fatalf("%s: %v", filepath.Base(migration), err)
+	}
+}
+
+func newTestUser(t *testing.T, c *Config, username string) User {
+	t.Helper()
+
+	user, err := c.CreateUser(context.Background(), CreateUserParams{Username: username, HashedPassword: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+// must returns v, and panics if err is not nil, like template.Must, so that
+// calls can be chained in tests.
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/events"
+)
+
+// Types of the events published on grocery lists. The data of item events is
+// the Item, of meal events the Meal and of grocery list events the GroceryList.
+const (
+	EventItemCreated        = "item.created"
+	EventItemUpdated        = "item.updated"
+	EventItemStatusChanged  = "item.status_changed"
+	EventItemDeleted        = "item.deleted"
+	EventMealAdded          = "meal.added"
+	EventMealRemoved        = "meal.removed"
+	EventGroceryListDeleted = "grocery_list.deleted"
+)
+
+// SubscribeToGroceryList starts receiving the events of a grocery list the user
+// may view. The subscription must be closed when it is no longer needed.
+func (c *Config) SubscribeToGroceryList(ctx context.Context, user User, groceryList GroceryList) (*events.Subscription, error) {
+	if c.Events == nil {
+		return nil, domerr.ErrInternal
+	}
+
+	if err := c.authorizeGroceryList(ctx, user, groceryList, ActionView); err != nil {
+		return nil, err
+	}
+
+	return c.Events.Subscribe(groceryList.ID), nil
+}
+
+// publish sends an event to the subscribers of a grocery list. It must only be
+// called once the change the event describes has been committed.
+func (c *Config) publish(groceryListID int64, eventType string, data any) {
+	if c.Events == nil {
+		return
+	}
+
+	c.Events.Publish(events.Event{
+		Type:          eventType,
+		GroceryListID: groceryListID,
+		Data:          data,
+	})
+}
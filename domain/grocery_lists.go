@@ -12,11 +12,12 @@ import (
 
 func databaseToDomainGroceryList(dbGroceryList database.GroceryList) GroceryList {
 	return GroceryList{
-		ID:        dbGroceryList.ID,
-		CreatedAt: dbGroceryList.CreatedAt,
-		UpdatedAt: dbGroceryList.UpdatedAt,
-		Name:      dbGroceryList.Name,
-		OwnerID:   dbGroceryList.ID,
+		ID:          dbGroceryList.ID,
+		CreatedAt:   dbGroceryList.CreatedAt,
+		UpdatedAt:   dbGroceryList.UpdatedAt,
+		Name:        dbGroceryList.Name,
+		OwnerID:     dbGroceryList.OwnerID,
+		HouseholdID: dbGroceryList.HouseholdID.Int64,
 	}
 }
 
@@ -47,15 +48,20 @@ func (c *Config) GetGroceryList(ctx context.Context, user User, id int64) (Groce
 		return GroceryList{}, err
 	}
 
-	if user.ID != groceryList.OwnerID {
-		return GroceryList{}, domerr.ErrForbidden
+	domainGroceryList := databaseToDomainGroceryList(groceryList)
+
+	if err := c.authorizeGroceryList(ctx, user, domainGroceryList, ActionView); err != nil {
+		return GroceryList{}, err
 	}
 
-	return databaseToDomainGroceryList(groceryList), nil
+	return domainGroceryList, nil
 }
 
 func (c *Config) GetGroceryListsForUser(ctx context.Context, user User) ([]GroceryList, error) {
-	groceryLists, err := c.Querier().GetGroceryListsForUser(ctx, user.ID)
+	groceryLists, err := c.Querier().GetGroceryListsForUser(ctx, database.GetGroceryListsForUserParams{
+		OwnerID:  user.ID,
+		MemberID: user.ID,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -67,3 +73,127 @@ func (c *Config) GetGroceryListsForUser(ctx context.Context, user User) ([]Groce
 
 	return domainList, nil
 }
+
+// ListGroceryListsForUser returns one page of the grocery lists of the user, and the cursor of the next page, which is
+// empty on the last page.
+func (c *Config) ListGroceryListsForUser(ctx context.Context, user User, params ListParams) ([]GroceryList, string, error) {
+	pg, err := params.page()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var rows []database.GroceryList
+	switch pg.sort {
+	case SortCreatedAt:
+		rows, err = c.Querier().ListGroceryListsForUser(ctx, database.ListGroceryListsForUserParams{
+			OwnerID:      user.ID,
+			MemberID:     user.ID,
+			NameContains: pg.nameContains,
+			CreatedAfter: pg.createdAfter,
+			AfterID:      pg.after.ID,
+			Limit:        pg.queryLimit(),
+		})
+	case SortCreatedAtDesc:
+		rows, err = c.Querier().ListGroceryListsForUserDesc(ctx, database.ListGroceryListsForUserDescParams{
+			OwnerID:      user.ID,
+			MemberID:     user.ID,
+			NameContains: pg.nameContains,
+			CreatedAfter: pg.createdAfter,
+			BeforeID:     pg.after.ID,
+			Limit:        pg.queryLimit(),
+		})
+	case SortName:
+		rows, err = c.Querier().ListGroceryListsForUserByName(ctx, database.ListGroceryListsForUserByNameParams{
+			OwnerID:      user.ID,
+			MemberID:     user.ID,
+			NameContains: pg.nameContains,
+			CreatedAfter: pg.createdAfter,
+			FromStart:    pg.fromStart,
+			AfterName:    pg.after.Name,
+			AfterID:      pg.after.ID,
+			Limit:        pg.queryLimit(),
+		})
+	case SortNameDesc:
+		rows, err = c.Querier().ListGroceryListsForUserByNameDesc(ctx, database.ListGroceryListsForUserByNameDescParams{
+			OwnerID:      user.ID,
+			MemberID:     user.ID,
+			NameContains: pg.nameContains,
+			CreatedAfter: pg.createdAfter,
+			FromStart:    pg.fromStart,
+			BeforeName:   pg.after.Name,
+			BeforeID:     pg.after.ID,
+			Limit:        pg.queryLimit(),
+		})
+	default:
+		return nil, "", domerr.ErrInvalidSort
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	list := make([]GroceryList, pg.trim(len(rows)))
+	for i := range list {
+		list[i] = databaseToDomainGroceryList(rows[i])
+	}
+
+	var next string
+	if len(list) > 0 {
+		last := list[len(list)-1]
+		next = pg.nextCursor(len(rows), cursor{Name: last.Name, ID: last.ID})
+	}
+
+	return list, next, nil
+}
+
+// DeleteGroceryList deletes a grocery list along with all of its meals and items.
+func (c *Config) DeleteGroceryList(ctx context.Context, user User, groceryList GroceryList) error {
+	if err := c.authorizeGroceryList(ctx, user, groceryList, ActionDelete); err != nil {
+		return err
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	err = qtx.DeleteItemsInGroceryList(ctx, groceryList.ID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteMealPantryUsesInGroceryList(ctx, groceryList.ID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.UnlinkPlannedMealsInGroceryList(ctx, groceryList.ID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteMealsInGroceryList(ctx, groceryList.ID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteGroceryList(ctx, groceryList.ID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteGroceryListChanges(ctx, groceryList.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	c.publish(groceryList.ID, EventGroceryListDeleted, groceryList)
+
+	return nil
+}
@@ -0,0 +1,374 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+)
+
+func databaseToDomainHousehold(household database.Household, role HouseholdRole) Household {
+	return Household{
+		ID:        household.ID,
+		CreatedAt: household.CreatedAt,
+		UpdatedAt: household.UpdatedAt,
+		Name:      household.Name,
+		Role:      role,
+	}
+}
+
+func databaseToDomainHouseholdMember(member database.HouseholdMember, username string) HouseholdMember {
+	role, _ := HouseholdRoleFromString(member.Role)
+	return HouseholdMember{
+		HouseholdID: member.HouseholdID,
+		UserID:      member.UserID,
+		CreatedAt:   member.CreatedAt,
+		UpdatedAt:   member.UpdatedAt,
+		Username:    username,
+		Role:        role,
+	}
+}
+
+func validHouseholdRole(role HouseholdRole) bool {
+	return role == RoleViewer || role == RoleEditor || role == RoleOwner
+}
+
+// CreateHousehold creates a household with the user as its owner.
+func (c *Config) CreateHousehold(ctx context.Context, user User, name string) (Household, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Household{}, domerr.ErrInvalidInput
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return Household{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	now := time.Now()
+
+	household, err := qtx.CreateHousehold(ctx, database.CreateHouseholdParams{
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      name,
+	})
+	if err != nil {
+		return Household{}, err
+	}
+
+	_, err = qtx.CreateHouseholdMember(ctx, database.CreateHouseholdMemberParams{
+		HouseholdID: household.ID,
+		UserID:      user.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Role:        RoleOwner.String(),
+	})
+	if err != nil {
+		return Household{}, err
+	}
+
+	return databaseToDomainHousehold(household, RoleOwner), tx.Commit()
+}
+
+// GetHousehold returns a household the user is a member of.
+func (c *Config) GetHousehold(ctx context.Context, user User, id int64) (Household, error) {
+	household, err := c.Querier().GetHousehold(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Household{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return Household{}, err
+	}
+
+	role, err := c.householdRole(ctx, user, household.ID)
+	if err != nil {
+		return Household{}, err
+	}
+
+	return databaseToDomainHousehold(household, role), nil
+}
+
+func (c *Config) GetHouseholdsForUser(ctx context.Context, user User) ([]Household, error) {
+	rows, err := c.Querier().GetHouseholdsForUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	households := make([]Household, len(rows))
+	for i, row := range rows {
+		role, _ := HouseholdRoleFromString(row.Role)
+		households[i] = databaseToDomainHousehold(row.Household, role)
+	}
+
+	return households, nil
+}
+
+// DeleteHousehold deletes a household. Its recipes and grocery lists are kept,
+// and are only shared with their owners from then on.
+func (c *Config) DeleteHousehold(ctx context.Context, user User, household Household) error {
+	role, err := c.householdRole(ctx, user, household.ID)
+	if err != nil {
+		return err
+	}
+	if role != RoleOwner {
+		return domerr.ErrForbidden
+	}
+
+	return c.Querier().DeleteHousehold(ctx, household.ID)
+}
+
+func (c *Config) GetHouseholdMembers(ctx context.Context, household Household) ([]HouseholdMember, error) {
+	rows, err := c.Querier().GetHouseholdMembers(ctx, household.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]HouseholdMember, len(rows))
+	for i, row := range rows {
+		members[i] = databaseToDomainHouseholdMember(row.HouseholdMember, row.Username)
+	}
+
+	return members, nil
+}
+
+// AddHouseholdMember adds the user with the given username to the household.
+// Only owners of the household may add members.
+func (c *Config) AddHouseholdMember(ctx context.Context, user User, household Household, username string, role HouseholdRole) (HouseholdMember, error) {
+	if !validHouseholdRole(role) {
+		return HouseholdMember{}, domerr.ErrInvalidInput
+	}
+
+	userRole, err := c.householdRole(ctx, user, household.ID)
+	if err != nil {
+		return HouseholdMember{}, err
+	}
+	if userRole != RoleOwner {
+		return HouseholdMember{}, domerr.ErrForbidden
+	}
+
+	newMember, err := c.Querier().GetUserByUsername(ctx, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return HouseholdMember{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return HouseholdMember{}, err
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return HouseholdMember{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	_, err = qtx.GetHouseholdMember(ctx, database.GetHouseholdMemberParams{
+		HouseholdID: household.ID,
+		UserID:      newMember.ID,
+	})
+	if err == nil {
+		return HouseholdMember{}, domerr.ErrAlreadyHouseholdMember
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return HouseholdMember{}, err
+	}
+
+	now := time.Now()
+
+	member, err := qtx.CreateHouseholdMember(ctx, database.CreateHouseholdMemberParams{
+		HouseholdID: household.ID,
+		UserID:      newMember.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Role:        role.String(),
+	})
+	if err != nil {
+		return HouseholdMember{}, err
+	}
+
+	return databaseToDomainHouseholdMember(member, newMember.Username), tx.Commit()
+}
+
+// SetHouseholdMemberRole changes the role of a member of the household. Only
+// owners of the household may change roles, and the last owner cannot step
+// down.
+func (c *Config) SetHouseholdMemberRole(ctx context.Context, user User, household Household, memberID int64, role HouseholdRole) (HouseholdMember, error) {
+	if !validHouseholdRole(role) {
+		return HouseholdMember{}, domerr.ErrInvalidInput
+	}
+
+	userRole, err := c.householdRole(ctx, user, household.ID)
+	if err != nil {
+		return HouseholdMember{}, err
+	}
+	if userRole != RoleOwner {
+		return HouseholdMember{}, domerr.ErrForbidden
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return HouseholdMember{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	if err := checkKeepsOwner(ctx, qtx, household.ID, memberID, role); err != nil {
+		return HouseholdMember{}, err
+	}
+
+	member, err := qtx.SetHouseholdMemberRole(ctx, database.SetHouseholdMemberRoleParams{
+		UpdatedAt:   time.Now(),
+		Role:        role.String(),
+		HouseholdID: household.ID,
+		UserID:      memberID,
+	})
+	if err != nil {
+		return HouseholdMember{}, err
+	}
+
+	memberUser, err := qtx.GetUser(ctx, memberID)
+	if err != nil {
+		return HouseholdMember{}, err
+	}
+
+	return databaseToDomainHouseholdMember(member, memberUser.Username), tx.Commit()
+}
+
+// RemoveHouseholdMember removes a member from the household. Owners may remove
+// anyone, and every member may leave. The last owner cannot leave.
+//
+// Recipes and grocery lists the member shared with the household stay in it.
+func (c *Config) RemoveHouseholdMember(ctx context.Context, user User, household Household, memberID int64) error {
+	if user.ID != memberID {
+		userRole, err := c.householdRole(ctx, user, household.ID)
+		if err != nil {
+			return err
+		}
+		if userRole != RoleOwner {
+			return domerr.ErrForbidden
+		}
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	if err := checkKeepsOwner(ctx, qtx, household.ID, memberID, 0); err != nil {
+		return err
+	}
+
+	err = qtx.DeleteHouseholdMember(ctx, database.DeleteHouseholdMemberParams{
+		HouseholdID: household.ID,
+		UserID:      memberID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// checkKeepsOwner fails if giving the member the new role, or removing them
+// when newRole is 0, would leave the household without an owner.
+func checkKeepsOwner(ctx context.Context, q *database.Queries, householdID int64, memberID int64, newRole HouseholdRole) error {
+	member, err := q.GetHouseholdMember(ctx, database.GetHouseholdMemberParams{
+		HouseholdID: householdID,
+		UserID:      memberID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return domerr.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if member.Role != RoleOwner.String() || newRole == RoleOwner {
+		return nil
+	}
+
+	owners, err := q.CountHouseholdOwners(ctx, householdID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return domerr.ErrLastHouseholdOwner
+	}
+
+	return nil
+}
+
+// checkCanShareWith fails unless the user may add recipes and grocery lists
+// to the household. A householdID of 0 stops sharing, which anyone may do.
+func (c *Config) checkCanShareWith(ctx context.Context, user User, householdID int64) error {
+	if householdID == 0 {
+		return nil
+	}
+
+	role, err := c.householdRole(ctx, user, householdID)
+	if err != nil {
+		return err
+	}
+	if !role.allows(ActionEdit) {
+		return domerr.ErrForbidden
+	}
+
+	return nil
+}
+
+// SetRecipeHousehold shares the recipe with a household, or stops sharing it
+// when householdID is 0.
+func (c *Config) SetRecipeHousehold(ctx context.Context, user User, recipe Recipe, householdID int64) (Recipe, error) {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionDelete); err != nil {
+		return Recipe{}, err
+	}
+
+	if err := c.checkCanShareWith(ctx, user, householdID); err != nil {
+		return Recipe{}, err
+	}
+
+	updated, err := c.Querier().SetRecipeHousehold(ctx, database.SetRecipeHouseholdParams{
+		UpdatedAt:   time.Now(),
+		HouseholdID: sql.NullInt64{Int64: householdID, Valid: householdID != 0},
+		ID:          recipe.ID,
+	})
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	return databaseToDomainRecipe(updated), nil
+}
+
+// SetGroceryListHousehold shares the grocery list with a household, or stops
+// sharing it when householdID is 0.
+func (c *Config) SetGroceryListHousehold(ctx context.Context, user User, groceryList GroceryList, householdID int64) (GroceryList, error) {
+	if err := c.authorizeGroceryList(ctx, user, groceryList, ActionDelete); err != nil {
+		return GroceryList{}, err
+	}
+
+	if err := c.checkCanShareWith(ctx, user, householdID); err != nil {
+		return GroceryList{}, err
+	}
+
+	updated, err := c.Querier().SetGroceryListHousehold(ctx, database.SetGroceryListHouseholdParams{
+		UpdatedAt:   time.Now(),
+		HouseholdID: sql.NullInt64{Int64: householdID, Valid: householdID != 0},
+		ID:          groceryList.ID,
+	})
+	if err != nil {
+		return GroceryList{}, err
+	}
+
+	return databaseToDomainGroceryList(updated), nil
+}
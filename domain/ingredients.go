@@ -2,6 +2,10 @@ package domain
 
 import (
 	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/snorman7384/recipe-wizard/domerr"
 	"github.com/snorman7384/recipe-wizard/ingparse"
@@ -10,22 +14,128 @@ import (
 
 func databaseToDomainIngredient(ingredient database.Ingredient) Ingredient {
 	return Ingredient{
-		ID:             ingredient.ID,
-		CreatedAt:      ingredient.CreatedAt,
-		UpdatedAt:      ingredient.UpdatedAt,
-		Name:           ingredient.Name,
-		Description:    ingredient.Description.String,
-		Units:          ingredient.Units,
-		Amount:         ingredient.Amount,
-		StandardUnits:  ingparse.StandardUnitFromString(ingredient.StandardUnits),
-		StandardAmount: ingredient.StandardAmount,
-		RecipeID:       ingredient.RecipeID,
+		ID:                    ingredient.ID,
+		CreatedAt:             ingredient.CreatedAt,
+		UpdatedAt:             ingredient.UpdatedAt,
+		Name:                  ingredient.Name,
+		Description:           ingredient.Description.String,
+		Units:                 ingredient.Units,
+		Amount:                ingredient.Amount,
+		StandardUnits:         ingparse.StandardUnitFromString(ingredient.StandardUnits),
+		StandardAmount:        ingredient.StandardAmount,
+		RecipeID:              ingredient.RecipeID,
+		CanonicalIngredientID: ingredient.CanonicalIngredientID.Int64,
 	}
 }
 
+func domainToCreateIngredientParams(recipeID int64, ingredient ingparse.Ingredient, canonicalID sql.NullInt64) database.CreateIngredientParams {
+	now := time.Now()
+	return database.CreateIngredientParams{
+		CreatedAt:             now,
+		UpdatedAt:             now,
+		Name:                  ingredient.Name,
+		Amount:                ingredient.Measure.OriginalAmount,
+		Units:                 ingredient.Measure.OriginalUnits,
+		StandardAmount:        ingredient.Measure.StandardAmount,
+		StandardUnits:         ingredient.Measure.StandardUnits.String(),
+		RecipeID:              recipeID,
+		Description:           sql.NullString{String: ingredient.Description, Valid: ingredient.Description != ""},
+		CanonicalIngredientID: canonicalID,
+	}
+}
+
+// parseIngredientLines parses user entered ingredient lines one at a time, so
+// a line the parser does not understand is kept as a plain named ingredient
+// instead of being dropped.
+func (c *Config) parseIngredientLines(lines []string) []ingparse.Ingredient {
+	ingredients := make([]ingparse.Ingredient, 0, len(lines))
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		ingredient, err := c.IngredientParser.ParseIngredientLine(line)
+		if err != nil {
+			ingredient = ingparse.Ingredient{
+				Line:    line,
+				Name:    line,
+				Measure: ingparse.StandardizeMeasure(0, ""),
+			}
+		}
+
+		ingredients = append(ingredients, ingredient)
+	}
+
+	return ingredients
+}
+
+func createIngredients(ctx context.Context, qtx *database.Queries, recipeID int64, ingredients []ingparse.Ingredient) error {
+	// resolved one at a time, since the same name may add a catalog entry
+	canonicalIDs := make([]sql.NullInt64, len(ingredients))
+	for i, ingredient := range ingredients {
+		canonicalID, err := resolveCanonicalIngredient(ctx, qtx, ingredient.Name)
+		if err != nil {
+			return err
+		}
+		canonicalIDs[i] = canonicalID
+	}
+
+	var wg sync.WaitGroup
+	ch := make(chan error, len(ingredients))
+	for i, ingredient := range ingredients {
+		ingredient := ingredient // I love loop variables
+		canonicalID := canonicalIDs[i]
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := qtx.CreateIngredient(ctx, domainToCreateIngredientParams(recipeID, ingredient, canonicalID))
+			if err != nil {
+				ch <- err
+				return
+			}
+		}()
+	}
+	wg.Wait()
+	select {
+	case err := <-ch:
+		return err
+	default:
+	}
+
+	return nil
+}
+
+func (c *Config) CreateIngredient(ctx context.Context, user User, recipe Recipe, line string) (Ingredient, error) {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionEdit); err != nil {
+		return Ingredient{}, err
+	}
+
+	ingredients := c.parseIngredientLines([]string{line})
+	if len(ingredients) != 1 {
+		return Ingredient{}, domerr.ErrInvalidInput
+	}
+
+	canonicalID, err := resolveCanonicalIngredient(ctx, c.Querier(), ingredients[0].Name)
+	if err != nil {
+		return Ingredient{}, err
+	}
+
+	ingredient, err := c.Querier().CreateIngredient(ctx, domainToCreateIngredientParams(recipe.ID, ingredients[0], canonicalID))
+	if err != nil {
+		return Ingredient{}, err
+	}
+
+	return databaseToDomainIngredient(ingredient), nil
+}
+
 func (c *Config) GetIngredientsForRecipe(ctx context.Context, user User, recipe Recipe) ([]Ingredient, error) {
-	if user.ID != recipe.OwnerID {
-		return nil, domerr.ErrForbidden
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionView); err != nil {
+		return nil, err
 	}
 
 	ingredients, err := c.Querier().GetIngredientsForRecipe(ctx, recipe.ID)
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/internal/database"
+)
+
+func databaseToDomainInstruction(instruction database.Instruction) Instruction {
+	return Instruction{
+		ID:        instruction.ID,
+		CreatedAt: instruction.CreatedAt,
+		UpdatedAt: instruction.UpdatedAt,
+		RecipeID:  instruction.RecipeID,
+		Step:      instruction.Step,
+		Text:      instruction.Text,
+	}
+}
+
+// createInstructions stores the given lines as the numbered steps of a
+// recipe, skipping blank lines.
+func createInstructions(ctx context.Context, qtx *database.Queries, recipeID int64, lines []string) error {
+	var step int64
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		step++
+		now := time.Now()
+
+		_, err := qtx.CreateInstruction(ctx, database.CreateInstructionParams{
+			CreatedAt: now,
+			UpdatedAt: now,
+			RecipeID:  recipeID,
+			Step:      step,
+			Text:      line,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) GetInstructionsForRecipe(ctx context.Context, user User, recipe Recipe) ([]Instruction, error) {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionView); err != nil {
+		return nil, err
+	}
+
+	instructions, err := c.Querier().GetInstructionsForRecipe(ctx, recipe.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	domainList := make([]Instruction, len(instructions))
+
+	for i, instruction := range instructions {
+		domainList[i] = databaseToDomainInstruction(instruction)
+	}
+
+	return domainList, nil
+}
@@ -19,43 +19,75 @@ func databaseToDomainItem(it database.Item) Item {
 		status = Incomplete
 	}
 	return Item{
-		ID:             it.ID,
-		CreatedAt:      it.CreatedAt,
-		UpdatedAt:      it.UpdatedAt,
-		GroceryListID:  it.GroceryListID,
-		MealID:         it.MealID.Int64,
-		IngredientID:   it.IngredientID.Int64,
-		Name:           it.Name,
-		Description:    it.Description.String,
-		Amount:         it.Amount,
-		Units:          it.Units,
-		StandardAmount: it.StandardAmount,
-		StandardUnits:  ingparse.StandardUnitFromString(it.StandardUnits),
-		Status:         status,
+		ID:                    it.ID,
+		CreatedAt:             it.CreatedAt,
+		UpdatedAt:             it.UpdatedAt,
+		GroceryListID:         it.GroceryListID,
+		MealID:                it.MealID.Int64,
+		IngredientID:          it.IngredientID.Int64,
+		CanonicalIngredientID: it.CanonicalIngredientID.Int64,
+		Name:                  it.Name,
+		Description:           it.Description.String,
+		Amount:                it.Amount,
+		Units:                 it.Units,
+		StandardAmount:        it.StandardAmount,
+		StandardUnits:         ingparse.StandardUnitFromString(it.StandardUnits),
+		Status:                status,
+		Category:              databaseToDomainCategory(it.Category),
 	}
 }
 
-func (c *Config) CreateItem(ctx context.Context, groceryList GroceryList, name string, description string, amount float64, units string) (Item, error) {
+// databaseToDomainCategory reads a stored category. Items without one are in
+// CategoryOther.
+func databaseToDomainCategory(category sql.NullString) ItemCategory {
+	c, err := ItemCategoryFromString(category.String)
+	if err != nil {
+		return CategoryOther
+	}
+	return c
+}
+
+func (c *Config) CreateItem(ctx context.Context, user User, groceryList GroceryList, name string, description string, amount float64, units string) (Item, error) {
+	if err := c.authorizeGroceryList(ctx, user, groceryList, ActionEdit); err != nil {
+		return Item{}, err
+	}
+
+	canonicalID, err := resolveCanonicalIngredient(ctx, c.Querier(), name)
+	if err != nil {
+		return Item{}, err
+	}
+
+	category, err := canonicalCategory(ctx, c.Querier(), canonicalID)
+	if err != nil {
+		return Item{}, err
+	}
+
 	now := time.Now()
+	measure := ingparse.StandardizeMeasure(amount, units)
 
 	item, err := c.Querier().CreateItem(ctx, database.CreateItemParams{
-		CreatedAt:      now,
-		UpdatedAt:      now,
-		IngredientID:   sql.NullInt64{},
-		MealID:         sql.NullInt64{},
-		GroceryListID:  groceryList.ID,
-		Name:           name,
-		Description:    sql.NullString{String: description, Valid: description == ""},
-		Amount:         amount,
-		Units:          units,
-		StandardAmount: -1,      // TODO
-		StandardUnits:  "whole", // TODO
+		CreatedAt:             now,
+		UpdatedAt:             now,
+		IngredientID:          sql.NullInt64{},
+		CanonicalIngredientID: canonicalID,
+		MealID:                sql.NullInt64{},
+		GroceryListID:         groceryList.ID,
+		Name:                  name,
+		Description:           sql.NullString{String: description, Valid: description == ""},
+		Amount:                amount,
+		Units:                 units,
+		StandardAmount:        measure.StandardAmount,
+		StandardUnits:         measure.StandardUnits.String(),
+		Category:              category,
 	})
 	if err != nil {
 		return Item{}, err
 	}
 
-	return databaseToDomainItem(item), nil
+	domainItem := databaseToDomainItem(item)
+	c.publish(groceryList.ID, EventItemCreated, domainItem)
+
+	return domainItem, nil
 }
 
 func (c *Config) GetItem(ctx context.Context, user User, id int64) (Item, error) {
@@ -67,8 +99,8 @@ func (c *Config) GetItem(ctx context.Context, user User, id int64) (Item, error)
 		return Item{}, err
 	}
 
-	if user.ID != row.GroceryList.OwnerID {
-		return Item{}, domerr.ErrForbidden
+	if err := c.authorizeGroceryList(ctx, user, databaseToDomainGroceryList(row.GroceryList), ActionView); err != nil {
+		return Item{}, err
 	}
 
 	return databaseToDomainItem(row.Item), nil
@@ -123,77 +155,327 @@ func (c *Config) GetItemsForGroceryListByName(ctx context.Context, groceryList G
 	return items, nil
 }
 
+// GetItemGroupsForGroceryList groups the items on the grocery list by their
+// entry in the ingredient catalog, so that "onions" and "yellow onion" are
+// bought together. Items without an entry, which were added before there was a
+// catalog, join the group of the entry their name stands for, or else a group
+// of their own name.
 func (c *Config) GetItemGroupsForGroceryList(ctx context.Context, groceryList GroceryList) ([]ItemGroup, error) {
 	items, err := c.GetItemsForGroceryList(ctx, groceryList)
 	if err != nil {
 		return nil, err
 	}
 
-	groupMap := make(map[string]ItemGroup)
+	canonicals, err := c.Querier().GetCanonicalIngredientsInGroceryList(ctx, groceryList.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalNames := make(map[int64]string, len(canonicals))
+	for _, canonical := range canonicals {
+		canonicalNames[canonical.ID] = canonical.Name
+	}
+
+	type groupKey struct {
+		canonicalID int64
+		name        string // for items that are not in the catalog
+	}
+
+	groupMap := make(map[groupKey]ItemGroup)
+	var keys []groupKey
 
 	for _, it := range items {
-		name := it.Name
-		entry, ok := groupMap[name]
+		key := groupKey{canonicalID: it.CanonicalIngredientID}
+		if key.canonicalID == 0 {
+			canonical, ok, err := findCanonicalIngredient(ctx, c.Querier(), it.Name)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				key.canonicalID = canonical.ID
+				canonicalNames[canonical.ID] = canonical.Name
+			} else {
+				key.name = it.Name
+			}
+		}
+
+		entry, ok := groupMap[key]
 		if !ok {
 			entry = ItemGroup{
-				Name:   name,
-				Totals: make(map[ingparse.StandardUnit]float64),
-				Items:  make([]Item, 0),
+				Name:                  key.name,
+				CanonicalIngredientID: key.canonicalID,
+				Category:              it.Category,
+				Totals:                make(map[ingparse.StandardUnit]float64),
+				Items:                 make([]Item, 0),
+			}
+			if key.canonicalID != 0 {
+				entry.Name = canonicalNames[key.canonicalID]
 			}
+			keys = append(keys, key)
 		}
 
 		entry.Totals[it.StandardUnits] += it.StandardAmount
 		entry.Items = append(entry.Items, it)
-		groupMap[name] = entry
+		groupMap[key] = entry
 	}
 
-	groups := make([]ItemGroup, 0)
+	groups := make([]ItemGroup, 0, len(keys))
 
-	for _, group := range groupMap {
+	for _, key := range keys {
+		group := groupMap[key]
+		group.Quantities = aggregateItems(group.Items)
 		groups = append(groups, group)
 	}
 
 	return groups, nil
 }
 
+// GetItemGroupForGroceryListByName returns the group of items that the name
+// stands for, as GetItemGroupsForGroceryList groups them.
 func (c *Config) GetItemGroupForGroceryListByName(ctx context.Context, groceryList GroceryList, name string) (ItemGroup, error) {
-	items, err := c.GetItemsForGroceryListByName(ctx, groceryList, name)
+	canonical, inCatalog, err := findCanonicalIngredient(ctx, c.Querier(), name)
 	if err != nil {
 		return ItemGroup{}, err
 	}
-	totals := make(map[ingparse.StandardUnit]float64)
 
-	for _, item := range items {
-		totals[item.StandardUnits] += item.StandardAmount
+	groups, err := c.GetItemGroupsForGroceryList(ctx, groceryList)
+	if err != nil {
+		return ItemGroup{}, err
+	}
+
+	for _, group := range groups {
+		if inCatalog && group.CanonicalIngredientID == canonical.ID {
+			return group, nil
+		}
+		if group.CanonicalIngredientID == 0 && group.Name == name {
+			return group, nil
+		}
 	}
 
 	group := ItemGroup{
-		Name:   name,
-		Items:  items,
-		Totals: totals,
+		Name:       name,
+		Category:   CategoryOther,
+		Totals:     make(map[ingparse.StandardUnit]float64),
+		Quantities: make([]ingparse.Quantity, 0),
+		Items:      make([]Item, 0),
+	}
+	if inCatalog {
+		group.Name = canonical.Name
+		group.CanonicalIngredientID = canonical.ID
+		group.Category = databaseToDomainCategory(canonical.Category)
 	}
 
 	return group, nil
 }
 
-func (c *Config) MarkItemStatus(ctx context.Context, item Item, status ItemStatus) (Item, error) {
+// aggregateItems adds up the amounts of items, combining those in compatible
+// units.
+func aggregateItems(items []Item) []ingparse.Quantity {
+	measures := make([]ingparse.Measure, len(items))
+	for i, item := range items {
+		measures[i] = ingparse.Measure{
+			OriginalAmount: item.Amount,
+			OriginalUnits:  item.Units,
+			StandardAmount: item.StandardAmount,
+			StandardUnits:  item.StandardUnits,
+		}
+	}
+
+	return ingparse.Aggregate(measures)
+}
+
+// MarkItemStatus marks the item complete or incomplete. With restock set,
+// marking it complete also adds it to the pantry of its grocery list, and
+// marking it incomplete again takes it back out.
+func (c *Config) MarkItemStatus(ctx context.Context, user User, item Item, status ItemStatus, restock bool) (Item, error) {
+	dbGroceryList, err := c.Querier().GetGroceryList(ctx, item.GroceryListID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Item{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return Item{}, err
+	}
+	groceryList := databaseToDomainGroceryList(dbGroceryList)
+
+	if err := c.authorizeGroceryList(ctx, user, groceryList, ActionEdit); err != nil {
+		return Item{}, err
+	}
+
 	if item.Status == status {
 		return item, nil
 	}
 
-	now := time.Now()
-	isComplete := status == Complete
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return Item{}, err
+	}
+	defer tx.Rollback()
 
-	err := c.Querier().SetIsComplete(ctx, database.SetIsCompleteParams{
+	qtx := c.Querier().WithTx(tx)
+
+	item, err = setItemStatus(ctx, qtx, groceryList, item, status, restock, time.Now())
+	if err != nil {
+		return Item{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Item{}, err
+	}
+
+	c.publish(item.GroceryListID, EventItemStatusChanged, item)
+
+	return item, nil
+}
+
+// setItemStatus does the work of MarkItemStatus within a transaction, dating
+// the change at now. Whatever an item added to the pantry when it was marked
+// complete is taken back out when it is marked incomplete, with or without
+// restock.
+func setItemStatus(ctx context.Context, q *database.Queries, groceryList GroceryList, item Item, status ItemStatus, restock bool, now time.Time) (Item, error) {
+	err := q.SetIsComplete(ctx, database.SetIsCompleteParams{
 		UpdatedAt:  now,
-		IsComplete: isComplete,
+		IsComplete: status == Complete,
 		ID:         item.ID,
 	})
 	if err != nil {
 		return Item{}, err
 	}
 
+	switch {
+	case status == Complete && restock:
+		err = restockPantry(ctx, q, groceryList, item, now)
+	case status == Incomplete:
+		err = unstockPantry(ctx, q, item, now)
+	}
+	if err != nil {
+		return Item{}, err
+	}
+
+	item.UpdatedAt = now
 	item.Status = status
 
 	return item, nil
 }
+
+// SetItemCategory moves the item to another section of the store, for items
+// the ingredient catalog does not know or files under the wrong category.
+func (c *Config) SetItemCategory(ctx context.Context, user User, item Item, category ItemCategory) (Item, error) {
+	if err := c.authorizeGroceryListID(ctx, user, item.GroceryListID, ActionEdit); err != nil {
+		return Item{}, err
+	}
+
+	if category < CategoryProduce || category > CategoryOther {
+		return Item{}, domerr.ErrInvalidInput
+	}
+
+	if item.Category == category {
+		return item, nil
+	}
+
+	dbItem, err := c.Querier().SetItemCategory(ctx, database.SetItemCategoryParams{
+		UpdatedAt: time.Now(),
+		Category:  sql.NullString{String: category.String(), Valid: true},
+		ID:        item.ID,
+	})
+	if err != nil {
+		return Item{}, err
+	}
+
+	item = databaseToDomainItem(dbItem)
+	c.publish(item.GroceryListID, EventItemUpdated, item)
+
+	return item, nil
+}
+
+func (c *Config) DeleteItem(ctx context.Context, user User, item Item) error {
+	if err := c.authorizeGroceryListID(ctx, user, item.GroceryListID, ActionEdit); err != nil {
+		return err
+	}
+
+	err := c.Querier().DeleteItem(ctx, item.ID)
+	if err != nil {
+		return err
+	}
+
+	c.publish(item.GroceryListID, EventItemDeleted, item)
+
+	return nil
+}
+
+// ListItemsForGroceryList returns one page of the items on the grocery list, and the cursor of the next page, which is
+// empty on the last page.
+func (c *Config) ListItemsForGroceryList(ctx context.Context, groceryList GroceryList, params ItemListParams) ([]Item, string, error) {
+	pg, err := params.page()
+	if err != nil {
+		return nil, "", err
+	}
+
+	includeIncomplete := params.Status == 0 || params.Status == Incomplete
+	includeComplete := params.Status == 0 || params.Status == Complete
+
+	var rows []database.Item
+	switch pg.sort {
+	case SortCreatedAt:
+		rows, err = c.Querier().ListItemsForGroceryList(ctx, database.ListItemsForGroceryListParams{
+			GroceryListID:     groceryList.ID,
+			NameContains:      pg.nameContains,
+			CreatedAfter:      pg.createdAfter,
+			IncludeIncomplete: includeIncomplete,
+			IncludeComplete:   includeComplete,
+			AfterID:           pg.after.ID,
+			Limit:             pg.queryLimit(),
+		})
+	case SortCreatedAtDesc:
+		rows, err = c.Querier().ListItemsForGroceryListDesc(ctx, database.ListItemsForGroceryListDescParams{
+			GroceryListID:     groceryList.ID,
+			NameContains:      pg.nameContains,
+			CreatedAfter:      pg.createdAfter,
+			IncludeIncomplete: includeIncomplete,
+			IncludeComplete:   includeComplete,
+			BeforeID:          pg.after.ID,
+			Limit:             pg.queryLimit(),
+		})
+	case SortName:
+		rows, err = c.Querier().ListItemsForGroceryListByName(ctx, database.ListItemsForGroceryListByNameParams{
+			GroceryListID:     groceryList.ID,
+			NameContains:      pg.nameContains,
+			CreatedAfter:      pg.createdAfter,
+			IncludeIncomplete: includeIncomplete,
+			IncludeComplete:   includeComplete,
+			FromStart:         pg.fromStart,
+			AfterName:         pg.after.Name,
+			AfterID:           pg.after.ID,
+			Limit:             pg.queryLimit(),
+		})
+	case SortNameDesc:
+		rows, err = c.Querier().ListItemsForGroceryListByNameDesc(ctx, database.ListItemsForGroceryListByNameDescParams{
+			GroceryListID:     groceryList.ID,
+			NameContains:      pg.nameContains,
+			CreatedAfter:      pg.createdAfter,
+			IncludeIncomplete: includeIncomplete,
+			IncludeComplete:   includeComplete,
+			FromStart:         pg.fromStart,
+			BeforeName:        pg.after.Name,
+			BeforeID:          pg.after.ID,
+			Limit:             pg.queryLimit(),
+		})
+	default:
+		return nil, "", domerr.ErrInvalidSort
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	list := make([]Item, pg.trim(len(rows)))
+	for i := range list {
+		list[i] = databaseToDomainItem(rows[i])
+	}
+
+	var next string
+	if len(list) > 0 {
+		last := list[len(list)-1]
+		next = pg.nextCursor(len(rows), cursor{Name: last.Name, ID: last.ID})
+	}
+
+	return list, next, nil
+}
@@ -0,0 +1,305 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+	"github.com/snorman7384/recipe-wizard/internal/misc"
+)
+
+// DateLayout is how the days of the meal plan are written.
+const DateLayout = "2006-01-02"
+
+func databaseToDomainPlannedMeal(pm database.PlannedMeal, recipe Recipe) PlannedMeal {
+	date, _ := time.Parse(DateLayout, pm.Date)
+	slot, _ := MealSlotFromString(pm.Slot)
+	return PlannedMeal{
+		ID:          pm.ID,
+		CreatedAt:   pm.CreatedAt,
+		UpdatedAt:   pm.UpdatedAt,
+		OwnerID:     pm.OwnerID,
+		HouseholdID: pm.HouseholdID.Int64,
+		Date:        date,
+		Slot:        slot,
+		SlotName:    pm.SlotName.String,
+		Servings:    pm.Servings.Int64,
+		MealID:      pm.MealID.Int64,
+		Recipe:      recipe,
+	}
+}
+
+func (c *Config) authorizePlannedMeal(ctx context.Context, user User, plannedMeal PlannedMeal, action Action) error {
+	return c.authorize(ctx, user, plannedMeal.OwnerID, plannedMeal.HouseholdID, action)
+}
+
+// checkPlannedMealParams validates the params of a planned meal, and returns
+// its recipe. Only custom slots keep their name.
+func (c *Config) checkPlannedMealParams(ctx context.Context, user User, params *PlannedMealParams) (Recipe, error) {
+	params.SlotName = strings.TrimSpace(params.SlotName)
+	if params.Slot != SlotCustom {
+		params.SlotName = ""
+	}
+
+	if params.Date.IsZero() || params.Slot < SlotBreakfast || params.Slot > SlotCustom || (params.Slot == SlotCustom && params.SlotName == "") {
+		return Recipe{}, domerr.ErrInvalidInput
+	}
+
+	recipe, err := c.GetRecipe(ctx, user, params.RecipeID)
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	if _, err := servingsScale(recipe, params.Servings); err != nil {
+		return Recipe{}, err
+	}
+
+	return recipe, nil
+}
+
+// CreatePlannedMeal plans a recipe for a slot of a day, shared with the
+// household if one is given.
+func (c *Config) CreatePlannedMeal(ctx context.Context, user User, params PlannedMealParams) (PlannedMeal, error) {
+	recipe, err := c.checkPlannedMealParams(ctx, user, &params)
+	if err != nil {
+		return PlannedMeal{}, err
+	}
+
+	if err := c.checkCanShareWith(ctx, user, params.HouseholdID); err != nil {
+		return PlannedMeal{}, err
+	}
+
+	now := time.Now()
+	plannedMeal, err := c.Querier().CreatePlannedMeal(ctx, database.CreatePlannedMealParams{
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		OwnerID:     user.ID,
+		HouseholdID: misc.SqlNullInt64FromPositiveInt64(params.HouseholdID),
+		Date:        params.Date.Format(DateLayout),
+		Slot:        params.Slot.String(),
+		SlotName:    misc.SqlNullStringFromString(params.SlotName),
+		RecipeID:    recipe.ID,
+		Servings:    misc.SqlNullInt64FromPositiveInt64(params.Servings),
+	})
+	if err != nil {
+		return PlannedMeal{}, err
+	}
+
+	return databaseToDomainPlannedMeal(plannedMeal, recipe), nil
+}
+
+func (c *Config) GetPlannedMeal(ctx context.Context, user User, id int64) (PlannedMeal, error) {
+	row, err := c.Querier().GetExtendedPlannedMeal(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return PlannedMeal{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return PlannedMeal{}, err
+	}
+
+	plannedMeal := databaseToDomainPlannedMeal(row.PlannedMeal, databaseToDomainRecipe(row.Recipe))
+	if err := c.authorizePlannedMeal(ctx, user, plannedMeal, ActionView); err != nil {
+		return PlannedMeal{}, err
+	}
+
+	return plannedMeal, nil
+}
+
+// GetPlannedMeals returns the meals the user, or a household they are in, has
+// planned from one day to another, both included. They are sorted by day, and
+// then by slot.
+func (c *Config) GetPlannedMeals(ctx context.Context, user User, from time.Time, to time.Time) ([]PlannedMeal, error) {
+	if to.Before(from) {
+		return nil, domerr.ErrInvalidInput
+	}
+
+	rows, err := c.Querier().GetExtendedPlannedMealsForUser(ctx, database.GetExtendedPlannedMealsForUserParams{
+		OwnerID:  user.ID,
+		MemberID: user.ID,
+		FromDate: from.Format(DateLayout),
+		ToDate:   to.Format(DateLayout),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plannedMeals := make([]PlannedMeal, len(rows))
+	for i, row := range rows {
+		plannedMeals[i] = databaseToDomainPlannedMeal(row.PlannedMeal, databaseToDomainRecipe(row.Recipe))
+	}
+
+	sort.SliceStable(plannedMeals, func(i, j int) bool {
+		if !plannedMeals[i].Date.Equal(plannedMeals[j].Date) {
+			return plannedMeals[i].Date.Before(plannedMeals[j].Date)
+		}
+		return plannedMeals[i].Slot < plannedMeals[j].Slot
+	})
+
+	return plannedMeals, nil
+}
+
+// UpdatePlannedMeal replaces what is planned. Moving it to another household
+// takes the same rights as moving a grocery list.
+func (c *Config) UpdatePlannedMeal(ctx context.Context, user User, plannedMeal PlannedMeal, params PlannedMealParams) (PlannedMeal, error) {
+	if err := c.authorizePlannedMeal(ctx, user, plannedMeal, ActionEdit); err != nil {
+		return PlannedMeal{}, err
+	}
+
+	if params.HouseholdID != plannedMeal.HouseholdID {
+		if err := c.authorizePlannedMeal(ctx, user, plannedMeal, ActionDelete); err != nil {
+			return PlannedMeal{}, err
+		}
+		if err := c.checkCanShareWith(ctx, user, params.HouseholdID); err != nil {
+			return PlannedMeal{}, err
+		}
+	}
+
+	recipe, err := c.checkPlannedMealParams(ctx, user, &params)
+	if err != nil {
+		return PlannedMeal{}, err
+	}
+
+	updated, err := c.Querier().UpdatePlannedMeal(ctx, database.UpdatePlannedMealParams{
+		UpdatedAt:   time.Now(),
+		HouseholdID: misc.SqlNullInt64FromPositiveInt64(params.HouseholdID),
+		Date:        params.Date.Format(DateLayout),
+		Slot:        params.Slot.String(),
+		SlotName:    misc.SqlNullStringFromString(params.SlotName),
+		RecipeID:    recipe.ID,
+		Servings:    misc.SqlNullInt64FromPositiveInt64(params.Servings),
+		ID:          plannedMeal.ID,
+	})
+	if err != nil {
+		return PlannedMeal{}, err
+	}
+
+	return databaseToDomainPlannedMeal(updated, recipe), nil
+}
+
+// DeletePlannedMeal takes a meal off the plan. The meal it was put on a
+// grocery list as, if any, stays on the list.
+func (c *Config) DeletePlannedMeal(ctx context.Context, user User, plannedMeal PlannedMeal) error {
+	if err := c.authorizePlannedMeal(ctx, user, plannedMeal, ActionDelete); err != nil {
+		return err
+	}
+
+	return c.Querier().DeletePlannedMeal(ctx, plannedMeal.ID)
+}
+
+// BuildGroceryListFromMealPlan puts every meal the user has planned from one
+// day to another on the grocery list, as CreateMeal does. Planned meals that
+// are already on the list are not added again; if their servings changed since,
+// their items are rescaled instead. Planned meals whose recipe changed replace
+// the meal they were put on the list as, since its items no longer fit.
+//
+// It returns the meals that were added and those that were rescaled. All
+// planned meals are put on the list in one transaction.
+func (c *Config) BuildGroceryListFromMealPlan(ctx context.Context, user User, groceryList GroceryList, from time.Time, to time.Time) ([]Meal, []Meal, error) {
+	if err := c.authorizeGroceryList(ctx, user, groceryList, ActionEdit); err != nil {
+		return nil, nil, err
+	}
+
+	plannedMeals, err := c.GetPlannedMeals(ctx, user, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the planned meals of a household may use recipes of other members,
+	// which the user cannot see
+	for _, plannedMeal := range plannedMeals {
+		if err := c.authorizeRecipe(ctx, user, plannedMeal.Recipe, ActionView); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	var added, rescaled, removed []Meal
+	var createdItems, rescaledItems, deletedItems []Item
+
+	for _, plannedMeal := range plannedMeals {
+		if plannedMeal.MealID != 0 {
+			row, err := qtx.GetExtendedMeal(ctx, plannedMeal.MealID)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, nil, err
+			}
+
+			if err == nil && row.Meal.GroceryListID == groceryList.ID {
+				meal := databaseToDomainMeal(row.Meal, databaseToDomainRecipe(row.Recipe))
+
+				switch {
+				case meal.Recipe.ID != plannedMeal.Recipe.ID:
+					items, err := deleteMeal(ctx, qtx, meal)
+					if err != nil {
+						return nil, nil, err
+					}
+					removed = append(removed, meal)
+					deletedItems = append(deletedItems, items...)
+
+				case meal.Servings != plannedMeal.Servings:
+					meal, changes, err := setMealServings(ctx, qtx, groceryList, meal, plannedMeal.Servings)
+					if err != nil {
+						return nil, nil, err
+					}
+					rescaled = append(rescaled, meal)
+					createdItems = append(createdItems, changes.created...)
+					rescaledItems = append(rescaledItems, changes.updated...)
+					deletedItems = append(deletedItems, changes.deleted...)
+					continue
+
+				default:
+					continue
+				}
+			}
+		}
+
+		meal, items, err := createMeal(ctx, qtx, groceryList, plannedMeal.Recipe, plannedMeal.Servings)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = qtx.SetPlannedMealMeal(ctx, database.SetPlannedMealMealParams{
+			MealID: sql.NullInt64{Int64: meal.ID, Valid: true},
+			ID:     plannedMeal.ID,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		added = append(added, meal)
+		createdItems = append(createdItems, items...)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	for _, item := range deletedItems {
+		c.publish(groceryList.ID, EventItemDeleted, item)
+	}
+	for _, meal := range removed {
+		c.publish(groceryList.ID, EventMealRemoved, meal)
+	}
+	for _, meal := range added {
+		c.publish(groceryList.ID, EventMealAdded, meal)
+	}
+	for _, item := range createdItems {
+		c.publish(groceryList.ID, EventItemCreated, item)
+	}
+	for _, item := range rescaledItems {
+		c.publish(groceryList.ID, EventItemUpdated, item)
+	}
+
+	return added, rescaled, nil
+}
@@ -3,9 +3,13 @@ package domain
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/ingparse"
 	"github.com/snorman7384/recipe-wizard/internal/database"
+	"github.com/snorman7384/recipe-wizard/internal/misc"
 )
 
 func databaseToDomainMeal(m database.Meal, recipe Recipe) Meal {
@@ -14,11 +18,28 @@ func databaseToDomainMeal(m database.Meal, recipe Recipe) Meal {
 		CreatedAt:     m.CreatedAt,
 		UpdatedAt:     m.UpdatedAt,
 		GroceryListID: m.GroceryListID,
+		Servings:      m.Servings.Int64,
 		Recipe:        recipe,
 	}
 }
 
-func (c *Config) CreateMeal(ctx context.Context, user User, groceryList GroceryList, recipeID int64) (Meal, error) {
+// CreateMeal adds a recipe to a grocery list, along with an item for each of
+// its ingredients. Amounts are scaled to make the given number of servings; 0
+// servings makes the recipe as written.
+//
+// What the pantry of the grocery list holds is netted out of the amounts and
+// reserved for the meal, and ingredients the pantry covers, or that are always
+// stocked, get no item.
+func (c *Config) CreateMeal(ctx context.Context, user User, groceryList GroceryList, recipeID int64, servings int64) (Meal, error) {
+	if err := c.authorizeGroceryList(ctx, user, groceryList, ActionEdit); err != nil {
+		return Meal{}, err
+	}
+
+	recipe, err := c.GetRecipe(ctx, user, recipeID)
+	if err != nil {
+		return Meal{}, err
+	}
+
 	tx, err := c.DB.Begin()
 	if err != nil {
 		return Meal{}, err
@@ -27,51 +48,115 @@ func (c *Config) CreateMeal(ctx context.Context, user User, groceryList GroceryL
 
 	qtx := c.Querier().WithTx(tx)
 
+	meal, items, err := createMeal(ctx, qtx, groceryList, recipe, servings)
+	if err != nil {
+		return Meal{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Meal{}, err
+	}
+
+	c.publish(groceryList.ID, EventMealAdded, meal)
+	for _, item := range items {
+		c.publish(groceryList.ID, EventItemCreated, item)
+	}
+
+	return meal, nil
+}
+
+// createMeal does the work of CreateMeal within a transaction, and returns the
+// items it created along with the meal.
+func createMeal(ctx context.Context, q *database.Queries, groceryList GroceryList, recipe Recipe, servings int64) (Meal, []Item, error) {
+	scale, err := servingsScale(recipe, servings)
+	if err != nil {
+		return Meal{}, nil, err
+	}
+
 	now := time.Now()
 
-	meal, err := qtx.CreateMeal(ctx, database.CreateMealParams{
+	meal, err := q.CreateMeal(ctx, database.CreateMealParams{
 		CreatedAt:     now,
 		UpdatedAt:     now,
-		RecipeID:      recipeID,
+		RecipeID:      recipe.ID,
 		GroceryListID: groceryList.ID,
+		Servings:      misc.SqlNullInt64FromPositiveInt64(servings),
 	})
 	if err != nil {
-		return Meal{}, err
+		return Meal{}, nil, err
 	}
 
-	recipe, err := qtx.GetRecipe(ctx, recipeID)
+	ingredients, err := q.GetIngredientsForRecipe(ctx, recipe.ID)
 	if err != nil {
-		return Meal{}, err
-	}
-
-	ingredients, err := qtx.GetIngredientsForRecipe(ctx, recipeID)
-	if err != nil {
-		return Meal{}, err
+		return Meal{}, nil, err
 	}
 
+	items := make([]Item, 0, len(ingredients))
 	for _, ingredient := range ingredients {
+		need := ingparse.Measure{
+			StandardAmount: ingredient.StandardAmount * scale,
+			StandardUnits:  ingparse.StandardUnitFromString(ingredient.StandardUnits),
+		}
 
-		now := time.Now()
-
-		_, err := qtx.CreateItem(ctx, database.CreateItemParams{
-			CreatedAt:      now,
-			UpdatedAt:      now,
-			IngredientID:   sql.NullInt64{Int64: ingredient.ID, Valid: true},
-			GroceryListID:  groceryList.ID,
-			MealID:         sql.NullInt64{Int64: meal.ID, Valid: true},
-			Name:           ingredient.Name,
-			Description:    ingredient.Description,
-			Amount:         ingredient.Amount,
-			Units:          ingredient.Units,
-			StandardAmount: ingredient.StandardAmount,
-			StandardUnits:  ingredient.StandardUnits,
-		})
+		remaining, covered, err := takeFromPantry(ctx, q, groceryList, meal.ID, ingredient.CanonicalIngredientID, need)
 		if err != nil {
-			return Meal{}, err
+			return Meal{}, nil, err
 		}
+		if covered {
+			continue
+		}
+
+		item, err := createMealItem(ctx, q, groceryList, meal.ID, ingredient, scale, remaining)
+		if err != nil {
+			return Meal{}, nil, err
+		}
+		items = append(items, item)
+	}
+
+	return databaseToDomainMeal(meal, recipe), items, nil
+}
+
+// mealItemAmount is the amount of an ingredient, in the units of the recipe,
+// that an item has to buy when the meal still needs the given standard amount
+// of it.
+func mealItemAmount(ingredient database.Ingredient, scale float64, remaining float64) float64 {
+	amount := ingredient.Amount * scale
+	if need := ingredient.StandardAmount * scale; need > 0 {
+		amount *= remaining / need
+	}
+	return amount
+}
+
+// createMealItem adds an item to the grocery list for what is left to buy of
+// an ingredient of a meal.
+func createMealItem(ctx context.Context, q *database.Queries, groceryList GroceryList, mealID int64, ingredient database.Ingredient, scale float64, remaining float64) (Item, error) {
+	category, err := canonicalCategory(ctx, q, ingredient.CanonicalIngredientID)
+	if err != nil {
+		return Item{}, err
+	}
+
+	now := time.Now()
+
+	item, err := q.CreateItem(ctx, database.CreateItemParams{
+		CreatedAt:             now,
+		UpdatedAt:             now,
+		IngredientID:          sql.NullInt64{Int64: ingredient.ID, Valid: true},
+		CanonicalIngredientID: ingredient.CanonicalIngredientID,
+		GroceryListID:         groceryList.ID,
+		MealID:                sql.NullInt64{Int64: mealID, Valid: true},
+		Name:                  ingredient.Name,
+		Description:           ingredient.Description,
+		Amount:                mealItemAmount(ingredient, scale, remaining),
+		Units:                 ingredient.Units,
+		StandardAmount:        remaining,
+		StandardUnits:         ingredient.StandardUnits,
+		Category:              category,
+	})
+	if err != nil {
+		return Item{}, err
 	}
 
-	return databaseToDomainMeal(meal, databaseToDomainRecipe(recipe)), tx.Commit()
+	return databaseToDomainItem(item), nil
 }
 
 func (c *Config) GetMealsInGroceryList(ctx context.Context, groceryList GroceryList) ([]Meal, error) {
@@ -89,3 +174,299 @@ func (c *Config) GetMealsInGroceryList(ctx context.Context, groceryList GroceryL
 
 	return meals, nil
 }
+
+func (c *Config) GetMeal(ctx context.Context, user User, id int64) (Meal, error) {
+	row, err := c.Querier().GetExtendedMeal(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Meal{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return Meal{}, err
+	}
+
+	if err := c.authorizeGroceryListID(ctx, user, row.Meal.GroceryListID, ActionView); err != nil {
+		return Meal{}, err
+	}
+
+	return databaseToDomainMeal(row.Meal, databaseToDomainRecipe(row.Recipe)), nil
+}
+
+// DeleteMeal deletes a meal and the items that were generated for it.
+func (c *Config) DeleteMeal(ctx context.Context, user User, meal Meal) error {
+	if err := c.authorizeGroceryListID(ctx, user, meal.GroceryListID, ActionEdit); err != nil {
+		return err
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	items, err := deleteMeal(ctx, qtx, meal)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		c.publish(meal.GroceryListID, EventItemDeleted, item)
+	}
+	c.publish(meal.GroceryListID, EventMealRemoved, meal)
+
+	return nil
+}
+
+// deleteMeal does the work of DeleteMeal within a transaction, and returns the
+// items it deleted.
+func deleteMeal(ctx context.Context, q *database.Queries, meal Meal) ([]Item, error) {
+	dbItems, err := q.GetItemsForMeal(ctx, sql.NullInt64{Int64: meal.ID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.DeleteItemsForMeal(ctx, sql.NullInt64{Int64: meal.ID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.DeleteMealPantryUses(ctx, meal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.UnlinkPlannedMealsFromMeal(ctx, sql.NullInt64{Int64: meal.ID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.DeleteMeal(ctx, meal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, len(dbItems))
+	for i, item := range dbItems {
+		items[i] = databaseToDomainItem(item)
+	}
+
+	return items, nil
+}
+
+// SetMealServings changes the number of servings a meal makes and recomputes
+// what is left to buy for it, netting the pantry of the grocery list out again.
+// Items are created, resized or deleted to match; bought items are left as
+// they are and count towards what the meal needs.
+func (c *Config) SetMealServings(ctx context.Context, user User, meal Meal, servings int64) (Meal, error) {
+	dbGroceryList, err := c.Querier().GetGroceryList(ctx, meal.GroceryListID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Meal{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return Meal{}, err
+	}
+	groceryList := databaseToDomainGroceryList(dbGroceryList)
+
+	if err := c.authorizeGroceryList(ctx, user, groceryList, ActionEdit); err != nil {
+		return Meal{}, err
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return Meal{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	updated, changes, err := setMealServings(ctx, qtx, groceryList, meal, servings)
+	if err != nil {
+		return Meal{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Meal{}, err
+	}
+
+	for _, item := range changes.deleted {
+		c.publish(meal.GroceryListID, EventItemDeleted, item)
+	}
+	for _, item := range changes.created {
+		c.publish(meal.GroceryListID, EventItemCreated, item)
+	}
+	for _, item := range changes.updated {
+		c.publish(meal.GroceryListID, EventItemUpdated, item)
+	}
+
+	return updated, nil
+}
+
+// itemChanges are the items a change to a meal created, updated and deleted.
+type itemChanges struct {
+	created []Item
+	updated []Item
+	deleted []Item
+}
+
+// setMealServings does the work of SetMealServings within a transaction, and
+// returns the items it changed along with the meal.
+func setMealServings(ctx context.Context, q *database.Queries, groceryList GroceryList, meal Meal, servings int64) (Meal, itemChanges, error) {
+	oldScale, err := servingsScale(meal.Recipe, meal.Servings)
+	if err != nil {
+		return Meal{}, itemChanges{}, err
+	}
+
+	scale, err := servingsScale(meal.Recipe, servings)
+	if err != nil {
+		return Meal{}, itemChanges{}, err
+	}
+
+	now := time.Now()
+
+	updated, err := q.SetMealServings(ctx, database.SetMealServingsParams{
+		UpdatedAt: now,
+		Servings:  misc.SqlNullInt64FromPositiveInt64(servings),
+		ID:        meal.ID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Meal{}, itemChanges{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return Meal{}, itemChanges{}, err
+	}
+
+	items, err := q.GetItemsForMeal(ctx, sql.NullInt64{Int64: meal.ID, Valid: true})
+	if err != nil {
+		return Meal{}, itemChanges{}, err
+	}
+
+	ingredients, err := q.GetIngredientsForRecipe(ctx, meal.Recipe.ID)
+	if err != nil {
+		return Meal{}, itemChanges{}, err
+	}
+
+	// the pantry may not hold enough for more servings, and holds more than
+	// fewer servings need, so what the meal reserved is released and taken
+	// again for the new amounts
+	err = q.DeleteMealPantryUses(ctx, meal.ID)
+	if err != nil {
+		return Meal{}, itemChanges{}, err
+	}
+
+	itemsByIngredient := make(map[int64][]database.Item)
+	for _, item := range items {
+		if item.IngredientID.Valid {
+			itemsByIngredient[item.IngredientID.Int64] = append(itemsByIngredient[item.IngredientID.Int64], item)
+		}
+	}
+
+	var changes itemChanges
+	for _, ingredient := range ingredients {
+		need := ingparse.Measure{
+			StandardAmount: ingredient.StandardAmount * scale,
+			StandardUnits:  ingparse.StandardUnitFromString(ingredient.StandardUnits),
+		}
+
+		// bought items count towards the need, unless they were restocked,
+		// in which case they are in the pantry and are taken from there
+		total := need.StandardAmount
+		var toBuy *database.Item
+		bought := false
+		for _, item := range itemsByIngredient[ingredient.ID] {
+			item := item
+			if !item.IsComplete {
+				toBuy = &item
+				continue
+			}
+
+			bought = true
+			_, err := q.GetItemRestock(ctx, item.ID)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return Meal{}, itemChanges{}, err
+			}
+			need.StandardAmount -= item.StandardAmount
+		}
+		delete(itemsByIngredient, ingredient.ID)
+
+		var remaining float64
+		var covered bool
+		// allow for rounding when the bought items are exactly what is needed
+		if bought && need.StandardAmount <= total*1e-9 {
+			covered = true
+		} else {
+			remaining, covered, err = takeFromPantry(ctx, q, groceryList, meal.ID, ingredient.CanonicalIngredientID, need)
+			if err != nil {
+				return Meal{}, itemChanges{}, err
+			}
+		}
+
+		switch {
+		case covered && toBuy != nil:
+			err = q.DeleteItem(ctx, toBuy.ID)
+			if err != nil {
+				return Meal{}, itemChanges{}, err
+			}
+			changes.deleted = append(changes.deleted, databaseToDomainItem(*toBuy))
+
+		case covered:
+			// nothing is left to buy, and there is no item to delete
+
+		case toBuy != nil:
+			toBuy.UpdatedAt = now
+			toBuy.Amount = mealItemAmount(ingredient, scale, remaining)
+			toBuy.StandardAmount = remaining
+
+			err = q.SetItemAmounts(ctx, database.SetItemAmountsParams{
+				UpdatedAt:      toBuy.UpdatedAt,
+				Amount:         toBuy.Amount,
+				StandardAmount: toBuy.StandardAmount,
+				ID:             toBuy.ID,
+			})
+			if err != nil {
+				return Meal{}, itemChanges{}, err
+			}
+			changes.updated = append(changes.updated, databaseToDomainItem(*toBuy))
+
+		case !bought || remaining > 0:
+			item, err := createMealItem(ctx, q, groceryList, meal.ID, ingredient, scale, remaining)
+			if err != nil {
+				return Meal{}, itemChanges{}, err
+			}
+			changes.created = append(changes.created, item)
+		}
+	}
+
+	// items of ingredients that have since been removed from the recipe are
+	// only rescaled
+	ratio := scale / oldScale
+	for _, item := range items {
+		if item.IsComplete || (item.IngredientID.Valid && itemsByIngredient[item.IngredientID.Int64] == nil) {
+			continue
+		}
+
+		item.UpdatedAt = now
+		item.Amount *= ratio
+		item.StandardAmount *= ratio
+
+		err = q.SetItemAmounts(ctx, database.SetItemAmountsParams{
+			UpdatedAt:      item.UpdatedAt,
+			Amount:         item.Amount,
+			StandardAmount: item.StandardAmount,
+			ID:             item.ID,
+		})
+		if err != nil {
+			return Meal{}, itemChanges{}, err
+		}
+		changes.updated = append(changes.updated, databaseToDomainItem(item))
+	}
+
+	return databaseToDomainMeal(updated, meal.Recipe), changes, nil
+}
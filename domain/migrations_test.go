@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// migrateTestDB applies the migrations before the named one, runs setup, and
+// then applies the named migration and the ones after it, so that migrations
+// can be tested against data in the schema they start from.
+func migrateTestDB(t *testing.T, db *sql.DB, name string, setup func()) {
+	t.Helper()
+
+	migrations := testMigrations(t)
+	for _, migration := range migrations {
+		if filepath.Base(migration) == name {
+			setup()
+			setup = nil
+		}
+		applyTestMigration(t, db, migration)
+	}
+	if setup != nil {
+		t.Fatalf("no migration %s", name)
+	}
+}
+
+func testQueryIDs(t *testing.T, db *sql.DB, query string) []int64 {
+	t.Helper()
+
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+func TestForeignKeysMigrationDropsOrphans(t *testing.T) {
+	db := newTestDB(t)
+
+	migrateTestDB(t, db, "20261018091500_foreign_keys.sql", func() {
+		for _, stmt := range []string{
+			`INSERT INTO users (id, created_at, updated_at, username, hashed_password) VALUES (1, 0, 0, 'cook', 'x')`,
+			// recipe 2 is from before recipes had owners
+			`INSERT INTO recipes (id, created_at, updated_at, name, owner_id) VALUES (1, 0, 0, 'soup', 1)`,
+			`INSERT INTO recipes (id, created_at, updated_at, name) VALUES (2, 0, 0, 'stew')`,
+			`INSERT INTO ingredients (id, created_at, updated_at, name, recipe_id, amount, units, standard_amount, standard_units)
+			VALUES (1, 0, 0, 'onion', 1, 1, '', 1, 'whole'), (2, 0, 0, 'beef', 2, 1, '', 1, 'whole'), (3, 0, 0, 'salt', 99, 1, '', 1, 'whole')`,
+			`INSERT INTO grocery_lists (id, created_at, updated_at, name, owner_id) VALUES (1, 0, 0, 'groceries', 1), (2, 0, 0, 'orphan', 42)`,
+			`INSERT INTO meals (id, created_at, updated_at, grocery_list_id, recipe_id) VALUES (1, 0, 0, 1, 1), (2, 0, 0, 1, 2), (3, 0, 0, 2, 1)`,
+			`INSERT INTO items (id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, amount, units, standard_amount, standard_units) VALUES
+			(1, 0, 0, 1, 1, 1, 'onion', 1, '', 1, 'whole'),
+			(2, 0, 0, 1, 2, 2, 'beef', 1, '', 1, 'whole'),
+			(3, 0, 0, 1, NULL, 3, 'salt', 1, '', 1, 'whole'),
+			(4, 0, 0, 2, NULL, NULL, 'bread', 1, '', 1, 'whole'),
+			(5, 0, 0, 1, 77, NULL, 'milk', 1, '', 1, 'whole')`,
+		} {
+			if _, err := db.Exec(stmt); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	tests := []struct {
+		query string
+		want  []int64
+	}{
+		{"SELECT id FROM recipes ORDER BY id", []int64{1}},
+		{"SELECT id FROM ingredients ORDER BY id", []int64{1}},
+		{"SELECT id FROM grocery_lists ORDER BY id", []int64{1}},
+		{"SELECT id FROM meals ORDER BY id", []int64{1}},
+		{"SELECT id FROM items ORDER BY id", []int64{1, 3}},
+		{"SELECT id FROM items WHERE ingredient_id IS NULL ORDER BY id", []int64{3}},
+		{"SELECT rowid FROM pragma_foreign_key_check", []int64{}},
+	}
+
+	for _, tt := range tests {
+		if got := testQueryIDs(t, db, tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestRecipeDurationsMigration(t *testing.T) {
+	tests := []struct {
+		old  string
+		want sql.NullInt64
+	}{
+		{"1h30m0s", sql.NullInt64{Int64: 5400, Valid: true}},
+		{"45m0s", sql.NullInt64{Int64: 2700, Valid: true}},
+		{"500ms", sql.NullInt64{Int64: 1, Valid: true}},
+		{"2m30.4s", sql.NullInt64{Int64: 150, Valid: true}},
+		{"PT1H30M", sql.NullInt64{Int64: 5400, Valid: true}},
+		{"pt20m", sql.NullInt64{Int64: 1200, Valid: true}},
+		{"PT1M", sql.NullInt64{Int64: 60, Valid: true}},
+		{"PT1.5S", sql.NullInt64{Int64: 2, Valid: true}},
+		{"P1DT2H", sql.NullInt64{Int64: 93600, Valid: true}},
+		{"P2D", sql.NullInt64{Int64: 172800, Valid: true}},
+		{"P0Y0M0DT1H", sql.NullInt64{Int64: 3600, Valid: true}},
+		{"P0WT5M", sql.NullInt64{Int64: 300, Valid: true}},
+		{"P1M", sql.NullInt64{}},
+		{"P1Y", sql.NullInt64{}},
+		{"P1W", sql.NullInt64{}},
+		{"P1Y2M", sql.NullInt64{}},
+		{"P1MT1M", sql.NullInt64{}},
+		{"P1.5D", sql.NullInt64{}},
+		{"PT1H1D", sql.NullInt64{}},
+		{"PT5MS", sql.NullInt64{}},
+		{"P1DT", sql.NullInt64{}},
+		{"PT", sql.NullInt64{}},
+		{"P", sql.NullInt64{}},
+		{"0y1h", sql.NullInt64{}},
+		{"0s", sql.NullInt64{}},
+		{"30 minutes", sql.NullInt64{}},
+		{"", sql.NullInt64{}},
+	}
+
+	db := newTestDB(t)
+
+	migrateTestDB(t, db, "20261018200000_recipe_durations.sql", func() {
+		if _, err := db.Exec(`INSERT INTO users (id, created_at, updated_at, username, hashed_password) VALUES (1, 0, 0, 'cook', 'x')`); err != nil {
+			t.Fatal(err)
+		}
+		for i, tt := range tests {
+			_, err := db.Exec(`INSERT INTO recipes (id, created_at, updated_at, name, owner_id, prep_time) VALUES (?, 0, 0, 'soup', 1, ?)`, i+1, tt.old)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	for i, tt := range tests {
+		var got sql.NullInt64
+		if err := db.QueryRow(`SELECT prep_time_seconds FROM recipes WHERE id = ?`, i+1).Scan(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%q = %v, want %v", tt.old, got, tt.want)
+		}
+	}
+}
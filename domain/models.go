@@ -9,40 +9,53 @@ import (
 )
 
 type GroceryList struct {
-	ID        int64
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Name      string
-	OwnerID   int64
+	ID          int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Name        string
+	OwnerID     int64
+	HouseholdID int64 // 0 when the list is not shared with a household
 }
 
 type Ingredient struct {
-	ID             int64
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	Name           string
-	Description    string
-	RecipeID       int64
-	Amount         float64
-	Units          string
-	StandardAmount float64
-	StandardUnits  ingparse.StandardUnit
+	ID                    int64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	Name                  string
+	Description           string
+	RecipeID              int64
+	Amount                float64
+	Units                 string
+	StandardAmount        float64
+	StandardUnits         ingparse.StandardUnit
+	CanonicalIngredientID int64 // the entry in the ingredient catalog, 0 if there is none
+}
+
+type Instruction struct {
+	ID        int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	RecipeID  int64
+	Step      int64
+	Text      string
 }
 
 type Item struct {
-	ID             int64
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	GroceryListID  int64
-	MealID         int64
-	IngredientID   int64
-	Name           string
-	Description    string
-	Amount         float64
-	Units          string
-	StandardAmount float64
-	StandardUnits  ingparse.StandardUnit
-	Status         ItemStatus
+	ID                    int64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	GroceryListID         int64
+	MealID                int64
+	IngredientID          int64
+	CanonicalIngredientID int64 // the entry in the ingredient catalog, 0 if there is none
+	Name                  string
+	Description           string
+	Amount                float64
+	Units                 string
+	StandardAmount        float64
+	StandardUnits         ingparse.StandardUnit
+	Status                ItemStatus
+	Category              ItemCategory
 }
 
 type Recipe struct {
@@ -52,10 +65,37 @@ type Recipe struct {
 	Name        string
 	Description string
 	Url         string
-	PrepTime    string
-	CookTime    string
-	TotalTime   string
+	PrepTime    time.Duration // 0 when the recipe does not say, as are the other times
+	CookTime    time.Duration
+	TotalTime   time.Duration
+	Servings    int64 // 0 when the recipe does not say
 	OwnerID     int64
+	HouseholdID int64 // 0 when the recipe is not shared with a household
+}
+
+// RecipeImage is a picture of a recipe, kept in the blob store under Key. Each
+// image gets a new key, so the bytes under a key never change.
+type RecipeImage struct {
+	RecipeID    int64
+	CreatedAt   time.Time
+	Key         string
+	ContentType string
+	Size        int64  // in bytes
+	SourceURL   string // where a scraped image was downloaded from; empty for uploads
+}
+
+// RecipeTag is a label on a recipe. Names are lower case.
+type RecipeTag struct {
+	RecipeID  int64
+	CreatedAt time.Time
+	Name      string
+	Kind      TagKind
+}
+
+// TagCount is a tag and how many of the recipes a user can see have it.
+type TagCount struct {
+	Name  string
+	Count int64
 }
 
 type Meal struct {
@@ -63,6 +103,7 @@ type Meal struct {
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	GroceryListID int64
+	Servings      int64 // 0 when the meal is made as the recipe is written
 	Recipe        Recipe
 }
 
@@ -74,12 +115,178 @@ type User struct {
 	HashedPassword string
 	FirstName      string
 	LastName       string
+	Email          string
+	TokenVersion   int64 // access tokens carrying an older version are revoked
+}
+
+// RecipeSearchResult is a recipe that matched a search. The highlight and
+// snippets are HTML, with the matched terms wrapped in <mark> elements.
+type RecipeSearchResult struct {
+	Recipe             Recipe
+	NameHighlight      string
+	DescriptionSnippet string
+	IngredientsSnippet string
+	Score              float64 // higher is a better match
+}
+
+type RecipeImport struct {
+	ID        int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	OwnerID   int64
+	Url       string
+	Status    RecipeImportStatus
+	RecipeID  int64  // set once the import has succeeded
+	ErrorCode string // set once the import has failed
+}
+
+type ApiKey struct {
+	ID         int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	OwnerID    int64
+	Name       string
+	Prefix     string // the start of the key, to tell keys apart
+	Scope      ApiKeyScope
+	LastUsedAt time.Time // zero if the key has never been used
+}
+
+// CalendarFeed is the secret address a user's meal plan can be subscribed to
+// at from calendar apps.
+type CalendarFeed struct {
+	ID         int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	OwnerID    int64
+	LastUsedAt time.Time // zero if the feed has never been fetched
+}
+
+// PlannedMeal is a recipe planned for a slot of a day. MealID is the meal it
+// was last put on a grocery list as, if any.
+type PlannedMeal struct {
+	ID          int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	OwnerID     int64
+	HouseholdID int64 // 0 when the planned meal is not shared with a household
+	Date        time.Time
+	Slot        MealSlot
+	SlotName    string // the name of a custom slot, like "snack"
+	Servings    int64  // 0 when the meal is made as the recipe is written
+	MealID      int64
+	Recipe      Recipe
+}
+
+// PlannedMealParams are what a planned meal is created or updated with.
+type PlannedMealParams struct {
+	HouseholdID int64
+	Date        time.Time
+	Slot        MealSlot
+	SlotName    string
+	RecipeID    int64
+	Servings    int64
+}
+
+// PantryItem is an ingredient a user or household has on hand. Reserved is the
+// part of the amount that meals on grocery lists count on taking, which is no
+// longer available to net other meals against.
+type PantryItem struct {
+	ID                    int64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	OwnerID               int64 // 0 for the pantry of a household
+	HouseholdID           int64 // 0 for the pantry of a user
+	CanonicalIngredientID int64
+	Name                  string // the name of the ingredient in the catalog
+	StandardAmount        float64
+	StandardUnits         ingparse.StandardUnit
+	AlwaysStocked         bool // never added to grocery lists, whatever the amount
+	Reserved              float64
+}
+
+// Store is a store laid out by its owner, so grocery lists can be sorted in
+// the order its aisles are walked.
+type Store struct {
+	ID        int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	OwnerID   int64
+	Name      string
+	Aisles    []StoreAisle // in walking order
+}
+
+type StoreAisle struct {
+	Category ItemCategory
+	Name     string // what the store calls the aisle, e.g. "Aisle 5"; may be empty
+}
+
+// ItemSection is the item groups of one category on a grocery list, as they
+// are found in a store.
+type ItemSection struct {
+	Category ItemCategory
+	Aisle    string // the name of the store's aisle for the category, if it has one
+	Groups   []ItemGroup
+}
+
+type Household struct {
+	ID        int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	Role      HouseholdRole // the role of the user the household was fetched for
+}
+
+type HouseholdMember struct {
+	HouseholdID int64
+	UserID      int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Username    string
+	Role        HouseholdRole
+}
+
+// GroceryListChanges is what changed on a grocery list since a sync token.
+// Items and meals hold the current state of everything that was created or
+// changed, and the deleted ids what was removed. When Full is set there was no
+// sync token, and Items and Meals hold everything on the list.
+type GroceryListChanges struct {
+	Full           bool
+	Items          []Item
+	Meals          []Meal
+	DeletedItemIDs []int64
+	DeletedMealIDs []int64
+	SyncToken      string // fetches the changes after these ones
+}
+
+// SyncOperation is a change to a grocery list that a client made while it was
+// offline. ChangedAt is when the client made it, and decides conflicts with
+// changes made on the server in the meantime.
+type SyncOperation struct {
+	Op          SyncOp
+	ClientID    string // chosen by the client to match up results, and echoed back
+	ItemID      int64  // for changes to existing items
+	Name        string // for new items
+	Description string // for new items
+	Amount      float64
+	Units       string
+	Status      ItemStatus // for new items and status changes; 0 leaves new items incomplete
+	Restock     bool       // adds items marked complete to the pantry, as MarkItemStatus does
+	ChangedAt   time.Time
+}
+
+type SyncResult struct {
+	ClientID string
+	Outcome  SyncOutcome
+	Item     Item // the item as it is now on the server, if it still exists
 }
 
 type ItemGroup struct {
-	Name   string
-	Totals map[ingparse.StandardUnit]float64
-	Items  []Item
+	Name                  string
+	CanonicalIngredientID int64        // 0 for items whose name is not in the ingredient catalog
+	Category              ItemCategory // the category of the first item in the group
+	Totals                map[ingparse.StandardUnit]float64
+	Quantities            []ingparse.Quantity // the totals in display units, with compatible units combined
+	Items                 []Item
 }
 
 type ItemStatus int
@@ -120,3 +327,389 @@ func ItemStatusFromString(s string) (ItemStatus, error) {
 	}
 	return 0, errors.New("invalid status string")
 }
+
+// ItemCategory is the section of a store an item is found in. The categories
+// are listed in the order of a typical store, which is how grocery lists are
+// sorted for stores that leave a category out of their layout.
+type ItemCategory int
+
+const (
+	_ ItemCategory = iota
+	CategoryProduce
+	CategoryBakery
+	CategoryDeli
+	CategoryMeat
+	CategorySeafood
+	CategoryDairy
+	CategoryFrozen
+	CategoryPantry
+	CategoryBaking
+	CategorySpices
+	CategoryBeverages
+	CategoryHousehold
+	CategoryOther // items that are not known to belong anywhere else
+)
+
+// ItemCategories are all the categories, in the order of a typical store.
+var ItemCategories = []ItemCategory{
+	CategoryProduce,
+	CategoryBakery,
+	CategoryDeli,
+	CategoryMeat,
+	CategorySeafood,
+	CategoryDairy,
+	CategoryFrozen,
+	CategoryPantry,
+	CategoryBaking,
+	CategorySpices,
+	CategoryBeverages,
+	CategoryHousehold,
+	CategoryOther,
+}
+
+func (c ItemCategory) String() string {
+	switch c {
+	case CategoryProduce:
+		return "produce"
+	case CategoryBakery:
+		return "bakery"
+	case CategoryDeli:
+		return "deli"
+	case CategoryMeat:
+		return "meat"
+	case CategorySeafood:
+		return "seafood"
+	case CategoryDairy:
+		return "dairy"
+	case CategoryFrozen:
+		return "frozen"
+	case CategoryPantry:
+		return "pantry"
+	case CategoryBaking:
+		return "baking"
+	case CategorySpices:
+		return "spices"
+	case CategoryBeverages:
+		return "beverages"
+	case CategoryHousehold:
+		return "household"
+	case CategoryOther:
+		return "other"
+	}
+	return "<error>"
+}
+
+func (c ItemCategory) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(c.String())
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+func (c ItemCategory) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func ItemCategoryFromString(s string) (ItemCategory, error) {
+	for _, category := range ItemCategories {
+		if s == category.String() {
+			return category, nil
+		}
+	}
+	return 0, errors.New("invalid category string")
+}
+
+// MealSlot is the part of a day a meal is planned for. Slots are listed in the
+// order of the day; custom slots come after dinner.
+type MealSlot int
+
+const (
+	_ MealSlot = iota
+	SlotBreakfast
+	SlotLunch
+	SlotDinner
+	SlotCustom
+)
+
+func (s MealSlot) String() string {
+	switch s {
+	case SlotBreakfast:
+		return "breakfast"
+	case SlotLunch:
+		return "lunch"
+	case SlotDinner:
+		return "dinner"
+	case SlotCustom:
+		return "custom"
+	}
+	return "<error>"
+}
+
+func (s MealSlot) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(s.String())
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+func (s MealSlot) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func MealSlotFromString(s string) (MealSlot, error) {
+	for _, slot := range []MealSlot{SlotBreakfast, SlotLunch, SlotDinner, SlotCustom} {
+		if s == slot.String() {
+			return slot, nil
+		}
+	}
+	return 0, errors.New("invalid slot string")
+}
+
+// TagKind is where a tag of a recipe came from: the category, cuisine or
+// keywords of a scraped recipe, or a user.
+type TagKind int
+
+const (
+	_ TagKind = iota
+	TagCategory
+	TagCuisine
+	TagKeyword
+	TagUser
+)
+
+func (k TagKind) String() string {
+	switch k {
+	case TagCategory:
+		return "category"
+	case TagCuisine:
+		return "cuisine"
+	case TagKeyword:
+		return "keyword"
+	case TagUser:
+		return "user"
+	}
+	return "<error>"
+}
+
+func (k TagKind) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(k.String())
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+func (k TagKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func TagKindFromString(s string) (TagKind, error) {
+	for _, kind := range []TagKind{TagCategory, TagCuisine, TagKeyword, TagUser} {
+		if s == kind.String() {
+			return kind, nil
+		}
+	}
+	return 0, errors.New("invalid tag kind string")
+}
+
+type RecipeImportStatus int
+
+const (
+	_ RecipeImportStatus = iota
+	ImportPending
+	ImportRunning
+	ImportSucceeded
+	ImportFailed
+)
+
+func (s RecipeImportStatus) String() string {
+	switch s {
+	case ImportPending:
+		return "pending"
+	case ImportRunning:
+		return "running"
+	case ImportSucceeded:
+		return "succeeded"
+	case ImportFailed:
+		return "failed"
+	}
+	return "<error>"
+}
+
+func (s RecipeImportStatus) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(s.String())
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+func (s RecipeImportStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func RecipeImportStatusFromString(s string) (RecipeImportStatus, error) {
+	for _, status := range []RecipeImportStatus{ImportPending, ImportRunning, ImportSucceeded, ImportFailed} {
+		if s == status.String() {
+			return status, nil
+		}
+	}
+	return 0, errors.New("invalid status string")
+}
+
+type ApiKeyScope int
+
+const (
+	_ ApiKeyScope = iota
+	ScopeRead
+	ScopeWrite
+)
+
+func (s ApiKeyScope) String() string {
+	switch s {
+	case ScopeRead:
+		return "read"
+	case ScopeWrite:
+		return "write"
+	}
+	return "<error>"
+}
+
+func (s ApiKeyScope) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(s.String())
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+func (s ApiKeyScope) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func ApiKeyScopeFromString(s string) (ApiKeyScope, error) {
+	for _, scope := range []ApiKeyScope{ScopeRead, ScopeWrite} {
+		if s == scope.String() {
+			return scope, nil
+		}
+	}
+	return 0, errors.New("invalid scope string")
+}
+
+// HouseholdRole is what a member may do with a household and the recipes and
+// grocery lists that belong to it. Each role may do everything the roles
+// before it may.
+type HouseholdRole int
+
+const (
+	_ HouseholdRole = iota
+	RoleViewer
+	RoleEditor
+	RoleOwner
+)
+
+func (r HouseholdRole) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleEditor:
+		return "editor"
+	case RoleOwner:
+		return "owner"
+	}
+	return "<error>"
+}
+
+func (r HouseholdRole) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(r.String())
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+func (r HouseholdRole) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func HouseholdRoleFromString(s string) (HouseholdRole, error) {
+	for _, role := range []HouseholdRole{RoleViewer, RoleEditor, RoleOwner} {
+		if s == role.String() {
+			return role, nil
+		}
+	}
+	return 0, errors.New("invalid role string")
+}
+
+type SyncOp int
+
+const (
+	_ SyncOp = iota
+	OpCreateItem
+	OpSetItemStatus
+	OpDeleteItem
+)
+
+func (o SyncOp) String() string {
+	switch o {
+	case OpCreateItem:
+		return "create_item"
+	case OpSetItemStatus:
+		return "set_item_status"
+	case OpDeleteItem:
+		return "delete_item"
+	}
+	return "<error>"
+}
+
+func (o SyncOp) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(o.String())
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+func (o SyncOp) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func SyncOpFromString(s string) (SyncOp, error) {
+	for _, op := range []SyncOp{OpCreateItem, OpSetItemStatus, OpDeleteItem} {
+		if s == op.String() {
+			return op, nil
+		}
+	}
+	return 0, errors.New("invalid op string")
+}
+
+// SyncOutcome is what became of a SyncOperation.
+type SyncOutcome int
+
+const (
+	_ SyncOutcome = iota
+	SyncApplied
+	SyncConflict // the item changed on the server after the client changed it, and the server version was kept
+	SyncNotFound // the item does not exist, or is not on the grocery list
+	SyncInvalid  // the operation is missing fields or has invalid ones
+)
+
+func (o SyncOutcome) String() string {
+	switch o {
+	case SyncApplied:
+		return "applied"
+	case SyncConflict:
+		return "conflict"
+	case SyncNotFound:
+		return "not_found"
+	case SyncInvalid:
+		return "invalid"
+	}
+	return "<error>"
+}
+
+func (o SyncOutcome) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(o.String())
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+func (o SyncOutcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
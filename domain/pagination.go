@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+type SortOrder int
+
+const (
+	_ SortOrder = iota
+	SortCreatedAt
+	SortCreatedAtDesc
+	SortName
+	SortNameDesc
+	SortTotalTime // recipes only; recipes that do not say how long they take come last
+	SortTotalTimeDesc
+)
+
+func (s SortOrder) String() string {
+	switch s {
+	case SortCreatedAt:
+		return "created_at"
+	case SortCreatedAtDesc:
+		return "-created_at"
+	case SortName:
+		return "name"
+	case SortNameDesc:
+		return "-name"
+	case SortTotalTime:
+		return "total_time"
+	case SortTotalTimeDesc:
+		return "-total_time"
+	}
+	return "<error>"
+}
+
+func SortOrderFromString(s string) (SortOrder, error) {
+	for _, sort := range []SortOrder{SortCreatedAt, SortCreatedAtDesc, SortName, SortNameDesc, SortTotalTime, SortTotalTimeDesc} {
+		if s == sort.String() {
+			return sort, nil
+		}
+	}
+	return 0, domerr.ErrInvalidSort
+}
+
+// ListParams select one page of a collection. The zero value is the first
+// page, oldest first, with the default limit.
+type ListParams struct {
+	Cursor       string // from the previous page; empty for the first page
+	Limit        int
+	Sort         SortOrder
+	NameContains string // case insensitive
+	CreatedAfter time.Time
+}
+
+// ItemListParams additionally filter items by status. A zero Status lists
+// items of any status.
+type ItemListParams struct {
+	ListParams
+	Status ItemStatus
+}
+
+// RecipeListParams additionally filter recipes by how long they take, as
+// Recipe.TotalDuration, and by their tags. A zero MaxTotalTime lists recipes of
+// any time, including those that do not say. Recipes with any of the Tags are
+// listed, or with MatchAllTags, only recipes with all of them; no Tags lists
+// recipes with any tags or none.
+type RecipeListParams struct {
+	ListParams
+	MaxTotalTime time.Duration
+	Tags         []string
+	MatchAllTags bool
+}
+
+// cursor marks the last row of a page. The sort is kept so that a cursor
+// cannot be used with a different order than the one it was made for.
+type cursor struct {
+	Sort    SortOrder `json:"s"`
+	Name    string    `json:"n,omitempty"`
+	Seconds int64     `json:"t,omitempty"`
+	ID      int64     `json:"i"`
+}
+
+// page is a validated ListParams.
+type page struct {
+	sort         SortOrder
+	limit        int64
+	nameContains string
+	createdAfter time.Time
+	fromStart    bool
+	after        cursor
+}
+
+func (p ListParams) page() (page, error) {
+	pg := page{
+		sort:         p.Sort,
+		limit:        int64(p.Limit),
+		nameContains: p.NameContains,
+		createdAfter: p.CreatedAfter,
+		fromStart:    p.Cursor == "",
+	}
+
+	if pg.sort == 0 {
+		pg.sort = SortCreatedAt
+	}
+	if pg.limit == 0 {
+		pg.limit = DefaultPageLimit
+	}
+	if pg.limit < 0 || pg.limit > MaxPageLimit {
+		return page{}, domerr.ErrInvalidPageLimit
+	}
+
+	if pg.fromStart {
+		if pg.sort == SortCreatedAtDesc || pg.sort == SortNameDesc || pg.sort == SortTotalTimeDesc {
+			pg.after.ID = math.MaxInt64
+		}
+		return pg, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(p.Cursor)
+	if err != nil {
+		return page{}, domerr.ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &pg.after); err != nil || pg.after.Sort != pg.sort {
+		return page{}, domerr.ErrInvalidCursor
+	}
+
+	return pg, nil
+}
+
+// queryLimit is the number of rows to fetch: one more than the page holds,
+// to find out whether there is a next page.
+func (pg page) queryLimit() int64 {
+	return pg.limit + 1
+}
+
+// nextCursor returns the cursor for the page after one that ends with the
+// given row, or "" when rowCount shows that there are no more rows. Only the
+// fields of last that the sort order uses are kept.
+func (pg page) nextCursor(rowCount int, last cursor) string {
+	if int64(rowCount) <= pg.limit {
+		return ""
+	}
+
+	c := cursor{Sort: pg.sort, ID: last.ID}
+	switch pg.sort {
+	case SortName, SortNameDesc:
+		c.Name = last.Name
+	case SortTotalTime, SortTotalTimeDesc:
+		c.Seconds = last.Seconds
+	}
+
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// trim returns the number of rows that belong on the page.
+func (pg page) trim(rowCount int) int {
+	if int64(rowCount) > pg.limit {
+		return int(pg.limit)
+	}
+	return rowCount
+}
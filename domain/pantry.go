@@ -0,0 +1,401 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/ingparse"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+	"github.com/snorman7384/recipe-wizard/internal/misc"
+)
+
+func databaseToDomainPantryItem(item database.PantryItem, name string, reserved float64) PantryItem {
+	return PantryItem{
+		ID:                    item.ID,
+		CreatedAt:             item.CreatedAt,
+		UpdatedAt:             item.UpdatedAt,
+		OwnerID:               item.OwnerID.Int64,
+		HouseholdID:           item.HouseholdID.Int64,
+		CanonicalIngredientID: item.CanonicalIngredientID,
+		Name:                  name,
+		StandardAmount:        item.StandardAmount,
+		StandardUnits:         ingparse.StandardUnitFromString(item.StandardUnits),
+		AlwaysStocked:         item.AlwaysStocked,
+		Reserved:              reserved,
+	}
+}
+
+// GetPantry returns what the user has on hand, or with a household id, what
+// the household has on hand.
+func (c *Config) GetPantry(ctx context.Context, user User, householdID int64) ([]PantryItem, error) {
+	type row struct {
+		item database.PantryItem
+		name string
+	}
+	var rows []row
+
+	if householdID == 0 {
+		dbRows, err := c.Querier().GetPantryItemsForUser(ctx, misc.SqlNullInt64FromPositiveInt64(user.ID))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range dbRows {
+			rows = append(rows, row{r.PantryItem, r.Name})
+		}
+	} else {
+		if err := c.authorize(ctx, user, 0, householdID, ActionView); err != nil {
+			return nil, err
+		}
+
+		dbRows, err := c.Querier().GetPantryItemsForHousehold(ctx, misc.SqlNullInt64FromPositiveInt64(householdID))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range dbRows {
+			rows = append(rows, row{r.PantryItem, r.Name})
+		}
+	}
+
+	items := make([]PantryItem, len(rows))
+	for i, r := range rows {
+		reserved, err := c.Querier().GetPantryItemReserved(ctx, r.item.ID)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = databaseToDomainPantryItem(r.item, r.name, reserved)
+	}
+
+	return items, nil
+}
+
+// CreatePantryItem adds an ingredient to the pantry of the user, or with a
+// household id, to the pantry of the household.
+func (c *Config) CreatePantryItem(ctx context.Context, user User, householdID int64, name string, amount float64, units string, alwaysStocked bool) (PantryItem, error) {
+	if householdID != 0 {
+		if err := c.authorize(ctx, user, 0, householdID, ActionEdit); err != nil {
+			return PantryItem{}, err
+		}
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || amount < 0 {
+		return PantryItem{}, domerr.ErrInvalidInput
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return PantryItem{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	canonicalID, err := resolveCanonicalIngredient(ctx, qtx, name)
+	if err != nil {
+		return PantryItem{}, err
+	}
+	if !canonicalID.Valid {
+		return PantryItem{}, domerr.ErrUnknownIngredient
+	}
+
+	ownerID := user.ID
+	if householdID != 0 {
+		ownerID = 0
+	}
+
+	_, found, err := pantryItemForIngredient(ctx, qtx, ownerID, householdID, canonicalID.Int64)
+	if err != nil {
+		return PantryItem{}, err
+	}
+	if found {
+		return PantryItem{}, domerr.ErrAlreadyInPantry
+	}
+
+	now := time.Now()
+	measure := ingparse.StandardizeMeasure(amount, units)
+
+	item, err := qtx.CreatePantryItem(ctx, database.CreatePantryItemParams{
+		CreatedAt:             now,
+		UpdatedAt:             now,
+		OwnerID:               misc.SqlNullInt64FromPositiveInt64(ownerID),
+		HouseholdID:           misc.SqlNullInt64FromPositiveInt64(householdID),
+		CanonicalIngredientID: canonicalID.Int64,
+		StandardAmount:        measure.StandardAmount,
+		StandardUnits:         measure.StandardUnits.String(),
+		AlwaysStocked:         alwaysStocked,
+	})
+	if err != nil {
+		return PantryItem{}, err
+	}
+
+	canonical, err := qtx.GetCanonicalIngredient(ctx, canonicalID.Int64)
+	if err != nil {
+		return PantryItem{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PantryItem{}, err
+	}
+
+	return databaseToDomainPantryItem(item, canonical.Name, 0), nil
+}
+
+func (c *Config) GetPantryItem(ctx context.Context, user User, id int64) (PantryItem, error) {
+	row, err := c.Querier().GetPantryItem(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return PantryItem{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return PantryItem{}, err
+	}
+
+	item := databaseToDomainPantryItem(row.PantryItem, row.Name, 0)
+	if err := c.authorizePantryItem(ctx, user, item, ActionView); err != nil {
+		return PantryItem{}, err
+	}
+
+	item.Reserved, err = c.Querier().GetPantryItemReserved(ctx, item.ID)
+	if err != nil {
+		return PantryItem{}, err
+	}
+
+	return item, nil
+}
+
+// UpdatePantryItem sets how much of the ingredient is on hand, and whether it
+// is always stocked.
+func (c *Config) UpdatePantryItem(ctx context.Context, user User, item PantryItem, amount float64, units string, alwaysStocked bool) (PantryItem, error) {
+	if err := c.authorizePantryItem(ctx, user, item, ActionEdit); err != nil {
+		return PantryItem{}, err
+	}
+
+	if amount < 0 {
+		return PantryItem{}, domerr.ErrInvalidInput
+	}
+
+	now := time.Now()
+	measure := ingparse.StandardizeMeasure(amount, units)
+
+	err := c.Querier().UpdatePantryItem(ctx, database.UpdatePantryItemParams{
+		UpdatedAt:      now,
+		StandardAmount: measure.StandardAmount,
+		StandardUnits:  measure.StandardUnits.String(),
+		AlwaysStocked:  alwaysStocked,
+		ID:             item.ID,
+	})
+	if err != nil {
+		return PantryItem{}, err
+	}
+
+	item.UpdatedAt = now
+	item.StandardAmount = measure.StandardAmount
+	item.StandardUnits = measure.StandardUnits
+	item.AlwaysStocked = alwaysStocked
+
+	return item, nil
+}
+
+func (c *Config) DeletePantryItem(ctx context.Context, user User, item PantryItem) error {
+	if err := c.authorizePantryItem(ctx, user, item, ActionEdit); err != nil {
+		return err
+	}
+
+	return c.Querier().DeletePantryItem(ctx, item.ID)
+}
+
+// pantryItemForIngredient looks up the ingredient in the pantry of a user, or
+// of a household if the owner id is 0. It reports false if the pantry does not
+// hold the ingredient.
+func pantryItemForIngredient(ctx context.Context, q *database.Queries, ownerID int64, householdID int64, canonicalID int64) (database.PantryItem, bool, error) {
+	var item database.PantryItem
+	var err error
+	if ownerID != 0 {
+		item, err = q.GetUserPantryItemForIngredient(ctx, database.GetUserPantryItemForIngredientParams{
+			OwnerID:               misc.SqlNullInt64FromPositiveInt64(ownerID),
+			CanonicalIngredientID: canonicalID,
+		})
+	} else {
+		item, err = q.GetHouseholdPantryItemForIngredient(ctx, database.GetHouseholdPantryItemForIngredientParams{
+			HouseholdID:           misc.SqlNullInt64FromPositiveInt64(householdID),
+			CanonicalIngredientID: canonicalID,
+		})
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.PantryItem{}, false, nil
+	}
+	if err != nil {
+		return database.PantryItem{}, false, err
+	}
+
+	return item, true, nil
+}
+
+// groceryListPantryOwner returns whose pantry a grocery list is netted against
+// and restocks, as the arguments of pantryItemForIngredient: the household's if
+// the list is shared with one, and otherwise its owner's.
+func groceryListPantryOwner(groceryList GroceryList) (ownerID int64, householdID int64) {
+	if groceryList.HouseholdID != 0 {
+		return 0, groceryList.HouseholdID
+	}
+	return groceryList.OwnerID, 0
+}
+
+// takeFromPantry nets the pantry of the grocery list out of the standard amount
+// a meal needs of an ingredient, and reserves what it takes for the meal. It
+// returns the amount that is still needed, and reports true if nothing is,
+// either because the pantry covers all of it or because the ingredient is
+// always stocked. Stock kept in other standard units than the meal needs is
+// not netted.
+func takeFromPantry(ctx context.Context, q *database.Queries, groceryList GroceryList, mealID int64, canonicalID sql.NullInt64, need ingparse.Measure) (float64, bool, error) {
+	if !canonicalID.Valid {
+		return need.StandardAmount, false, nil
+	}
+
+	ownerID, householdID := groceryListPantryOwner(groceryList)
+	item, found, err := pantryItemForIngredient(ctx, q, ownerID, householdID, canonicalID.Int64)
+	if err != nil || !found {
+		return need.StandardAmount, false, err
+	}
+
+	if item.AlwaysStocked {
+		return 0, true, nil
+	}
+
+	if ingparse.StandardUnitFromString(item.StandardUnits) != need.StandardUnits || need.StandardAmount <= 0 {
+		return need.StandardAmount, false, nil
+	}
+
+	reserved, err := q.GetPantryItemReserved(ctx, item.ID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	available := item.StandardAmount - reserved
+	if available <= 0 {
+		return need.StandardAmount, false, nil
+	}
+
+	taken := math.Min(available, need.StandardAmount)
+	err = q.AddMealPantryUse(ctx, database.AddMealPantryUseParams{
+		MealID:         mealID,
+		PantryItemID:   item.ID,
+		StandardAmount: taken,
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	remaining := need.StandardAmount - taken
+	// allow for rounding when the pantry holds exactly what is needed
+	return remaining, remaining <= need.StandardAmount*1e-9, nil
+}
+
+// restockPantry adds a bought item to the pantry of its grocery list. Items
+// bought for a meal stay reserved for it. Items that are not in the ingredient
+// catalog, or that are stocked in other standard units, are not added, and
+// neither are items that already were.
+func restockPantry(ctx context.Context, q *database.Queries, groceryList GroceryList, item Item, now time.Time) error {
+	if item.CanonicalIngredientID == 0 || item.StandardAmount <= 0 {
+		return nil
+	}
+
+	_, err := q.GetItemRestock(ctx, item.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	ownerID, householdID := groceryListPantryOwner(groceryList)
+	pantryItem, found, err := pantryItemForIngredient(ctx, q, ownerID, householdID, item.CanonicalIngredientID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !found:
+		pantryItem, err = q.CreatePantryItem(ctx, database.CreatePantryItemParams{
+			CreatedAt:             now,
+			UpdatedAt:             now,
+			OwnerID:               misc.SqlNullInt64FromPositiveInt64(ownerID),
+			HouseholdID:           misc.SqlNullInt64FromPositiveInt64(householdID),
+			CanonicalIngredientID: item.CanonicalIngredientID,
+			StandardAmount:        item.StandardAmount,
+			StandardUnits:         item.StandardUnits.String(),
+		})
+		if err != nil {
+			return err
+		}
+	case ingparse.StandardUnitFromString(pantryItem.StandardUnits) != item.StandardUnits:
+		return nil
+	default:
+		err = q.AddToPantryItem(ctx, database.AddToPantryItemParams{
+			UpdatedAt: now,
+			Amount:    item.StandardAmount,
+			ID:        pantryItem.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = q.CreateItemRestock(ctx, database.CreateItemRestockParams{
+		ItemID:         item.ID,
+		PantryItemID:   pantryItem.ID,
+		StandardAmount: item.StandardAmount,
+	})
+	if err != nil {
+		return err
+	}
+
+	if item.MealID == 0 {
+		return nil
+	}
+
+	return q.AddMealPantryUse(ctx, database.AddMealPantryUseParams{
+		MealID:         item.MealID,
+		PantryItemID:   pantryItem.ID,
+		StandardAmount: item.StandardAmount,
+	})
+}
+
+// unstockPantry takes back out of the pantry what restockPantry added for an
+// item, along with what it reserved for the item's meal. Stock that was used
+// up since is not taken below zero.
+func unstockPantry(ctx context.Context, q *database.Queries, item Item, now time.Time) error {
+	restock, err := q.GetItemRestock(ctx, item.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = q.TakeFromPantryItem(ctx, database.TakeFromPantryItemParams{
+		UpdatedAt: now,
+		Amount:    restock.StandardAmount,
+		ID:        restock.PantryItemID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if item.MealID != 0 {
+		err = q.ReleaseMealPantryUse(ctx, database.ReleaseMealPantryUseParams{
+			Amount:       restock.StandardAmount,
+			MealID:       item.MealID,
+			PantryItemID: restock.PantryItemID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return q.DeleteItemRestock(ctx, item.ID)
+}
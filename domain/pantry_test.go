@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+// pantryFixture is a grocery list and a recipe, for 2 servings, that needs
+// 2 cups (16 fl oz) of milk and 1 cup (8 fl oz) of flour.
+type pantryFixture struct {
+	c           *Config
+	user        User
+	groceryList GroceryList
+	recipe      Recipe
+}
+
+func newPantryFixture(t *testing.T) pantryFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	c := newTestConfig(t)
+	user := newTestUser(t, c, "cook")
+
+	return pantryFixture{
+		c:           c,
+		user:        user,
+		groceryList: must(c.CreateGroceryList(ctx, user, "groceries")),
+		recipe: must(c.CreateRecipe(ctx, user, CreateRecipeParams{
+			Name:        "pancakes",
+			Servings:    2,
+			Ingredients: []string{"2 cups milk", "1 cup flour"},
+		})),
+	}
+}
+
+// pantry returns the amount on hand and reserved of an ingredient, which are
+// both 0 if the pantry does not hold it.
+func (f pantryFixture) pantry(t *testing.T, name string) (amount float64, reserved float64) {
+	t.Helper()
+
+	for _, item := range must(f.c.GetPantry(context.Background(), f.user, 0)) {
+		if item.Name == name {
+			return item.StandardAmount, item.Reserved
+		}
+	}
+	return 0, 0
+}
+
+// item returns the item on the grocery list with the given name and status,
+// and reports false if there is none.
+func (f pantryFixture) item(t *testing.T, name string, status ItemStatus) (Item, bool) {
+	t.Helper()
+
+	for _, item := range must(f.c.GetItemsForGroceryList(context.Background(), f.groceryList)) {
+		if item.Name == name && item.Status == status {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+func (f pantryFixture) checkPantry(t *testing.T, name string, wantAmount float64, wantReserved float64) {
+	t.Helper()
+
+	amount, reserved := f.pantry(t, name)
+	if !approxEqual(amount, wantAmount) || !approxEqual(reserved, wantReserved) {
+		t.Errorf("pantry %s = %v, %v reserved, want %v, %v reserved", name, amount, reserved, wantAmount, wantReserved)
+	}
+}
+
+func (f pantryFixture) checkItem(t *testing.T, name string, wantAmount float64) {
+	t.Helper()
+
+	item, found := f.item(t, name, Incomplete)
+	switch {
+	case wantAmount == 0 && found:
+		t.Errorf("item %s = %v fl oz, want none", name, item.StandardAmount)
+	case wantAmount != 0 && !found:
+		t.Errorf("item %s is missing, want %v fl oz", name, wantAmount)
+	case wantAmount != 0 && !approxEqual(item.StandardAmount, wantAmount):
+		t.Errorf("item %s = %v fl oz, want %v fl oz", name, item.StandardAmount, wantAmount)
+	}
+}
+
+func approxEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCreateMealNetsPantry(t *testing.T) {
+	tests := []struct {
+		name         string
+		stock        float64 // fl oz of milk on hand
+		wantItem     float64 // fl oz of milk left to buy
+		wantReserved float64
+	}{
+		{"covered", 20, 0, 16},
+		{"exactly covered", 16, 0, 16},
+		{"partly covered", 10, 6, 10},
+		{"empty", 0, 16, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newPantryFixture(t)
+			must(f.c.CreatePantryItem(ctx, f.user, 0, "milk", tt.stock, "fl oz", false))
+
+			must(f.c.CreateMeal(ctx, f.user, f.groceryList, f.recipe.ID, 2))
+
+			f.checkItem(t, "milk", tt.wantItem)
+			f.checkItem(t, "flour", 8)
+			f.checkPantry(t, "milk", tt.stock, tt.wantReserved)
+		})
+	}
+}
+
+func TestCreateMealAlwaysStocked(t *testing.T) {
+	ctx := context.Background()
+	f := newPantryFixture(t)
+	must(f.c.CreatePantryItem(ctx, f.user, 0, "milk", 0, "fl oz", true))
+
+	must(f.c.CreateMeal(ctx, f.user, f.groceryList, f.recipe.ID, 2))
+
+	f.checkItem(t, "milk", 0)
+	f.checkPantry(t, "milk", 0, 0)
+}
+
+func TestSetMealServingsNetsPantry(t *testing.T) {
+	ctx := context.Background()
+	f := newPantryFixture(t)
+	must(f.c.CreatePantryItem(ctx, f.user, 0, "milk", 20, "fl oz", false))
+	meal := must(f.c.CreateMeal(ctx, f.user, f.groceryList, f.recipe.ID, 2))
+
+	// 32 fl oz of milk, of which the pantry has 20
+	meal = must(f.c.SetMealServings(ctx, f.user, meal, 4))
+	f.checkItem(t, "milk", 12)
+	f.checkItem(t, "flour", 16)
+	f.checkPantry(t, "milk", 20, 20)
+
+	// 8 fl oz of milk, all from the pantry
+	meal = must(f.c.SetMealServings(ctx, f.user, meal, 1))
+	f.checkItem(t, "milk", 0)
+	f.checkItem(t, "flour", 4)
+	f.checkPantry(t, "milk", 20, 8)
+
+	// back as written
+	must(f.c.SetMealServings(ctx, f.user, meal, 2))
+	f.checkItem(t, "milk", 0)
+	f.checkItem(t, "flour", 8)
+	f.checkPantry(t, "milk", 20, 16)
+}
+
+func TestSetMealServingsCountsBoughtItems(t *testing.T) {
+	ctx := context.Background()
+	f := newPantryFixture(t)
+	meal := must(f.c.CreateMeal(ctx, f.user, f.groceryList, f.recipe.ID, 2))
+
+	flour, _ := f.item(t, "flour", Incomplete)
+	must(f.c.MarkItemStatus(ctx, f.user, flour, Complete, false))
+
+	// 16 fl oz of flour, of which 8 were bought
+	meal = must(f.c.SetMealServings(ctx, f.user, meal, 4))
+	f.checkItem(t, "flour", 8)
+
+	// 4 fl oz of flour, already bought
+	must(f.c.SetMealServings(ctx, f.user, meal, 1))
+	f.checkItem(t, "flour", 0)
+}
+
+func TestMarkItemStatusRestocksPantry(t *testing.T) {
+	ctx := context.Background()
+	f := newPantryFixture(t)
+	meal := must(f.c.CreateMeal(ctx, f.user, f.groceryList, f.recipe.ID, 2))
+
+	flour, _ := f.item(t, "flour", Incomplete)
+
+	// bought flour is kept for the meal it was bought for
+	stale := flour
+	flour = must(f.c.MarkItemStatus(ctx, f.user, flour, Complete, true))
+	f.checkPantry(t, "flour", 8, 8)
+
+	// a retried request, made with the item as it was, adds it once
+	must(f.c.MarkItemStatus(ctx, f.user, stale, Complete, true))
+	f.checkPantry(t, "flour", 8, 8)
+
+	flour = must(f.c.MarkItemStatus(ctx, f.user, flour, Incomplete, true))
+	f.checkPantry(t, "flour", 0, 0)
+
+	// unchecking an item that was not restocked leaves the pantry alone
+	must(f.c.UpdatePantryItem(ctx, f.user, must(f.c.GetPantry(ctx, f.user, 0))[0], 3, "fl oz", false))
+	flour = must(f.c.MarkItemStatus(ctx, f.user, flour, Complete, false))
+	must(f.c.MarkItemStatus(ctx, f.user, flour, Incomplete, false))
+	f.checkPantry(t, "flour", 3, 0)
+
+	// restocked items are not bought again when the meal is rescaled
+	flour, _ = f.item(t, "flour", Incomplete)
+	must(f.c.MarkItemStatus(ctx, f.user, flour, Complete, true))
+	must(f.c.SetMealServings(ctx, f.user, meal, 1))
+	f.checkItem(t, "flour", 0)
+	f.checkPantry(t, "flour", 11, 4)
+}
+
+func TestApplyGroceryListOperationsRestocksPantry(t *testing.T) {
+	ctx := context.Background()
+	f := newPantryFixture(t)
+	must(f.c.CreateMeal(ctx, f.user, f.groceryList, f.recipe.ID, 2))
+
+	flour, _ := f.item(t, "flour", Incomplete)
+	changedAt := time.Now()
+
+	results := must(f.c.ApplyGroceryListOperations(ctx, f.user, f.groceryList, []SyncOperation{
+		{Op: OpSetItemStatus, ClientID: "check", ItemID: flour.ID, Status: Complete, Restock: true, ChangedAt: changedAt},
+		{Op: OpCreateItem, ClientID: "milk", Name: "milk", Amount: 1, Units: "cup", Status: Complete, Restock: true, ChangedAt: changedAt},
+	}))
+	for _, result := range results {
+		if result.Outcome != SyncApplied {
+			t.Fatalf("operation %s = %v, want applied", result.ClientID, result.Outcome)
+		}
+	}
+	f.checkPantry(t, "flour", 8, 8)
+	f.checkPantry(t, "milk", 8, 0)
+
+	results = must(f.c.ApplyGroceryListOperations(ctx, f.user, f.groceryList, []SyncOperation{
+		{Op: OpSetItemStatus, ClientID: "uncheck", ItemID: flour.ID, Status: Incomplete, ChangedAt: time.Now()},
+	}))
+	if results[0].Outcome != SyncApplied {
+		t.Fatalf("operation %s = %v, want applied", results[0].ClientID, results[0].Outcome)
+	}
+	f.checkPantry(t, "flour", 0, 0)
+}
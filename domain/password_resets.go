@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+	"github.com/snorman7384/recipe-wizard/mailer"
+)
+
+const passwordResetTokenDuration = time.Hour
+
+// ChangePassword replaces the user's password. Every token the user holds is
+// revoked, so all of their sessions have to log in again.
+func (c *Config) ChangePassword(ctx context.Context, user User, hashedPassword string) error {
+	now := time.Now()
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	if err := setPassword(ctx, qtx, user.ID, hashedPassword, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RequestPasswordReset emails a single use password reset token to the user
+// with the given email. Nothing happens if no user has that email, so callers
+// cannot use this to find out which emails are registered.
+func (c *Config) RequestPasswordReset(ctx context.Context, email string) error {
+	address, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.Querier().GetUserByEmail(ctx, sql.NullString{String: address, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	token, hash, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	_, err = c.Querier().CreatePasswordResetToken(ctx, database.CreatePasswordResetTokenParams{
+		CreatedAt: now,
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: now.Add(passwordResetTokenDuration),
+	})
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\n"+
+		"Someone asked to reset the password of your Recipe Wizard account. "+
+		"If it was you, use the link below within the next hour to choose a new password.\n\n"+
+		"%s%s\n\n"+
+		"If it wasn't you, you can ignore this email.\n",
+		user.Username, c.PasswordResetURL, token)
+
+	return c.Mailer.Send(ctx, mailer.Message{
+		To:      address,
+		Subject: "Reset your Recipe Wizard password",
+		Body:    body,
+	})
+}
+
+// ResetPassword sets a new password for the user a password reset token was
+// issued to. The token can only be used once, and using it also invalidates
+// the user's other reset tokens and revokes all of their sessions.
+func (c *Config) ResetPassword(ctx context.Context, token string, hashedPassword string) error {
+	now := time.Now()
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	resetToken, err := qtx.GetPasswordResetTokenByHash(ctx, hashSecretToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domerr.ErrInvalidResetToken
+	} else if err != nil {
+		return err
+	}
+
+	if resetToken.UsedAt.Valid || !now.Before(resetToken.ExpiresAt) {
+		return domerr.ErrInvalidResetToken
+	}
+
+	rows, err := qtx.UsePasswordResetToken(ctx, database.UsePasswordResetTokenParams{
+		UsedAt: sql.NullTime{Time: now, Valid: true},
+		ID:     resetToken.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domerr.ErrInvalidResetToken
+	}
+
+	err = qtx.UsePasswordResetTokensForUser(ctx, database.UsePasswordResetTokensForUserParams{
+		UsedAt: sql.NullTime{Time: now, Valid: true},
+		UserID: resetToken.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := setPassword(ctx, qtx, resetToken.UserID, hashedPassword, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func setPassword(ctx context.Context, q *database.Queries, userID int64, hashedPassword string, now time.Time) error {
+	err := q.SetUserPassword(ctx, database.SetUserPasswordParams{
+		UpdatedAt:      now,
+		HashedPassword: hashedPassword,
+		ID:             userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return revokeAllTokens(ctx, q, userID, now)
+}
@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/blobstore"
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+	"github.com/snorman7384/recipe-wizard/internal/misc"
+)
+
+// MaxImageSize is the largest image a recipe may have, in bytes.
+const MaxImageSize = 10 << 20
+
+// imageContentTypes are the types of image a recipe may have.
+var imageContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+func databaseToDomainRecipeImage(image database.RecipeImage) RecipeImage {
+	return RecipeImage{
+		RecipeID:    image.RecipeID,
+		CreatedAt:   image.CreatedAt,
+		Key:         image.BlobKey,
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		SourceURL:   image.SourceUrl.String,
+	}
+}
+
+func (c *Config) GetRecipeImage(ctx context.Context, user User, recipe Recipe) (RecipeImage, error) {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionView); err != nil {
+		return RecipeImage{}, err
+	}
+
+	image, err := c.Querier().GetRecipeImage(ctx, recipe.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return RecipeImage{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return RecipeImage{}, err
+	}
+
+	return databaseToDomainRecipeImage(image), nil
+}
+
+// OpenRecipeImage returns the bytes of an image from GetRecipeImage. The
+// caller must close them.
+func (c *Config) OpenRecipeImage(ctx context.Context, image RecipeImage) (io.ReadCloser, error) {
+	if c.Blobs == nil {
+		return nil, domerr.ErrImagesUnavailable
+	}
+
+	data, err := c.Blobs.Get(ctx, image.Key)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, domerr.ErrNotFound
+	}
+	return data, err
+}
+
+// SetRecipeImage replaces the image of a recipe with one the user uploaded.
+// The image is only read once the user is known to be allowed to edit the
+// recipe, and its type is taken from its contents.
+func (c *Config) SetRecipeImage(ctx context.Context, user User, recipe Recipe, image io.Reader) (RecipeImage, error) {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionEdit); err != nil {
+		return RecipeImage{}, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(image, MaxImageSize+1))
+	if err != nil {
+		return RecipeImage{}, err
+	}
+
+	return c.storeRecipeImage(ctx, recipe.ID, data, "")
+}
+
+func (c *Config) DeleteRecipeImage(ctx context.Context, user User, recipe Recipe) error {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionEdit); err != nil {
+		return err
+	}
+
+	image, err := c.Querier().GetRecipeImage(ctx, recipe.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domerr.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	err = c.Querier().DeleteRecipeImage(ctx, recipe.ID)
+	if err != nil {
+		return err
+	}
+
+	c.deleteBlob(ctx, image.BlobKey)
+	return nil
+}
+
+// storeRecipeImage puts an image in the blob store under a new key, records it
+// as the image of the recipe, and deletes the image it replaces.
+func (c *Config) storeRecipeImage(ctx context.Context, recipeID int64, data []byte, sourceURL string) (RecipeImage, error) {
+	if c.Blobs == nil {
+		return RecipeImage{}, domerr.ErrImagesUnavailable
+	}
+
+	if len(data) > MaxImageSize {
+		return RecipeImage{}, domerr.ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !imageContentTypes[contentType] {
+		return RecipeImage{}, domerr.ErrUnsupportedImage
+	}
+
+	old, err := c.Querier().GetRecipeImage(ctx, recipeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return RecipeImage{}, err
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return RecipeImage{}, err
+	}
+	key := fmt.Sprintf("recipe-images/%d/%s", recipeID, hex.EncodeToString(b))
+
+	if err := c.Blobs.Put(ctx, key, data, contentType); err != nil {
+		return RecipeImage{}, err
+	}
+
+	image, err := c.Querier().UpsertRecipeImage(ctx, database.UpsertRecipeImageParams{
+		RecipeID:    recipeID,
+		CreatedAt:   time.Now(),
+		BlobKey:     key,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		SourceUrl:   misc.SqlNullStringFromString(sourceURL),
+	})
+	if err != nil {
+		c.deleteBlob(ctx, key)
+		return RecipeImage{}, err
+	}
+
+	if old.BlobKey != "" {
+		c.deleteBlob(ctx, old.BlobKey)
+	}
+
+	return databaseToDomainRecipeImage(image), nil
+}
+
+// importRecipeImage downloads the image of a scraped recipe, resolving its url
+// against the page the recipe came from. A recipe is still imported when its
+// image cannot be, so failures are only logged.
+func (c *Config) importRecipeImage(ctx context.Context, recipeID int64, pageURL string, imageURL string) {
+	if c.Blobs == nil || imageURL == "" {
+		return
+	}
+
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return
+	}
+	if !u.IsAbs() {
+		base, err := url.Parse(pageURL)
+		if err != nil || !base.IsAbs() {
+			return
+		}
+		u = base.ResolveReference(u)
+	}
+
+	data, _, err := c.RecipeScraper.FetchImage(ctx, u.String(), MaxImageSize)
+	if err != nil {
+		log.Printf("could not fetch image of recipe %d: %v", recipeID, err)
+		return
+	}
+
+	if _, err := c.storeRecipeImage(ctx, recipeID, data, u.String()); err != nil {
+		log.Printf("could not store image of recipe %d: %v", recipeID, err)
+	}
+}
+
+// deleteBlob deletes a blob that is no longer referenced. A blob that cannot
+// be deleted is only wasted space, so failures are only logged.
+func (c *Config) deleteBlob(ctx context.Context, key string) {
+	if c.Blobs == nil {
+		return
+	}
+	if err := c.Blobs.Delete(ctx, key); err != nil {
+		log.Printf("could not delete blob %s: %v", key, err)
+	}
+}
@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+	"github.com/snorman7384/recipe-wizard/internal/misc"
+)
+
+const (
+	// MaxRecipeImportBatch is the largest number of urls that can be
+	// imported with a single request.
+	MaxRecipeImportBatch = 100
+
+	// recipeImportTimeout bounds the time a worker spends on one import.
+	recipeImportTimeout = 2 * time.Minute
+	// recipeImportStaleAfter is how long an import may stay running before
+	// it is assumed that its worker died, e.g. in a restart, and it is
+	// claimed again.
+	recipeImportStaleAfter = 2 * recipeImportTimeout
+	// maxRecipeImportAttempts stops an import that keeps taking its worker
+	// down from being retried forever.
+	maxRecipeImportAttempts = 3
+)
+
+func databaseToDomainRecipeImport(ri database.RecipeImport) RecipeImport {
+	status, err := RecipeImportStatusFromString(ri.Status)
+	if err != nil {
+		log.Printf("recipe import %d has invalid status %q", ri.ID, ri.Status)
+	}
+	return RecipeImport{
+		ID:        ri.ID,
+		CreatedAt: ri.CreatedAt,
+		UpdatedAt: ri.UpdatedAt,
+		OwnerID:   ri.OwnerID,
+		Url:       ri.Url,
+		Status:    status,
+		RecipeID:  ri.RecipeID.Int64,
+		ErrorCode: ri.ErrorCode.String,
+	}
+}
+
+// CreateRecipeImports queues a recipe import for each url. The imports are
+// processed by the workers started with RunRecipeImportWorkers.
+func (c *Config) CreateRecipeImports(ctx context.Context, user User, urls []string) ([]RecipeImport, error) {
+	if len(urls) == 0 || len(urls) > MaxRecipeImportBatch {
+		return nil, domerr.ErrInvalidInput
+	}
+
+	for i, rawURL := range urls {
+		rawURL = strings.TrimSpace(rawURL)
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Host == "" {
+			return nil, domerr.ErrInvalidInput
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, domerr.ErrUrlSchemeNotAllowed
+		}
+		urls[i] = rawURL
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	now := time.Now()
+
+	imports := make([]RecipeImport, len(urls))
+	for i, u := range urls {
+		ri, err := qtx.CreateRecipeImport(ctx, database.CreateRecipeImportParams{
+			CreatedAt: now,
+			UpdatedAt: now,
+			OwnerID:   user.ID,
+			Url:       u,
+		})
+		if err != nil {
+			return nil, err
+		}
+		imports[i] = databaseToDomainRecipeImport(ri)
+	}
+
+	return imports, tx.Commit()
+}
+
+func (c *Config) GetRecipeImport(ctx context.Context, user User, id int64) (RecipeImport, error) {
+	ri, err := c.Querier().GetRecipeImport(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return RecipeImport{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return RecipeImport{}, err
+	}
+
+	if user.ID != ri.OwnerID {
+		return RecipeImport{}, domerr.ErrForbidden
+	}
+
+	return databaseToDomainRecipeImport(ri), nil
+}
+
+func (c *Config) GetRecipeImportsForUser(ctx context.Context, user User) ([]RecipeImport, error) {
+	rows, err := c.Querier().GetRecipeImportsForUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make([]RecipeImport, len(rows))
+	for i, row := range rows {
+		imports[i] = databaseToDomainRecipeImport(row)
+	}
+
+	return imports, nil
+}
+
+// RunRecipeImportWorkers processes queued recipe imports with the given number
+// of workers until ctx is cancelled. Idle workers check for new imports every
+// pollInterval. Since imports are claimed in the database, workers in several
+// processes can share the queue.
+func (c *Config) RunRecipeImportWorkers(ctx context.Context, workers int, pollInterval time.Duration) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.runRecipeImportWorker(ctx, pollInterval)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (c *Config) runRecipeImportWorker(ctx context.Context, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// work through the queue before waiting again
+		for ctx.Err() == nil {
+			claimed, err := c.processNextRecipeImport(ctx)
+			if err != nil {
+				log.Println("Could not process recipe import:", err)
+				break
+			}
+			if !claimed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNextRecipeImport claims and runs one queued import. It reports
+// whether there was an import to claim.
+func (c *Config) processNextRecipeImport(ctx context.Context) (bool, error) {
+	now := time.Now()
+
+	ri, err := c.Querier().ClaimRecipeImport(ctx, database.ClaimRecipeImportParams{
+		Now:         now,
+		StaleBefore: now.Add(-recipeImportStaleAfter),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if ri.Attempts > maxRecipeImportAttempts {
+		return true, c.finishRecipeImport(ctx, ri.ID, 0, domerr.ErrInternal)
+	}
+
+	user, err := c.GetUser(ctx, ri.OwnerID)
+	if err != nil {
+		return true, c.finishRecipeImport(ctx, ri.ID, 0, err)
+	}
+
+	importCtx, cancel := context.WithTimeout(ctx, recipeImportTimeout)
+	defer cancel()
+
+	recipe, err := c.CreateRecipeFromUrl(importCtx, user, ri.Url)
+	if err != nil && ctx.Err() != nil {
+		// shutting down; the import is claimed again once it goes stale
+		return true, nil
+	}
+	if err != nil {
+		return true, c.finishRecipeImport(ctx, ri.ID, 0, err)
+	}
+
+	return true, c.finishRecipeImport(ctx, ri.ID, recipe.ID, nil)
+}
+
+func (c *Config) finishRecipeImport(ctx context.Context, id int64, recipeID int64, importErr error) error {
+	status := ImportSucceeded
+	var errorCode string
+
+	if importErr != nil {
+		status = ImportFailed
+
+		var e *domerr.DomainError
+		if !errors.As(importErr, &e) {
+			log.Printf("recipe import %d failed: %v", id, importErr)
+			e = domerr.ErrInternal
+		}
+		errorCode = e.Code()
+	}
+
+	return c.Querier().FinishRecipeImport(ctx, database.FinishRecipeImportParams{
+		Status:    status.String(),
+		UpdatedAt: time.Now(),
+		RecipeID:  misc.SqlNullInt64FromPositiveInt64(recipeID),
+		ErrorCode: misc.SqlNullStringFromString(errorCode),
+		ID:        id,
+	})
+}
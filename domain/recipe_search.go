@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+)
+
+const (
+	DefaultSearchLimit = 20
+
+	// the search query marks matches with these, since they cannot appear in
+	// recipe text and are replaced after the text is escaped
+	matchStart = "\x02"
+	matchEnd   = "\x03"
+)
+
+var matchReplacer = strings.NewReplacer(matchStart, "<mark>", matchEnd, "</mark>")
+
+// ftsQuery turns text typed by a user into an FTS5 query that matches rows
+// containing every word, or words starting with it. Operators and quotes in
+// the text are ignored rather than passed to FTS5.
+func ftsQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"*`
+	}
+
+	return strings.Join(terms, " ")
+}
+
+func markMatches(s string) string {
+	return matchReplacer.Replace(html.EscapeString(s))
+}
+
+// SearchRecipesForUser searches the names, descriptions and ingredients of the
+// recipes of the user, best matches first.
+func (c *Config) SearchRecipesForUser(ctx context.Context, user User, text string, limit int) ([]RecipeSearchResult, error) {
+	query := ftsQuery(text)
+	if query == "" {
+		return nil, domerr.ErrInvalidInput
+	}
+
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit < 0 || limit > MaxPageLimit {
+		return nil, domerr.ErrInvalidPageLimit
+	}
+
+	rows, err := c.Querier().SearchRecipesForUser(ctx, database.SearchRecipesForUserParams{
+		Query:    query,
+		OwnerID:  user.ID,
+		MemberID: user.ID,
+		Limit:    int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]RecipeSearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = RecipeSearchResult{
+			Recipe:             databaseToDomainRecipe(row.Recipe),
+			NameHighlight:      markMatches(row.NameHighlight),
+			DescriptionSnippet: markMatches(row.DescriptionSnippet),
+			IngredientsSnippet: markMatches(row.IngredientsSnippet),
+			// bm25 scores are negative, with the best match the lowest
+			Score: -row.Rank,
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+	"github.com/snorman7384/recipe-wizard/recscrape"
+)
+
+// MaxTagLength is the longest a tag may be, in characters.
+const MaxTagLength = 50
+
+// maxScrapedTags limits the tags a scraped recipe gets, since some sites stuff
+// their keywords.
+const maxScrapedTags = 30
+
+func databaseToDomainRecipeTag(tag database.RecipeTag) RecipeTag {
+	kind, _ := TagKindFromString(tag.Kind)
+	return RecipeTag{
+		RecipeID:  tag.RecipeID,
+		CreatedAt: tag.CreatedAt,
+		Name:      tag.Name,
+		Kind:      kind,
+	}
+}
+
+// normalizeTag returns the name a tag is stored under: lower case, with runs of
+// white space collapsed. It reports false if the name is empty or too long.
+func normalizeTag(name string) (string, bool) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if name == "" || utf8.RuneCountInString(name) > MaxTagLength {
+		return "", false
+	}
+	return name, true
+}
+
+// normalizeTags normalizes a list of tags and drops duplicates.
+func normalizeTags(names []string) ([]string, error) {
+	tags := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		tag, ok := normalizeTag(name)
+		if !ok {
+			return nil, domerr.ErrInvalidTag
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+func (c *Config) GetTagsForRecipe(ctx context.Context, user User, recipe Recipe) ([]RecipeTag, error) {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionView); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.Querier().GetTagsForRecipe(ctx, recipe.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]RecipeTag, len(rows))
+	for i, row := range rows {
+		tags[i] = databaseToDomainRecipeTag(row)
+	}
+
+	return tags, nil
+}
+
+// AddRecipeTag tags a recipe. Adding a tag the recipe already has, from its
+// scraped data or otherwise, leaves it as it is.
+func (c *Config) AddRecipeTag(ctx context.Context, user User, recipe Recipe, name string) (RecipeTag, error) {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionEdit); err != nil {
+		return RecipeTag{}, err
+	}
+
+	name, ok := normalizeTag(name)
+	if !ok {
+		return RecipeTag{}, domerr.ErrInvalidTag
+	}
+
+	err := c.Querier().AddRecipeTag(ctx, database.AddRecipeTagParams{
+		RecipeID:  recipe.ID,
+		Name:      name,
+		Kind:      TagUser.String(),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return RecipeTag{}, err
+	}
+
+	tag, err := c.Querier().GetRecipeTag(ctx, database.GetRecipeTagParams{
+		RecipeID: recipe.ID,
+		Name:     name,
+	})
+	if err != nil {
+		return RecipeTag{}, err
+	}
+
+	return databaseToDomainRecipeTag(tag), nil
+}
+
+// DeleteRecipeTag removes a tag from a recipe, whichever kind it is.
+func (c *Config) DeleteRecipeTag(ctx context.Context, user User, recipe Recipe, name string) error {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionEdit); err != nil {
+		return err
+	}
+
+	name, ok := normalizeTag(name)
+	if !ok {
+		return domerr.ErrNotFound
+	}
+
+	count, err := c.Querier().DeleteRecipeTag(ctx, database.DeleteRecipeTagParams{
+		RecipeID: recipe.ID,
+		Name:     name,
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domerr.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetTagsForUser returns the tags of the recipes the user can see, with how
+// many recipes have each, most used first.
+func (c *Config) GetTagsForUser(ctx context.Context, user User) ([]TagCount, error) {
+	rows, err := c.Querier().GetTagCountsForUser(ctx, database.GetTagCountsForUserParams{
+		OwnerID:  user.ID,
+		MemberID: user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]TagCount, len(rows))
+	for i, row := range rows {
+		tags[i] = TagCount{Name: row.Name, Count: row.RecipeCount}
+	}
+
+	return tags, nil
+}
+
+// createScrapedTags tags a newly scraped recipe with its categories, cuisines
+// and keywords. Values that do not make valid tags are skipped; a value that is
+// both a category and a keyword keeps the first kind it is found as.
+func createScrapedTags(ctx context.Context, q *database.Queries, recipeID int64, scraped recscrape.Recipe) error {
+	now := time.Now()
+	count := 0
+
+	for _, source := range []struct {
+		kind   TagKind
+		values []string
+	}{
+		{TagCategory, scraped.Categories},
+		{TagCuisine, scraped.Cuisines},
+		{TagKeyword, scraped.Keywords},
+	} {
+		for _, value := range source.values {
+			if count >= maxScrapedTags {
+				return nil
+			}
+
+			name, ok := normalizeTag(value)
+			if !ok {
+				continue
+			}
+
+			err := q.AddRecipeTag(ctx, database.AddRecipeTagParams{
+				RecipeID:  recipeID,
+				Name:      name,
+				Kind:      source.kind.String(),
+				CreatedAt: now,
+			})
+			if err != nil {
+				return err
+			}
+			count++
+		}
+	}
+
+	return nil
+}
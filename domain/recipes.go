@@ -3,14 +3,16 @@ package domain
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
-	"sync"
+	"math"
+	"strings"
 	"time"
 
-	"github.com/kkyr/go-recipe/pkg/recipe"
 	"github.com/snorman7384/recipe-wizard/domerr"
 	"github.com/snorman7384/recipe-wizard/internal/database"
 	"github.com/snorman7384/recipe-wizard/internal/misc"
+	"github.com/snorman7384/recipe-wizard/recscrape"
 )
 
 func databaseToDomainRecipe(recipe database.Recipe) Recipe {
@@ -21,38 +23,131 @@ func databaseToDomainRecipe(recipe database.Recipe) Recipe {
 		Name:        recipe.Name,
 		Description: recipe.Description.String,
 		Url:         recipe.Url.String,
-		PrepTime:    recipe.PrepTime.String,
-		CookTime:    recipe.CookTime.String,
-		TotalTime:   recipe.TotalTime.String,
+		PrepTime:    time.Duration(recipe.PrepTimeSeconds.Int64) * time.Second,
+		CookTime:    time.Duration(recipe.CookTimeSeconds.Int64) * time.Second,
+		TotalTime:   time.Duration(recipe.TotalTimeSeconds.Int64) * time.Second,
+		Servings:    recipe.Servings.Int64,
 		OwnerID:     recipe.OwnerID,
+		HouseholdID: recipe.HouseholdID.Int64,
 	}
 }
 
+// MaxRecipeTime is the longest a time of a recipe may be.
+const MaxRecipeTime = 30 * 24 * time.Hour
+
+// TotalDuration returns how long the recipe takes to make: its total time, or
+// if it has none, its prep and cook times added up. It is 0 when the recipe
+// does not say.
+func (r Recipe) TotalDuration() time.Duration {
+	if r.TotalTime > 0 {
+		return r.TotalTime
+	}
+	return r.PrepTime + r.CookTime
+}
+
+func validRecipeTimes(times ...time.Duration) bool {
+	for _, t := range times {
+		if t < 0 || t > MaxRecipeTime {
+			return false
+		}
+	}
+	return true
+}
+
+// validRecipeUrl reports whether a recipe may link to the url. Recipes
+// without a url are fine.
+func validRecipeUrl(u string) bool {
+	return u == "" || misc.IsWebURL(u)
+}
+
 func (c *Config) CreateRecipeFromUrl(ctx context.Context, user User, url string) (Recipe, error) {
 
 	// get recipe data
-	s, err := recipe.ScrapeURL(url)
+	scraped, err := c.RecipeScraper.ScrapeURL(ctx, url)
 	if err != nil {
-		// respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Could not parse recipe from url: %v", err))
-		return Recipe{}, domerr.ErrRecipeScraperFailure
+		return Recipe{}, scrapeURLErrorToDomain(err)
+	}
+
+	recipe, err := c.createScrapedRecipe(ctx, user, url, scraped)
+	if err != nil {
+		return Recipe{}, err
 	}
 
-	name, ok := s.Name()
-	if !ok {
+	c.importRecipeImage(ctx, recipe.ID, url, scraped.ImageURL)
+
+	return recipe, nil
+}
+
+func scrapeURLErrorToDomain(err error) error {
+	switch {
+	case errors.Is(err, recscrape.ErrUnsupportedScheme):
+		return domerr.ErrUrlSchemeNotAllowed
+	case errors.Is(err, recscrape.ErrForbiddenAddress):
+		return domerr.ErrUrlAddressForbidden
+	case errors.Is(err, recscrape.ErrTooManyRedirects):
+		return domerr.ErrTooManyRedirects
+	case errors.Is(err, recscrape.ErrResponseTooLarge):
+		return domerr.ErrRecipePageTooLarge
+	case errors.Is(err, recscrape.ErrUnexpectedStatus):
+		return domerr.ErrRecipePageUnavailable
+	case errors.Is(err, recscrape.ErrFetchTimeout):
+		return domerr.ErrRecipeFetchTimeout
+	default:
+		return domerr.ErrRecipeScraperFailure
+	}
+}
+
+// CreateRecipeFromHTML creates a recipe from a page that has already been
+// fetched. The url is optional and is stored as the source of the recipe.
+// Nothing is fetched, so the recipe has no image until one is uploaded.
+func (c *Config) CreateRecipeFromHTML(ctx context.Context, user User, url string, html []byte) (Recipe, error) {
+	scraped, err := c.RecipeScraper.ScrapeHTML(url, html)
+	if err != nil {
+		return Recipe{}, domerr.ErrRecipeDocumentFailure
+	}
+
+	return c.createScrapedRecipe(ctx, user, url, scraped)
+}
+
+// CreateRecipeFromJSONLD creates a recipe from a schema.org Recipe JSON-LD
+// document. The url is optional and is stored as the source of the recipe.
+func (c *Config) CreateRecipeFromJSONLD(ctx context.Context, user User, url string, doc []byte) (Recipe, error) {
+	scraped, err := c.RecipeScraper.ScrapeJSONLD(doc)
+	if err != nil {
+		return Recipe{}, domerr.ErrRecipeDocumentFailure
+	}
+
+	return c.createScrapedRecipe(ctx, user, url, scraped)
+}
+
+func (c *Config) createScrapedRecipe(ctx context.Context, user User, url string, scraped recscrape.Recipe) (Recipe, error) {
+	if !validRecipeUrl(url) {
+		return Recipe{}, domerr.ErrInvalidInput
+	}
+
+	name := scraped.Name
+	if name == "" {
 		name = url
 	}
+	if name == "" {
+		return Recipe{}, domerr.ErrRecipeDocumentFailure
+	}
+
+	description := misc.SqlNullStringFromString(scraped.Description)
 
-	timeDurationToString := func(t time.Duration, ok bool) (string, bool) {
-		return t.String(), ok
+	// times that cannot be right are dropped rather than failing the import
+	recipeTime := func(t time.Duration) sql.NullInt64 {
+		if !validRecipeTimes(t) {
+			return sql.NullInt64{}
+		}
+		return misc.SqlNullInt64FromDuration(t)
 	}
 
-	description := misc.SqlNullStringFromOkString(s.Description())
-	prepTime := misc.SqlNullStringFromOkString(timeDurationToString(s.PrepTime()))
-	cookTime := misc.SqlNullStringFromOkString(timeDurationToString(s.CookTime()))
-	totalTime := misc.SqlNullStringFromOkString(timeDurationToString(s.TotalTime()))
+	var servings sql.NullInt64
+	servings.Int64, servings.Valid = servingsFromYield(scraped.Yield)
 
 	now := time.Now()
-	sqlUrl := misc.SqlNullStringFromOkString(url, true)
+	sqlUrl := misc.SqlNullStringFromString(url)
 
 	tx, err := c.DB.Begin()
 	if err != nil {
@@ -63,64 +158,219 @@ func (c *Config) CreateRecipeFromUrl(ctx context.Context, user User, url string)
 	qtx := c.Querier().WithTx(tx)
 
 	recipe, err := qtx.CreateRecipe(ctx, database.CreateRecipeParams{
-		CreatedAt:   now,
-		UpdatedAt:   now,
-		Url:         sqlUrl,
-		Name:        name,
-		Description: description,
-		CookTime:    cookTime,
-		PrepTime:    prepTime,
-		TotalTime:   totalTime,
-		OwnerID:     user.ID,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		Url:              sqlUrl,
+		Name:             name,
+		Description:      description,
+		PrepTimeSeconds:  recipeTime(scraped.PrepTime),
+		CookTimeSeconds:  recipeTime(scraped.CookTime),
+		TotalTimeSeconds: recipeTime(scraped.TotalTime),
+		Servings:         servings,
+		OwnerID:          user.ID,
 	})
 	if err != nil {
 		return Recipe{}, err
 	}
 
-	if ings, ok := s.Ingredients(); ok {
-		ingredients, err := c.IngredientParser.ParseIngredients(ings)
+	if len(scraped.Ingredients) > 0 {
+		ingredients, err := c.IngredientParser.ParseIngredients(scraped.Ingredients)
 		if err != nil {
 			return Recipe{}, err
 		}
-		var wg sync.WaitGroup
-		ch := make(chan error, len(ingredients))
-		for _, ingredient := range ingredients {
-			ingredient := ingredient // I love loop variables
-
-			wg.Add(1)
-
-			go func() {
-				defer wg.Done()
-
-				now = time.Now()
-				_, err := qtx.CreateIngredient(ctx, database.CreateIngredientParams{
-					CreatedAt:      now,
-					UpdatedAt:      now,
-					Name:           ingredient.Name,
-					Amount:         ingredient.Measure.OriginalAmount,
-					Units:          ingredient.Measure.OriginalUnits,
-					StandardAmount: ingredient.Measure.StandardAmount,
-					StandardUnits:  ingredient.Measure.StandardUnits.String(),
-					RecipeID:       recipe.ID,
-					Description:    sql.NullString{String: ingredient.Description, Valid: ingredient.Description != ""},
-				})
-				if err != nil {
-					ch <- err
-					return
-				}
-			}()
-		}
-		wg.Wait()
-		select {
-		case err := <-ch:
+		err = createIngredients(ctx, qtx, recipe.ID, ingredients)
+		if err != nil {
 			return Recipe{}, err
-		default:
 		}
 	}
 
+	err = createInstructions(ctx, qtx, recipe.ID, scraped.Instructions)
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	err = createScrapedTags(ctx, qtx, recipe.ID, scraped)
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Recipe{}, err
+	}
+
+	return databaseToDomainRecipe(recipe), nil
+}
+
+type CreateRecipeParams struct {
+	Name         string
+	Description  string
+	Url          string
+	PrepTime     time.Duration
+	CookTime     time.Duration
+	TotalTime    time.Duration
+	Servings     int64
+	Ingredients  []string // one ingredient per line, e.g. "2 cups flour"
+	Instructions []string // one step per line, in order
+}
+
+func (c *Config) CreateRecipe(ctx context.Context, user User, params CreateRecipeParams) (Recipe, error) {
+	if strings.TrimSpace(params.Name) == "" || params.Servings < 0 || !validRecipeUrl(params.Url) {
+		return Recipe{}, domerr.ErrInvalidInput
+	}
+	if !validRecipeTimes(params.PrepTime, params.CookTime, params.TotalTime) {
+		return Recipe{}, domerr.ErrInvalidDuration
+	}
+
+	ingredients := c.parseIngredientLines(params.Ingredients)
+
+	now := time.Now()
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return Recipe{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	recipe, err := qtx.CreateRecipe(ctx, database.CreateRecipeParams{
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		Url:              misc.SqlNullStringFromString(params.Url),
+		Name:             params.Name,
+		Description:      misc.SqlNullStringFromString(params.Description),
+		PrepTimeSeconds:  misc.SqlNullInt64FromDuration(params.PrepTime),
+		CookTimeSeconds:  misc.SqlNullInt64FromDuration(params.CookTime),
+		TotalTimeSeconds: misc.SqlNullInt64FromDuration(params.TotalTime),
+		Servings:         misc.SqlNullInt64FromPositiveInt64(params.Servings),
+		OwnerID:          user.ID,
+	})
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	err = createIngredients(ctx, qtx, recipe.ID, ingredients)
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	err = createInstructions(ctx, qtx, recipe.ID, params.Instructions)
+	if err != nil {
+		return Recipe{}, err
+	}
+
 	return databaseToDomainRecipe(recipe), tx.Commit()
 }
 
+// UpdateRecipeParams describes changes to a recipe. Nil fields are left as
+// they are, so the same params serve both full and partial updates.
+type UpdateRecipeParams struct {
+	Name         *string
+	Description  *string
+	Url          *string
+	PrepTime     *time.Duration
+	CookTime     *time.Duration
+	TotalTime    *time.Duration
+	Servings     *int64
+	Ingredients  *[]string // replaces every ingredient of the recipe when set
+	Instructions *[]string // replaces every step of the recipe when set
+}
+
+func (c *Config) UpdateRecipe(ctx context.Context, user User, recipe Recipe, params UpdateRecipeParams) (Recipe, error) {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionEdit); err != nil {
+		return Recipe{}, err
+	}
+
+	if params.Name != nil {
+		recipe.Name = *params.Name
+	}
+	if params.Description != nil {
+		recipe.Description = *params.Description
+	}
+	if params.Url != nil {
+		recipe.Url = *params.Url
+	}
+	if params.PrepTime != nil {
+		recipe.PrepTime = *params.PrepTime
+	}
+	if params.CookTime != nil {
+		recipe.CookTime = *params.CookTime
+	}
+	if params.TotalTime != nil {
+		recipe.TotalTime = *params.TotalTime
+	}
+	if params.Servings != nil {
+		recipe.Servings = *params.Servings
+	}
+
+	if strings.TrimSpace(recipe.Name) == "" || recipe.Servings < 0 || !validRecipeUrl(recipe.Url) {
+		return Recipe{}, domerr.ErrInvalidInput
+	}
+	if !validRecipeTimes(recipe.PrepTime, recipe.CookTime, recipe.TotalTime) {
+		return Recipe{}, domerr.ErrInvalidDuration
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return Recipe{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	updated, err := qtx.UpdateRecipe(ctx, database.UpdateRecipeParams{
+		UpdatedAt:        time.Now(),
+		Name:             recipe.Name,
+		Description:      misc.SqlNullStringFromString(recipe.Description),
+		Url:              misc.SqlNullStringFromString(recipe.Url),
+		PrepTimeSeconds:  misc.SqlNullInt64FromDuration(recipe.PrepTime),
+		CookTimeSeconds:  misc.SqlNullInt64FromDuration(recipe.CookTime),
+		TotalTimeSeconds: misc.SqlNullInt64FromDuration(recipe.TotalTime),
+		Servings:         misc.SqlNullInt64FromPositiveInt64(recipe.Servings),
+		ID:               recipe.ID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Recipe{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	if params.Ingredients != nil {
+		ingredients := c.parseIngredientLines(*params.Ingredients)
+
+		// items already on grocery lists keep their own copy of the ingredient data
+		err = qtx.UnlinkItemsFromRecipeIngredients(ctx, recipe.ID)
+		if err != nil {
+			return Recipe{}, err
+		}
+
+		err = qtx.DeleteIngredientsForRecipe(ctx, recipe.ID)
+		if err != nil {
+			return Recipe{}, err
+		}
+
+		err = createIngredients(ctx, qtx, recipe.ID, ingredients)
+		if err != nil {
+			return Recipe{}, err
+		}
+	}
+
+	if params.Instructions != nil {
+		err = qtx.DeleteInstructionsForRecipe(ctx, recipe.ID)
+		if err != nil {
+			return Recipe{}, err
+		}
+
+		err = createInstructions(ctx, qtx, recipe.ID, *params.Instructions)
+		if err != nil {
+			return Recipe{}, err
+		}
+	}
+
+	return databaseToDomainRecipe(updated), tx.Commit()
+}
+
 func (c *Config) GetRecipe(ctx context.Context, user User, id int64) (Recipe, error) {
 
 	recipe, err := c.Querier().GetRecipe(ctx, int64(id))
@@ -131,15 +381,20 @@ func (c *Config) GetRecipe(ctx context.Context, user User, id int64) (Recipe, er
 		return Recipe{}, err
 	}
 
-	if user.ID != recipe.OwnerID {
-		return Recipe{}, domerr.ErrForbidden
+	domainRecipe := databaseToDomainRecipe(recipe)
+
+	if err := c.authorizeRecipe(ctx, user, domainRecipe, ActionView); err != nil {
+		return Recipe{}, err
 	}
 
-	return databaseToDomainRecipe(recipe), nil
+	return domainRecipe, nil
 }
 
 func (c *Config) GetRecipesForUser(ctx context.Context, user User) ([]Recipe, error) {
-	recipes, err := c.Querier().GetRecipesForUser(ctx, user.ID)
+	recipes, err := c.Querier().GetRecipesForUser(ctx, database.GetRecipesForUserParams{
+		OwnerID:  user.ID,
+		MemberID: user.ID,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -152,3 +407,225 @@ func (c *Config) GetRecipesForUser(ctx context.Context, user User) ([]Recipe, er
 
 	return domainList, nil
 }
+
+// ListRecipesForUser returns one page of the recipes of the user, and the cursor of the next page, which is
+// empty on the last page.
+func (c *Config) ListRecipesForUser(ctx context.Context, user User, params RecipeListParams) ([]Recipe, string, error) {
+	pg, err := params.page()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if params.MaxTotalTime < 0 {
+		return nil, "", domerr.ErrInvalidDuration
+	}
+	maxSeconds := int64(math.MaxInt64)
+	if params.MaxTotalTime > 0 {
+		maxSeconds = int64(params.MaxTotalTime / time.Second)
+	}
+
+	tags, err := normalizeTags(params.Tags)
+	if err != nil {
+		return nil, "", err
+	}
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return nil, "", err
+	}
+	tagsNeeded := int64(0)
+	if len(tags) > 0 {
+		tagsNeeded = 1
+		if params.MatchAllTags {
+			tagsNeeded = int64(len(tags))
+		}
+	}
+
+	var rows []database.Recipe
+	switch pg.sort {
+	case SortCreatedAt:
+		rows, err = c.Querier().ListRecipesForUser(ctx, database.ListRecipesForUserParams{
+			OwnerID:        user.ID,
+			MemberID:       user.ID,
+			NameContains:   pg.nameContains,
+			CreatedAfter:   pg.createdAfter,
+			MaxTimeSeconds: maxSeconds,
+			Tags:           string(tagsJSON),
+			TagsNeeded:     tagsNeeded,
+			AfterID:        pg.after.ID,
+			Limit:          pg.queryLimit(),
+		})
+	case SortCreatedAtDesc:
+		rows, err = c.Querier().ListRecipesForUserDesc(ctx, database.ListRecipesForUserDescParams{
+			OwnerID:        user.ID,
+			MemberID:       user.ID,
+			NameContains:   pg.nameContains,
+			CreatedAfter:   pg.createdAfter,
+			MaxTimeSeconds: maxSeconds,
+			Tags:           string(tagsJSON),
+			TagsNeeded:     tagsNeeded,
+			BeforeID:       pg.after.ID,
+			Limit:          pg.queryLimit(),
+		})
+	case SortName:
+		rows, err = c.Querier().ListRecipesForUserByName(ctx, database.ListRecipesForUserByNameParams{
+			OwnerID:        user.ID,
+			MemberID:       user.ID,
+			NameContains:   pg.nameContains,
+			CreatedAfter:   pg.createdAfter,
+			MaxTimeSeconds: maxSeconds,
+			Tags:           string(tagsJSON),
+			TagsNeeded:     tagsNeeded,
+			FromStart:      pg.fromStart,
+			AfterName:      pg.after.Name,
+			AfterID:        pg.after.ID,
+			Limit:          pg.queryLimit(),
+		})
+	case SortNameDesc:
+		rows, err = c.Querier().ListRecipesForUserByNameDesc(ctx, database.ListRecipesForUserByNameDescParams{
+			OwnerID:        user.ID,
+			MemberID:       user.ID,
+			NameContains:   pg.nameContains,
+			CreatedAfter:   pg.createdAfter,
+			MaxTimeSeconds: maxSeconds,
+			Tags:           string(tagsJSON),
+			TagsNeeded:     tagsNeeded,
+			FromStart:      pg.fromStart,
+			BeforeName:     pg.after.Name,
+			BeforeID:       pg.after.ID,
+			Limit:          pg.queryLimit(),
+		})
+	case SortTotalTime:
+		rows, err = c.Querier().ListRecipesForUserByTime(ctx, database.ListRecipesForUserByTimeParams{
+			OwnerID:        user.ID,
+			MemberID:       user.ID,
+			NameContains:   pg.nameContains,
+			CreatedAfter:   pg.createdAfter,
+			MaxTimeSeconds: maxSeconds,
+			Tags:           string(tagsJSON),
+			TagsNeeded:     tagsNeeded,
+			FromStart:      pg.fromStart,
+			AfterSeconds:   pg.after.Seconds,
+			AfterID:        pg.after.ID,
+			Limit:          pg.queryLimit(),
+		})
+	case SortTotalTimeDesc:
+		rows, err = c.Querier().ListRecipesForUserByTimeDesc(ctx, database.ListRecipesForUserByTimeDescParams{
+			OwnerID:        user.ID,
+			MemberID:       user.ID,
+			NameContains:   pg.nameContains,
+			CreatedAfter:   pg.createdAfter,
+			MaxTimeSeconds: maxSeconds,
+			Tags:           string(tagsJSON),
+			TagsNeeded:     tagsNeeded,
+			FromStart:      pg.fromStart,
+			BeforeSeconds:  pg.after.Seconds,
+			BeforeID:       pg.after.ID,
+			Limit:          pg.queryLimit(),
+		})
+	default:
+		return nil, "", domerr.ErrInvalidSort
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	list := make([]Recipe, pg.trim(len(rows)))
+	for i := range list {
+		list[i] = databaseToDomainRecipe(rows[i])
+	}
+
+	var next string
+	if len(list) > 0 {
+		last := rows[len(list)-1]
+
+		// the queries sort recipes that do not say how long they take last
+		seconds := last.TimeSeconds.Int64
+		if !last.TimeSeconds.Valid {
+			seconds = math.MaxInt64
+			if pg.sort == SortTotalTimeDesc {
+				seconds = -1
+			}
+		}
+
+		next = pg.nextCursor(len(rows), cursor{Name: last.Name, Seconds: seconds, ID: last.ID})
+	}
+
+	return list, next, nil
+}
+
+// DeleteRecipe deletes a recipe with its ingredients, instructions, tags and image. Recipes that are still
+// used by meals are not deleted; the meals have to be removed first.
+func (c *Config) DeleteRecipe(ctx context.Context, user User, recipe Recipe) error {
+	if err := c.authorizeRecipe(ctx, user, recipe, ActionDelete); err != nil {
+		return err
+	}
+
+	image, err := c.Querier().GetRecipeImage(ctx, recipe.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	count, err := qtx.CountMealsForRecipe(ctx, recipe.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return domerr.ErrRecipeInUse
+	}
+
+	count, err = qtx.CountPlannedMealsForRecipe(ctx, recipe.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return domerr.ErrRecipeInUse
+	}
+
+	err = qtx.UnlinkItemsFromRecipeIngredients(ctx, recipe.ID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteIngredientsForRecipe(ctx, recipe.ID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteInstructionsForRecipe(ctx, recipe.ID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteTagsForRecipe(ctx, recipe.ID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteRecipeImage(ctx, recipe.ID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteRecipe(ctx, recipe.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if image.BlobKey != "" {
+		c.deleteBlob(ctx, image.BlobKey)
+	}
+
+	return nil
+}
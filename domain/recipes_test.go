@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidRecipeTimes(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want bool
+	}{
+		{0, true},
+		{90 * time.Minute, true},
+		{MaxRecipeTime, true},
+		{MaxRecipeTime + time.Second, false},
+		{31 * 24 * time.Hour, false},
+		{-time.Second, false},
+	}
+
+	for _, tt := range tests {
+		if got := validRecipeTimes(tt.d); got != tt.want {
+			t.Errorf("validRecipeTimes(%v) = %v, want %v", tt.d, got, tt.want)
+		}
+	}
+}
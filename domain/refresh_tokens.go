@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+)
+
+const refreshTokenDuration = time.Hour * 24 * 30
+
+// Refresh and password reset tokens are random strings handed out once. Only
+// their sha256 hash is stored, so a leaked database cannot be used to mint
+// tokens.
+func newSecretToken() (token string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashSecretToken(token), nil
+}
+
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func createRefreshToken(ctx context.Context, q *database.Queries, userID int64, now time.Time) (database.RefreshToken, string, error) {
+	token, hash, err := newSecretToken()
+	if err != nil {
+		return database.RefreshToken{}, "", err
+	}
+
+	dbToken, err := q.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{
+		CreatedAt: now,
+		UpdatedAt: now,
+		UserID:    userID,
+		TokenHash: hash,
+		ExpiresAt: now.Add(refreshTokenDuration),
+	})
+	if err != nil {
+		return database.RefreshToken{}, "", err
+	}
+
+	return dbToken, token, nil
+}
+
+// CreateRefreshToken issues a new refresh token for the user and returns it.
+func (c *Config) CreateRefreshToken(ctx context.Context, user User) (string, error) {
+	_, token, err := createRefreshToken(ctx, c.Querier(), user.ID, time.Now())
+	return token, err
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one, revoking the old
+// token. It returns the user the token belongs to, so that a new access token
+// can be issued for them.
+//
+// A refresh token that has already been revoked being presented again means it
+// has been stolen, or the client has lost track of its tokens. Either way every
+// token the user holds is revoked and they have to log in again.
+func (c *Config) RotateRefreshToken(ctx context.Context, token string) (User, string, error) {
+	now := time.Now()
+
+	dbToken, err := c.Querier().GetRefreshTokenByHash(ctx, hashSecretToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, "", domerr.ErrInvalidRefreshToken
+	} else if err != nil {
+		return User{}, "", err
+	}
+
+	if dbToken.RevokedAt.Valid {
+		if err := c.revokeAllTokens(ctx, dbToken.UserID); err != nil {
+			return User{}, "", err
+		}
+		return User{}, "", domerr.ErrInvalidRefreshToken
+	}
+
+	if !now.Before(dbToken.ExpiresAt) {
+		return User{}, "", domerr.ErrInvalidRefreshToken
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return User{}, "", err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	newToken, tokenString, err := createRefreshToken(ctx, qtx, dbToken.UserID, now)
+	if err != nil {
+		return User{}, "", err
+	}
+
+	rows, err := qtx.RevokeRefreshToken(ctx, database.RevokeRefreshTokenParams{
+		UpdatedAt:    now,
+		RevokedAt:    sql.NullTime{Time: now, Valid: true},
+		ReplacedByID: sql.NullInt64{Int64: newToken.ID, Valid: true},
+		ID:           dbToken.ID,
+	})
+	if err != nil {
+		return User{}, "", err
+	}
+	if rows == 0 {
+		// another request rotated the token first
+		return User{}, "", domerr.ErrInvalidRefreshToken
+	}
+
+	user, err := qtx.GetUser(ctx, dbToken.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, "", domerr.ErrUserNotFound
+	} else if err != nil {
+		return User{}, "", err
+	}
+
+	return databaseToDomainUser(user), tokenString, tx.Commit()
+}
+
+// Logout revokes the access tokens issued to the user so far, and the given
+// refresh token if there is one. When all is set, every refresh token the user
+// holds is revoked as well.
+func (c *Config) Logout(ctx context.Context, user User, refreshToken string, all bool) error {
+	if all {
+		return c.revokeAllTokens(ctx, user.ID)
+	}
+
+	now := time.Now()
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	if refreshToken != "" {
+		dbToken, err := qtx.GetRefreshTokenByHash(ctx, hashSecretToken(refreshToken))
+		if errors.Is(err, sql.ErrNoRows) {
+			return domerr.ErrInvalidRefreshToken
+		} else if err != nil {
+			return err
+		}
+
+		if user.ID != dbToken.UserID {
+			return domerr.ErrForbidden
+		}
+
+		_, err = qtx.RevokeRefreshToken(ctx, database.RevokeRefreshTokenParams{
+			UpdatedAt: now,
+			RevokedAt: sql.NullTime{Time: now, Valid: true},
+			ID:        dbToken.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = qtx.IncrementUserTokenVersion(ctx, database.IncrementUserTokenVersionParams{
+		UpdatedAt: now,
+		ID:        user.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (c *Config) revokeAllTokens(ctx context.Context, userID int64) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := revokeAllTokens(ctx, c.Querier().WithTx(tx), userID, time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// revokeAllTokens revokes every refresh token of the user, and by bumping
+// their token version every access token as well.
+func revokeAllTokens(ctx context.Context, q *database.Queries, userID int64, now time.Time) error {
+	err := q.RevokeRefreshTokensForUser(ctx, database.RevokeRefreshTokensForUserParams{
+		UpdatedAt: now,
+		RevokedAt: sql.NullTime{Time: now, Valid: true},
+		UserID:    userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = q.IncrementUserTokenVersion(ctx, database.IncrementUserTokenVersionParams{
+		UpdatedAt: now,
+		ID:        userID,
+	})
+	return err
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+)
+
+// servingsFromYield reads the number of servings out of a scraped recipe
+// yield such as "4 servings", "Serves 4-6" or "Makes 12 cookies". The first
+// whole number found is used.
+func servingsFromYield(yield string) (int64, bool) {
+	start := strings.IndexFunc(yield, unicode.IsDigit)
+	if start < 0 {
+		return 0, false
+	}
+	yield = yield[start:]
+
+	if end := strings.IndexFunc(yield, func(r rune) bool { return !unicode.IsDigit(r) }); end >= 0 {
+		yield = yield[:end]
+	}
+
+	servings, err := strconv.ParseInt(yield, 10, 64)
+	if err != nil || servings <= 0 {
+		return 0, false
+	}
+	return servings, true
+}
+
+// servingsScale returns the factor by which a recipe's amounts are multiplied
+// to make the given number of servings. Zero servings means the recipe as
+// written.
+func servingsScale(recipe Recipe, servings int64) (float64, error) {
+	if servings == 0 {
+		return 1, nil
+	}
+	if servings < 0 {
+		return 0, domerr.ErrInvalidInput
+	}
+	if recipe.Servings <= 0 {
+		return 0, domerr.ErrUnknownServings
+	}
+	return float64(servings) / float64(recipe.Servings), nil
+}
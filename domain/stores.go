@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+	"github.com/snorman7384/recipe-wizard/internal/misc"
+)
+
+func databaseToDomainStore(store database.Store, aisles []database.StoreAisle) Store {
+	domainAisles := make([]StoreAisle, len(aisles))
+	for i, aisle := range aisles {
+		domainAisles[i] = StoreAisle{
+			Category: databaseToDomainCategory(sql.NullString{String: aisle.Category, Valid: true}),
+			Name:     aisle.Name.String,
+		}
+	}
+
+	return Store{
+		ID:        store.ID,
+		CreatedAt: store.CreatedAt,
+		UpdatedAt: store.UpdatedAt,
+		OwnerID:   store.OwnerID,
+		Name:      store.Name,
+		Aisles:    domainAisles,
+	}
+}
+
+// validateStoreLayout checks a store's name and aisles, and returns the name
+// trimmed. Each category may have at most one aisle.
+func validateStoreLayout(name string, aisles []StoreAisle) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", domerr.ErrInvalidInput
+	}
+
+	seen := make(map[ItemCategory]bool, len(aisles))
+	for _, aisle := range aisles {
+		if aisle.Category < CategoryProduce || aisle.Category > CategoryOther || seen[aisle.Category] {
+			return "", domerr.ErrInvalidInput
+		}
+		seen[aisle.Category] = true
+	}
+
+	return name, nil
+}
+
+// createStoreAisles stores the aisles of a store in the order they are given.
+func createStoreAisles(ctx context.Context, q *database.Queries, storeID int64, aisles []StoreAisle) error {
+	for i, aisle := range aisles {
+		err := q.CreateStoreAisle(ctx, database.CreateStoreAisleParams{
+			StoreID:  storeID,
+			Category: aisle.Category.String(),
+			Position: int64(i),
+			Name:     misc.SqlNullStringFromString(strings.TrimSpace(aisle.Name)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Config) CreateStore(ctx context.Context, user User, name string, aisles []StoreAisle) (Store, error) {
+	name, err := validateStoreLayout(name, aisles)
+	if err != nil {
+		return Store{}, err
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return Store{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	now := time.Now()
+	store, err := qtx.CreateStore(ctx, database.CreateStoreParams{
+		CreatedAt: now,
+		UpdatedAt: now,
+		OwnerID:   user.ID,
+		Name:      name,
+	})
+	if err != nil {
+		return Store{}, err
+	}
+
+	if err := createStoreAisles(ctx, qtx, store.ID, aisles); err != nil {
+		return Store{}, err
+	}
+
+	dbAisles, err := qtx.GetStoreAisles(ctx, store.ID)
+	if err != nil {
+		return Store{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Store{}, err
+	}
+
+	return databaseToDomainStore(store, dbAisles), nil
+}
+
+func (c *Config) GetStore(ctx context.Context, user User, id int64) (Store, error) {
+	store, err := c.Querier().GetStore(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Store{}, domerr.ErrNotFound
+	}
+	if err != nil {
+		return Store{}, err
+	}
+
+	if user.ID != store.OwnerID {
+		return Store{}, domerr.ErrForbidden
+	}
+
+	aisles, err := c.Querier().GetStoreAisles(ctx, store.ID)
+	if err != nil {
+		return Store{}, err
+	}
+
+	return databaseToDomainStore(store, aisles), nil
+}
+
+func (c *Config) GetStoresForUser(ctx context.Context, user User) ([]Store, error) {
+	dbStores, err := c.Querier().GetStoresForUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stores := make([]Store, len(dbStores))
+	for i, store := range dbStores {
+		aisles, err := c.Querier().GetStoreAisles(ctx, store.ID)
+		if err != nil {
+			return nil, err
+		}
+		stores[i] = databaseToDomainStore(store, aisles)
+	}
+
+	return stores, nil
+}
+
+// UpdateStore renames the store and replaces its layout with the aisles given.
+func (c *Config) UpdateStore(ctx context.Context, user User, store Store, name string, aisles []StoreAisle) (Store, error) {
+	if user.ID != store.OwnerID {
+		return Store{}, domerr.ErrForbidden
+	}
+
+	name, err := validateStoreLayout(name, aisles)
+	if err != nil {
+		return Store{}, err
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return Store{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	dbStore, err := qtx.UpdateStore(ctx, database.UpdateStoreParams{
+		UpdatedAt: time.Now(),
+		Name:      name,
+		ID:        store.ID,
+	})
+	if err != nil {
+		return Store{}, err
+	}
+
+	if err := qtx.DeleteStoreAisles(ctx, store.ID); err != nil {
+		return Store{}, err
+	}
+
+	if err := createStoreAisles(ctx, qtx, store.ID, aisles); err != nil {
+		return Store{}, err
+	}
+
+	dbAisles, err := qtx.GetStoreAisles(ctx, store.ID)
+	if err != nil {
+		return Store{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Store{}, err
+	}
+
+	return databaseToDomainStore(dbStore, dbAisles), nil
+}
+
+func (c *Config) DeleteStore(ctx context.Context, user User, store Store) error {
+	if user.ID != store.OwnerID {
+		return domerr.ErrForbidden
+	}
+
+	return c.Querier().DeleteStore(ctx, store.ID)
+}
+
+// GetItemSectionsForGroceryList groups the items on the grocery list as
+// GetItemGroupsForGroceryList does, and sorts the groups into sections in the
+// order the store's aisles are walked. Categories the store has no aisle for
+// come after those it has, in the order of ItemCategories. Sections without
+// items are left out.
+func (c *Config) GetItemSectionsForGroceryList(ctx context.Context, groceryList GroceryList, store Store) ([]ItemSection, error) {
+	groups, err := c.GetItemGroupsForGroceryList(ctx, groceryList)
+	if err != nil {
+		return nil, err
+	}
+
+	byCategory := make(map[ItemCategory][]ItemGroup)
+	for _, group := range groups {
+		byCategory[group.Category] = append(byCategory[group.Category], group)
+	}
+
+	sections := make([]ItemSection, 0, len(byCategory))
+	placed := make(map[ItemCategory]bool)
+
+	for _, aisle := range store.Aisles {
+		placed[aisle.Category] = true
+		if len(byCategory[aisle.Category]) == 0 {
+			continue
+		}
+		sections = append(sections, ItemSection{
+			Category: aisle.Category,
+			Aisle:    aisle.Name,
+			Groups:   byCategory[aisle.Category],
+		})
+	}
+
+	for _, category := range ItemCategories {
+		if placed[category] || len(byCategory[category]) == 0 {
+			continue
+		}
+		sections = append(sections, ItemSection{
+			Category: category,
+			Groups:   byCategory[category],
+		})
+	}
+
+	return sections, nil
+}
@@ -0,0 +1,333 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+	"github.com/snorman7384/recipe-wizard/ingparse"
+	"github.com/snorman7384/recipe-wizard/internal/database"
+	"github.com/snorman7384/recipe-wizard/internal/misc"
+)
+
+// MaxSyncOperations is the most operations ApplyGroceryListOperations takes at
+// once.
+const MaxSyncOperations = 500
+
+// syncToken marks the last change a client has seen. The grocery list is kept
+// so that a token cannot be used with a different list than the one it was
+// made for.
+type syncToken struct {
+	GroceryListID int64 `json:"g"`
+	ChangeID      int64 `json:"c"`
+}
+
+func (t syncToken) String() string {
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func parseSyncToken(s string, groceryListID int64) (syncToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return syncToken{}, domerr.ErrInvalidSyncToken
+	}
+
+	var t syncToken
+	if err := json.Unmarshal(b, &t); err != nil || t.GroceryListID != groceryListID {
+		return syncToken{}, domerr.ErrInvalidSyncToken
+	}
+
+	return t, nil
+}
+
+// GetGroceryListChanges returns what changed on the grocery list since the
+// sync token. Without a sync token it returns everything on the list, along
+// with a token to fetch the changes after that.
+func (c *Config) GetGroceryListChanges(ctx context.Context, groceryList GroceryList, since string) (GroceryListChanges, error) {
+	var after syncToken
+	if since != "" {
+		var err error
+		after, err = parseSyncToken(since, groceryList.ID)
+		if err != nil {
+			return GroceryListChanges{}, err
+		}
+	}
+
+	// everything is read in one transaction, so that the token matches what
+	// is returned even while the list is being changed
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return GroceryListChanges{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	latest, err := qtx.GetLatestGroceryListChange(ctx, groceryList.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return GroceryListChanges{}, err
+	}
+
+	changes := GroceryListChanges{
+		Full:           since == "",
+		Items:          []Item{},
+		Meals:          []Meal{},
+		DeletedItemIDs: []int64{},
+		DeletedMealIDs: []int64{},
+		SyncToken:      syncToken{GroceryListID: groceryList.ID, ChangeID: latest}.String(),
+	}
+
+	if changes.Full {
+		items, err := qtx.GetItemsForGroceryList(ctx, groceryList.ID)
+		if err != nil {
+			return GroceryListChanges{}, err
+		}
+		for _, item := range items {
+			changes.Items = append(changes.Items, databaseToDomainItem(item))
+		}
+
+		meals, err := qtx.GetExtendedMealsInGroceryList(ctx, groceryList.ID)
+		if err != nil {
+			return GroceryListChanges{}, err
+		}
+		for _, row := range meals {
+			changes.Meals = append(changes.Meals, databaseToDomainMeal(row.Meal, databaseToDomainRecipe(row.Recipe)))
+		}
+
+		return changes, tx.Commit()
+	}
+
+	rows, err := qtx.GetGroceryListChanges(ctx, database.GetGroceryListChangesParams{
+		GroceryListID: groceryList.ID,
+		AfterID:       after.ChangeID,
+		UntilID:       latest,
+	})
+	if err != nil {
+		return GroceryListChanges{}, err
+	}
+
+	// only the last change to each item and meal matters, since the current
+	// state of it is returned
+	type entity struct {
+		kind string
+		id   int64
+	}
+	last := make(map[entity]database.GroceryListChange)
+	for _, row := range rows {
+		last[entity{row.Entity, row.EntityID}] = row
+	}
+
+	for _, row := range rows {
+		if last[entity{row.Entity, row.EntityID}].ID != row.ID {
+			continue
+		}
+
+		switch row.Entity {
+		case "item":
+			if row.Deleted {
+				changes.DeletedItemIDs = append(changes.DeletedItemIDs, row.EntityID)
+				continue
+			}
+
+			item, err := qtx.GetItem(ctx, row.EntityID)
+			if err != nil {
+				return GroceryListChanges{}, err
+			}
+			changes.Items = append(changes.Items, databaseToDomainItem(item))
+
+		case "meal":
+			if row.Deleted {
+				changes.DeletedMealIDs = append(changes.DeletedMealIDs, row.EntityID)
+				continue
+			}
+
+			meal, err := qtx.GetExtendedMeal(ctx, row.EntityID)
+			if err != nil {
+				return GroceryListChanges{}, err
+			}
+			changes.Meals = append(changes.Meals, databaseToDomainMeal(meal.Meal, databaseToDomainRecipe(meal.Recipe)))
+		}
+	}
+
+	return changes, tx.Commit()
+}
+
+// ApplyGroceryListOperations applies changes a client made to the items of the
+// grocery list while it was offline, in order, and returns what became of each
+// of them.
+//
+// Conflicts are settled by the time each change was made, and the latest one
+// wins: a change to an item that was changed on the server after the client
+// changed it is not applied, and its result carries the item as the server has
+// it. Changes are dated no later than the time they are applied, so clients
+// with clocks that run fast cannot lock others out of an item.
+//
+// Deleting an item that was already deleted succeeds. All the operations are
+// applied in one transaction, so either all or none of them take effect.
+func (c *Config) ApplyGroceryListOperations(ctx context.Context, user User, groceryList GroceryList, ops []SyncOperation) ([]SyncResult, error) {
+	if len(ops) > MaxSyncOperations {
+		return nil, domerr.ErrTooManySyncOperations
+	}
+
+	if err := c.authorizeGroceryList(ctx, user, groceryList, ActionEdit); err != nil {
+		return nil, err
+	}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := c.Querier().WithTx(tx)
+
+	type event struct {
+		eventType string
+		item      Item
+	}
+	var published []event
+
+	now := time.Now()
+	results := make([]SyncResult, len(ops))
+
+	for i, op := range ops {
+		result := SyncResult{ClientID: op.ClientID, Outcome: SyncInvalid}
+
+		changedAt := op.ChangedAt
+		if changedAt.After(now) {
+			changedAt = now
+		}
+
+		switch {
+		case changedAt.IsZero():
+			// without a time there is nothing to settle conflicts with
+
+		case op.Op == OpCreateItem:
+			item, ok, err := createSyncedItem(ctx, qtx, groceryList, op, changedAt)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				result.Outcome = SyncApplied
+				result.Item = item
+				published = append(published, event{EventItemCreated, item})
+			}
+
+		case op.Op == OpSetItemStatus || op.Op == OpDeleteItem:
+			if op.Op == OpSetItemStatus && op.Status != Incomplete && op.Status != Complete {
+				break
+			}
+
+			dbItem, err := qtx.GetItem(ctx, op.ItemID)
+			if errors.Is(err, sql.ErrNoRows) {
+				if op.Op == OpDeleteItem {
+					result.Outcome = SyncApplied
+				} else {
+					result.Outcome = SyncNotFound
+				}
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			item := databaseToDomainItem(dbItem)
+			if item.GroceryListID != groceryList.ID {
+				result.Outcome = SyncNotFound
+				break
+			}
+
+			if item.UpdatedAt.After(changedAt) {
+				result.Outcome = SyncConflict
+				result.Item = item
+				break
+			}
+
+			result.Outcome = SyncApplied
+
+			if op.Op == OpDeleteItem {
+				err = qtx.DeleteItem(ctx, item.ID)
+				if err != nil {
+					return nil, err
+				}
+				published = append(published, event{EventItemDeleted, item})
+				break
+			}
+
+			if item.Status != op.Status {
+				item, err = setItemStatus(ctx, qtx, groceryList, item, op.Status, op.Restock, changedAt)
+				if err != nil {
+					return nil, err
+				}
+				published = append(published, event{EventItemStatusChanged, item})
+			}
+			result.Item = item
+		}
+
+		results[i] = result
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	for _, e := range published {
+		c.publish(groceryList.ID, e.eventType, e.item)
+	}
+
+	return results, nil
+}
+
+// createSyncedItem creates the item of an OpCreateItem, dated when the client
+// created it. It reports false if the operation is invalid.
+func createSyncedItem(ctx context.Context, q *database.Queries, groceryList GroceryList, op SyncOperation, changedAt time.Time) (Item, bool, error) {
+	name := strings.TrimSpace(op.Name)
+	if name == "" || (op.Status != 0 && op.Status != Incomplete && op.Status != Complete) {
+		return Item{}, false, nil
+	}
+
+	canonicalID, err := resolveCanonicalIngredient(ctx, q, name)
+	if err != nil {
+		return Item{}, false, err
+	}
+
+	category, err := canonicalCategory(ctx, q, canonicalID)
+	if err != nil {
+		return Item{}, false, err
+	}
+
+	measure := ingparse.StandardizeMeasure(op.Amount, op.Units)
+
+	dbItem, err := q.CreateItem(ctx, database.CreateItemParams{
+		CreatedAt:             changedAt,
+		UpdatedAt:             changedAt,
+		CanonicalIngredientID: canonicalID,
+		GroceryListID:         groceryList.ID,
+		Name:                  name,
+		Description:           misc.SqlNullStringFromString(op.Description),
+		Amount:                op.Amount,
+		Units:                 op.Units,
+		StandardAmount:        measure.StandardAmount,
+		StandardUnits:         measure.StandardUnits.String(),
+		Category:              category,
+	})
+	if err != nil {
+		return Item{}, false, err
+	}
+
+	item := databaseToDomainItem(dbItem)
+	if op.Status == Complete {
+		item, err = setItemStatus(ctx, q, groceryList, item, Complete, op.Restock, changedAt)
+		if err != nil {
+			return Item{}, false, err
+		}
+	}
+
+	return item, true, nil
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/snorman7384/recipe-wizard/domerr"
+)
+
+func newSyncFixture(t *testing.T) (*Config, User, GroceryList) {
+	t.Helper()
+	ctx := context.Background()
+
+	c := newTestConfig(t)
+	user := newTestUser(t, c, "shopper")
+	groceryList := must(c.CreateGroceryList(ctx, user, "groceries"))
+
+	return c, user, groceryList
+}
+
+func TestApplyGroceryListOperationsConflicts(t *testing.T) {
+	ctx := context.Background()
+	c, user, groceryList := newSyncFixture(t)
+
+	item := must(c.CreateItem(ctx, user, groceryList, "milk", "", 1, "cup"))
+
+	tests := []struct {
+		name       string
+		op         SyncOperation
+		want       SyncOutcome
+		wantStatus ItemStatus
+	}{
+		{
+			name:       "stale",
+			op:         SyncOperation{Op: OpSetItemStatus, ItemID: item.ID, Status: Complete, ChangedAt: item.UpdatedAt.Add(-time.Hour)},
+			want:       SyncConflict,
+			wantStatus: Incomplete,
+		},
+		{
+			name:       "newer",
+			op:         SyncOperation{Op: OpSetItemStatus, ItemID: item.ID, Status: Complete, ChangedAt: time.Now()},
+			want:       SyncApplied,
+			wantStatus: Complete,
+		},
+		{
+			name:       "stale after the server changed it",
+			op:         SyncOperation{Op: OpDeleteItem, ItemID: item.ID, ChangedAt: item.UpdatedAt},
+			want:       SyncConflict,
+			wantStatus: Complete,
+		},
+		{
+			name:       "undated",
+			op:         SyncOperation{Op: OpSetItemStatus, ItemID: item.ID, Status: Incomplete},
+			want:       SyncInvalid,
+			wantStatus: Complete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.op.ClientID = tt.name
+			results := must(c.ApplyGroceryListOperations(ctx, user, groceryList, []SyncOperation{tt.op}))
+
+			if results[0].ClientID != tt.name || results[0].Outcome != tt.want {
+				t.Errorf("result = %q %v, want %q %v", results[0].ClientID, results[0].Outcome, tt.name, tt.want)
+			}
+			if tt.want == SyncConflict && results[0].Item.ID != item.ID {
+				t.Errorf("conflict carries item %d, want the server's item %d", results[0].Item.ID, item.ID)
+			}
+
+			got := must(c.GetItem(ctx, user, item.ID))
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestApplyGroceryListOperationsClampsFutureChanges(t *testing.T) {
+	ctx := context.Background()
+	c, user, groceryList := newSyncFixture(t)
+
+	results := must(c.ApplyGroceryListOperations(ctx, user, groceryList, []SyncOperation{
+		{Op: OpCreateItem, ClientID: "eggs", Name: "eggs", Amount: 12, ChangedAt: time.Now().Add(24 * time.Hour)},
+	}))
+	if results[0].Outcome != SyncApplied {
+		t.Fatalf("create = %v, want applied", results[0].Outcome)
+	}
+
+	item := must(c.GetItem(ctx, user, results[0].Item.ID))
+	if now := time.Now(); item.UpdatedAt.After(now) {
+		t.Errorf("item updated at %v, after now %v", item.UpdatedAt, now)
+	}
+
+	// a client whose clock runs fast cannot lock out changes made since
+	results = must(c.ApplyGroceryListOperations(ctx, user, groceryList, []SyncOperation{
+		{Op: OpSetItemStatus, ClientID: "check", ItemID: item.ID, Status: Complete, ChangedAt: time.Now()},
+	}))
+	if results[0].Outcome != SyncApplied {
+		t.Errorf("check = %v, want applied", results[0].Outcome)
+	}
+}
+
+func TestApplyGroceryListOperationsDeletes(t *testing.T) {
+	ctx := context.Background()
+	c, user, groceryList := newSyncFixture(t)
+
+	other := must(c.CreateGroceryList(ctx, user, "other"))
+	otherItem := must(c.CreateItem(ctx, user, other, "bread", "", 1, ""))
+	item := must(c.CreateItem(ctx, user, groceryList, "milk", "", 1, "cup"))
+	if err := c.DeleteItem(ctx, user, item); err != nil {
+		t.Fatal(err)
+	}
+
+	results := must(c.ApplyGroceryListOperations(ctx, user, groceryList, []SyncOperation{
+		{Op: OpDeleteItem, ClientID: "deleted", ItemID: item.ID, ChangedAt: time.Now()},
+		{Op: OpDeleteItem, ClientID: "never", ItemID: otherItem.ID + 100, ChangedAt: time.Now()},
+		{Op: OpDeleteItem, ClientID: "other list", ItemID: otherItem.ID, ChangedAt: time.Now()},
+		{Op: OpSetItemStatus, ClientID: "check deleted", ItemID: item.ID, Status: Complete, ChangedAt: time.Now()},
+	}))
+
+	want := []SyncOutcome{SyncApplied, SyncApplied, SyncNotFound, SyncNotFound}
+	for i, result := range results {
+		if result.Outcome != want[i] {
+			t.Errorf("%s = %v, want %v", result.ClientID, result.Outcome, want[i])
+		}
+	}
+
+	if _, err := c.GetItem(ctx, user, otherItem.ID); err != nil {
+		t.Errorf("item on the other list: %v", err)
+	}
+}
+
+func TestApplyGroceryListOperationsLimit(t *testing.T) {
+	ctx := context.Background()
+	c, user, groceryList := newSyncFixture(t)
+
+	ops := make([]SyncOperation, MaxSyncOperations+1)
+	for i := range ops {
+		ops[i] = SyncOperation{Op: OpDeleteItem, ItemID: int64(i + 1), ChangedAt: time.Now()}
+	}
+
+	_, err := c.ApplyGroceryListOperations(ctx, user, groceryList, ops)
+	if !errors.Is(err, domerr.ErrTooManySyncOperations) {
+		t.Errorf("%d operations: err = %v, want %v", len(ops), err, domerr.ErrTooManySyncOperations)
+	}
+
+	results, err := c.ApplyGroceryListOperations(ctx, user, groceryList, ops[:MaxSyncOperations])
+	if err != nil || len(results) != MaxSyncOperations {
+		t.Errorf("%d operations: %d results, err = %v", MaxSyncOperations, len(results), err)
+	}
+}
+
+func TestGetGroceryListChangesSyncToken(t *testing.T) {
+	ctx := context.Background()
+	c, user, groceryList := newSyncFixture(t)
+
+	other := must(c.CreateGroceryList(ctx, user, "other"))
+	token := must(c.GetGroceryListChanges(ctx, groceryList, "")).SyncToken
+
+	item := must(c.CreateItem(ctx, user, groceryList, "milk", "", 1, "cup"))
+	changes := must(c.GetGroceryListChanges(ctx, groceryList, token))
+	if changes.Full || len(changes.Items) != 1 || changes.Items[0].ID != item.ID {
+		t.Errorf("changes = %+v, want only item %d", changes, item.ID)
+	}
+
+	for _, since := range []string{token, "not a token"} {
+		_, err := c.GetGroceryListChanges(ctx, other, since)
+		if !errors.Is(err, domerr.ErrInvalidSyncToken) {
+			t.Errorf("GetGroceryListChanges(other, %q): err = %v, want %v", since, err, domerr.ErrInvalidSyncToken)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/snorman7384/recipe-wizard/domerr"
@@ -19,6 +21,8 @@ func databaseToDomainUser(user database.User) User {
 		HashedPassword: user.HashedPassword,
 		FirstName:      user.FirstName.String,
 		LastName:       user.LastName.String,
+		Email:          user.Email.String,
+		TokenVersion:   user.TokenVersion,
 	}
 }
 
@@ -27,6 +31,7 @@ type CreateUserParams struct {
 	HashedPassword string
 	FirstName      string
 	LastName       string
+	Email          string
 }
 
 func (c *Config) CreateUser(ctx context.Context, params CreateUserParams) (User, error) {
@@ -35,6 +40,15 @@ func (c *Config) CreateUser(ctx context.Context, params CreateUserParams) (User,
 	firstName := sql.NullString{String: params.FirstName, Valid: params.FirstName != ""}
 	lastName := sql.NullString{String: params.LastName, Valid: params.LastName != ""}
 
+	email := sql.NullString{}
+	if params.Email != "" {
+		address, err := normalizeEmail(params.Email)
+		if err != nil {
+			return User{}, err
+		}
+		email = sql.NullString{String: address, Valid: true}
+	}
+
 	user, err := c.Querier().CreateUser(ctx, database.CreateUserParams{
 		CreatedAt:      now,
 		UpdatedAt:      now,
@@ -42,6 +56,7 @@ func (c *Config) CreateUser(ctx context.Context, params CreateUserParams) (User,
 		HashedPassword: params.HashedPassword,
 		FirstName:      firstName,
 		LastName:       lastName,
+		Email:          email,
 	})
 
 	if err != nil {
@@ -74,3 +89,14 @@ func (c *Config) GetUserByUsername(ctx context.Context, username string) (User,
 
 	return databaseToDomainUser(user), nil
 }
+
+// normalizeEmail checks that email is a bare address and lower cases it, so
+// that lookups by email are case insensitive.
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", domerr.ErrInvalidEmail
+	}
+	return strings.ToLower(address.Address), nil
+}
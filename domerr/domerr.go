@@ -11,6 +11,11 @@ const (
 	RecipeScraperFailure
 	Forbidden
 	DecodeJsonFailure
+	InvalidInput
+	Conflict
+	UpstreamFailure
+	UpstreamTimeout
+	Unauthorized
 )
 
 type DomainError struct {
@@ -47,4 +52,35 @@ var ErrNotFound *DomainError = newDomainError(NotFound, "not_found", "the reques
 var ErrUserNotFound *DomainError = newDomainError(UserNotFound, "user_not_found", "the user with the given id does not exist")
 var ErrInternal *DomainError = newDomainError(Internal, "internal_error", "something went wrong")
 var ErrRecipeScraperFailure *DomainError = newDomainError(RecipeScraperFailure, "recipe_scraper_failure", "the recipe scraper could not parse the given url")
+var ErrRecipeDocumentFailure *DomainError = newDomainError(RecipeScraperFailure, "recipe_document_failure", "no recipe could be found in the given document")
 var ErrForbidden *DomainError = newDomainError(Forbidden, "forbidden_access", "you do not have authorization to access that resource")
+var ErrInvalidInput *DomainError = newDomainError(InvalidInput, "invalid_input", "the request contained missing or invalid fields")
+var ErrRecipeInUse *DomainError = newDomainError(Conflict, "recipe_in_use", "the recipe is still used by meals in a grocery list or the meal plan")
+var ErrUnknownServings *DomainError = newDomainError(InvalidInput, "unknown_servings", "the recipe does not say how many servings it makes")
+var ErrUrlSchemeNotAllowed *DomainError = newDomainError(InvalidInput, "url_scheme_not_allowed", "only http and https urls can be imported")
+var ErrUrlAddressForbidden *DomainError = newDomainError(InvalidInput, "url_address_forbidden", "the url points to an address that may not be fetched")
+var ErrTooManyRedirects *DomainError = newDomainError(UpstreamFailure, "too_many_redirects", "the url redirected too many times")
+var ErrRecipePageTooLarge *DomainError = newDomainError(UpstreamFailure, "recipe_page_too_large", "the page at the url is too large to import")
+var ErrRecipePageUnavailable *DomainError = newDomainError(UpstreamFailure, "recipe_page_unavailable", "the page at the url could not be retrieved")
+var ErrRecipeFetchTimeout *DomainError = newDomainError(UpstreamTimeout, "recipe_fetch_timeout", "the page at the url took too long to respond")
+var ErrInvalidCursor *DomainError = newDomainError(InvalidInput, "invalid_cursor", "the cursor is malformed or was made for a different sort order")
+var ErrInvalidPageLimit *DomainError = newDomainError(InvalidInput, "invalid_page_limit", "the page limit must be between 1 and 200")
+var ErrInvalidSort *DomainError = newDomainError(InvalidInput, "invalid_sort", "the sort order must be one of created_at, -created_at, name or -name, or for recipes total_time or -total_time")
+var ErrInvalidRefreshToken *DomainError = newDomainError(Unauthorized, "invalid_refresh_token", "the refresh token is unknown, expired or revoked")
+var ErrInvalidEmail *DomainError = newDomainError(InvalidInput, "invalid_email", "the email address is not valid")
+var ErrInvalidResetToken *DomainError = newDomainError(InvalidInput, "invalid_reset_token", "the password reset token is unknown, expired or already used")
+var ErrInvalidApiKey *DomainError = newDomainError(Unauthorized, "invalid_api_key", "the api key is unknown or has been revoked")
+var ErrApiKeyReadOnly *DomainError = newDomainError(Forbidden, "api_key_read_only", "the api key only allows reading")
+var ErrApiKeyNotAllowed *DomainError = newDomainError(Forbidden, "api_key_not_allowed", "this action requires logging in with a password")
+var ErrLastHouseholdOwner *DomainError = newDomainError(Conflict, "last_household_owner", "a household must keep at least one owner")
+var ErrAlreadyHouseholdMember *DomainError = newDomainError(Conflict, "already_household_member", "the user is already a member of the household")
+var ErrInvalidSyncToken *DomainError = newDomainError(InvalidInput, "invalid_sync_token", "the sync token is malformed or was made for a different grocery list")
+var ErrTooManySyncOperations *DomainError = newDomainError(InvalidInput, "too_many_sync_operations", "at most 500 operations can be applied at once")
+var ErrAlreadyInPantry *DomainError = newDomainError(Conflict, "already_in_pantry", "the pantry already holds that ingredient")
+var ErrUnknownIngredient *DomainError = newDomainError(InvalidInput, "unknown_ingredient", "the ingredient catalog does not know that ingredient")
+var ErrInvalidCalendarToken *DomainError = newDomainError(Unauthorized, "invalid_calendar_token", "the calendar feed token is unknown or has been revoked")
+var ErrInvalidDuration *DomainError = newDomainError(InvalidInput, "invalid_duration", "recipe times must be ISO 8601 durations such as PT1H30M, of at most 30 days")
+var ErrInvalidTag *DomainError = newDomainError(InvalidInput, "invalid_tag", "tags must be between 1 and 50 characters")
+var ErrUnsupportedImage *DomainError = newDomainError(InvalidInput, "unsupported_image", "images must be JPEG, PNG, GIF or WebP")
+var ErrImageTooLarge *DomainError = newDomainError(InvalidInput, "image_too_large", "images must be at most 10 MiB")
+var ErrImagesUnavailable *DomainError = newDomainError(Internal, "images_unavailable", "no blob store is configured for recipe images")
@@ -0,0 +1,113 @@
+package events
+
+import (
+	"sync"
+)
+
+const defaultBufferSize = 64
+
+// Event is something that happened to a grocery list. Data holds the domain
+// value the event is about, for example the item that was created.
+type Event struct {
+	Type          string
+	GroceryListID int64
+	Data          any
+}
+
+// Broker is an in-process publish/subscribe broker. Subscribers receive every
+// event published for the grocery list they subscribed to, from the moment
+// they subscribed.
+//
+// Publishing never blocks. A subscriber that falls too far behind is dropped:
+// its channel is closed and Dropped reports true, so that it can start over
+// from a fresh copy of the list.
+type Broker struct {
+	BufferSize int // events held for each subscriber; defaults to 64
+
+	mu   sync.Mutex
+	subs map[int64]map[*Subscription]struct{}
+}
+
+type Subscription struct {
+	broker        *Broker
+	groceryListID int64
+	ch            chan Event
+	closed        bool // guarded by broker.mu
+	dropped       bool // guarded by broker.mu
+}
+
+// Subscribe starts receiving the events of a grocery list. The subscription
+// must be closed when it is no longer needed.
+func (b *Broker) Subscribe(groceryListID int64) *Subscription {
+	size := b.BufferSize
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+
+	sub := &Subscription{
+		broker:        b,
+		groceryListID: groceryListID,
+		ch:            make(chan Event, size),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subs == nil {
+		b.subs = make(map[int64]map[*Subscription]struct{})
+	}
+	if b.subs[groceryListID] == nil {
+		b.subs[groceryListID] = make(map[*Subscription]struct{})
+	}
+	b.subs[groceryListID][sub] = struct{}{}
+
+	return sub
+}
+
+// Publish sends an event to every subscriber of its grocery list.
+func (b *Broker) Publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs[e.GroceryListID] {
+		select {
+		case sub.ch <- e:
+		default:
+			sub.dropped = true
+			b.remove(sub)
+		}
+	}
+}
+
+// remove must be called with b.mu held.
+func (b *Broker) remove(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.ch)
+
+	delete(b.subs[sub.groceryListID], sub)
+	if len(b.subs[sub.groceryListID]) == 0 {
+		delete(b.subs, sub.groceryListID)
+	}
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription is closed or dropped.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Dropped reports whether the subscription was closed because it fell behind.
+func (s *Subscription) Dropped() bool {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.dropped
+}
+
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
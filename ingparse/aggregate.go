@@ -0,0 +1,212 @@
+package ingparse
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Quantity is an amount in a unit fit to show to people, like 2.75 cups.
+type Quantity struct {
+	Amount float64 // not rounded; String rounds it
+	Units  string  // a conversions key such as "cup", an unknown unit as written, or "" for whole things
+}
+
+// metricUnits are the conversions keys of metric units. Totals of measures
+// that were all metric are shown in metric units.
+var metricUnits = map[string]bool{
+	"milliliter": true,
+	"centiliter": true,
+	"deciliter":  true,
+	"liter":      true,
+	"milligram":  true,
+	"gram":       true,
+	"kilogram":   true,
+}
+
+// Aggregate adds up measures of the same ingredient. Volumes are added
+// together, as are weights and counts of the same kind of thing, like cloves
+// or cans. Each sum is then put in a unit that suits its size, so 22 fluid
+// ounces become 2.75 cups.
+//
+// Sums that cannot be combined are returned side by side: volume first, then
+// weight, then each kind of count in the order it first appears. Sums of zero
+// are left out.
+func Aggregate(measures []Measure) []Quantity {
+	var volume, weight float64
+	volumeMetric, weightMetric := true, true
+
+	var countUnits []string
+	counts := make(map[string]float64)
+
+	for _, m := range measures {
+		name, known := normalizeUnit(m.OriginalUnits)
+
+		switch {
+		case known && m.StandardUnits == FluidOunce:
+			volume += m.StandardAmount
+			volumeMetric = volumeMetric && metricUnits[name]
+		case known && m.StandardUnits == Ounce:
+			weight += m.StandardAmount
+			weightMetric = weightMetric && metricUnits[name]
+		default:
+			if !known {
+				name = strings.ToLower(strings.TrimSpace(m.OriginalUnits))
+			}
+			if name == "whole" {
+				name = ""
+			}
+			if _, ok := counts[name]; !ok {
+				countUnits = append(countUnits, name)
+			}
+			counts[name] += m.OriginalAmount
+		}
+	}
+
+	quantities := make([]Quantity, 0)
+	if volume > 0 {
+		quantities = append(quantities, volumeQuantity(volume, volumeMetric))
+	}
+	if weight > 0 {
+		quantities = append(quantities, weightQuantity(weight, weightMetric))
+	}
+	for _, units := range countUnits {
+		if counts[units] > 0 {
+			quantities = append(quantities, Quantity{Amount: counts[units], Units: units})
+		}
+	}
+
+	return quantities
+}
+
+// FormatQuantities joins quantities for display, e.g. "2 ¾ cups + 3 cloves".
+func FormatQuantities(quantities []Quantity) string {
+	parts := make([]string, len(quantities))
+	for i, q := range quantities {
+		parts[i] = q.String()
+	}
+	return strings.Join(parts, " + ")
+}
+
+func volumeQuantity(fluidOunces float64, metric bool) Quantity {
+	if metric {
+		milliliters := fluidOunces / conversions["milliliter"].factor
+		if milliliters >= 1000 {
+			return Quantity{Amount: milliliters / 1000, Units: "liter"}
+		}
+		return Quantity{Amount: milliliters, Units: "milliliter"}
+	}
+
+	switch {
+	case fluidOunces < conversions["tablespoon"].factor:
+		return Quantity{Amount: fluidOunces / conversions["teaspoon"].factor, Units: "teaspoon"}
+	case fluidOunces < conversions["cup"].factor/4:
+		return Quantity{Amount: fluidOunces / conversions["tablespoon"].factor, Units: "tablespoon"}
+	case fluidOunces < conversions["gallon"].factor:
+		return Quantity{Amount: fluidOunces / conversions["cup"].factor, Units: "cup"}
+	}
+	return Quantity{Amount: fluidOunces / conversions["gallon"].factor, Units: "gallon"}
+}
+
+func weightQuantity(ounces float64, metric bool) Quantity {
+	if metric {
+		grams := ounces / conversions["gram"].factor
+		if grams >= 1000 {
+			return Quantity{Amount: grams / 1000, Units: "kilogram"}
+		}
+		return Quantity{Amount: grams, Units: "gram"}
+	}
+
+	if ounces < conversions["pound"].factor {
+		return Quantity{Amount: ounces, Units: "ounce"}
+	}
+	return Quantity{Amount: ounces / conversions["pound"].factor, Units: "pound"}
+}
+
+// String formats the quantity the way a recipe would. Customary units are
+// rounded to the nearest common fraction, as in "1 ⅓ cups", and metric units
+// to a whole number, or to one decimal place above 1000 of the smaller unit,
+// as in "1.5 liters".
+func (q Quantity) String() string {
+	var amount string
+	switch q.Units {
+	case "milliliter", "gram":
+		amount = strconv.FormatFloat(math.Max(math.Round(q.Amount), 1), 'f', -1, 64)
+	case "liter", "kilogram":
+		amount = strconv.FormatFloat(math.Round(q.Amount*10)/10, 'f', -1, 64)
+	default:
+		amount = formatFraction(q.Amount)
+	}
+
+	if q.Units == "" {
+		return amount
+	}
+
+	// a lone fraction is less than one, so "½ cup" but "1 ½ cups"
+	plural := amount != "1" && strings.ContainsAny(amount, "0123456789")
+
+	units := q.Units
+	if _, known := conversions[units]; known && plural {
+		units = pluralizeUnit(units)
+	}
+
+	return amount + " " + units
+}
+
+// fractions are the fractions measuring cups and spoons come in.
+var fractions = []struct {
+	value  float64
+	symbol string
+}{
+	{0, ""},
+	{1.0 / 8, "⅛"},
+	{1.0 / 4, "¼"},
+	{1.0 / 3, "⅓"},
+	{3.0 / 8, "⅜"},
+	{1.0 / 2, "½"},
+	{5.0 / 8, "⅝"},
+	{2.0 / 3, "⅔"},
+	{3.0 / 4, "¾"},
+	{7.0 / 8, "⅞"},
+	{1, ""},
+}
+
+// formatFraction rounds an amount to the nearest of fractions, never down to
+// nothing, and writes it as a whole number and a fraction.
+func formatFraction(amount float64) string {
+	whole := math.Floor(amount)
+	rest := amount - whole
+
+	nearest := 0
+	for i, f := range fractions {
+		if math.Abs(rest-f.value) < math.Abs(rest-fractions[nearest].value) {
+			nearest = i
+		}
+	}
+
+	if fractions[nearest].value == 1 {
+		whole++
+		nearest = 0
+	}
+	if whole == 0 && nearest == 0 {
+		nearest = 1
+	}
+
+	symbol := fractions[nearest].symbol
+	switch {
+	case whole == 0:
+		return symbol
+	case symbol == "":
+		return strconv.FormatFloat(whole, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(whole, 'f', 0, 64) + " " + symbol
+}
+
+func pluralizeUnit(units string) string {
+	for _, suffix := range []string{"ch", "sh", "x"} {
+		if strings.HasSuffix(units, suffix) {
+			return units + "es"
+		}
+	}
+	return units + "s"
+}
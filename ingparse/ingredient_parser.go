@@ -94,7 +94,8 @@ func (p SchollzParser) ParseIngredients(lines []string) ([]Ingredient, error) {
 }
 
 func (p SchollzParser) ParseIngredientLine(line string) (Ingredient, error) {
-	ings, err := ingredients.ParseTextIngredients(line)
+	// schollz ignores the final line of text unless it is newline terminated
+	ings, err := ingredients.ParseTextIngredients(line + "\n")
 	if err != nil {
 		return Ingredient{}, err
 	}
@@ -118,11 +119,22 @@ func (p SchollzParser) convertIngredient(ing ingredients.Ingredient) Ingredient
 }
 
 func (p SchollzParser) convertMeasure(ing ingredients.Ingredient) Measure {
-	m := Measure{
-		OriginalAmount: ing.Measure.Amount,
-		OriginalUnits:  ing.Measure.Name,
-		StandardAmount: -1,   // TODO not implemented yet
-		StandardUnits:  Each, // TODO not implemented yet
+	units := ing.Measure.Name
+
+	lineUnits, lineOk := unitFromLine(ing.Line)
+
+	// schollz only knows a handful of units and reports everything else (kg, l, pinch...) as "whole"
+	if (units == "whole" || units == "") && lineOk {
+		units = lineUnits
 	}
-	return m
+
+	// schollz lowercases the line before finding the unit, which turns the T of
+	// tablespoon into the t of teaspoon, and only reads the last word of units
+	// such as "fl oz", so the unit is taken as written
+	lastWord := lineUnits[strings.LastIndex(lineUnits, " ")+1:]
+	if lineOk && strings.EqualFold(strings.TrimRight(lastWord, "."), strings.TrimRight(units, ".")) {
+		units = lineUnits
+	}
+
+	return StandardizeMeasure(ing.Measure.Amount, units)
 }
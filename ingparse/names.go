@@ -0,0 +1,106 @@
+package ingparse
+
+import (
+	"strings"
+	"unicode"
+)
+
+// preparationWords describe how an ingredient is cut, sized or prepared rather
+// than what it is, so they are left out of normalized names.
+var preparationWords = map[string]bool{
+	"chopped":     true,
+	"coarsely":    true,
+	"crushed":     true,
+	"cubed":       true,
+	"diced":       true,
+	"finely":      true,
+	"fresh":       true,
+	"freshly":     true,
+	"grated":      true,
+	"halved":      true,
+	"julienned":   true,
+	"large":       true,
+	"medium":      true,
+	"melted":      true,
+	"minced":      true,
+	"peeled":      true,
+	"room":        true,
+	"roughly":     true,
+	"shredded":    true,
+	"sliced":      true,
+	"small":       true,
+	"softened":    true,
+	"temperature": true,
+	"thinly":      true,
+	"trimmed":     true,
+}
+
+// singulars are plurals that the rules in singularize get wrong.
+var singulars = map[string]string{
+	"leaves":    "leaf",
+	"halves":    "half",
+	"loaves":    "loaf",
+	"cookies":   "cookie",
+	"brownies":  "brownie",
+	"pies":      "pie",
+	"smoothies": "smoothie",
+	"anchovies": "anchovy",
+	"chives":    "chive",
+}
+
+// NormalizeName reduces an ingredient name to the form it is looked up by in
+// the ingredient catalog: lowercase and singular, without the comments after a
+// comma or in parentheses, and without words that describe how it is
+// prepared. "Onions, diced" and "2 large onions" both become "onion".
+func NormalizeName(name string) string {
+	name = strings.ToLower(name)
+	if i := strings.IndexAny(name, ",("); i >= 0 {
+		name = name[:i]
+	}
+
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '\''
+	})
+
+	kept := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.Trim(word, "-'")
+		if word == "" || preparationWords[word] || isAmountWord(word) {
+			continue
+		}
+		kept = append(kept, word)
+	}
+
+	if len(kept) == 0 {
+		return ""
+	}
+
+	kept[len(kept)-1] = singularize(kept[len(kept)-1])
+
+	return strings.Join(kept, " ")
+}
+
+// singularize returns the singular of an English noun, as far as simple rules
+// allow. Words that look singular already are returned as they are.
+func singularize(word string) string {
+	if singular, ok := singulars[word]; ok {
+		return singular
+	}
+
+	switch {
+	case len(word) <= 3:
+		return word
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"):
+		return word
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "sses"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	}
+
+	return word
+}
@@ -0,0 +1,241 @@
+package ingparse
+
+import (
+	"strings"
+	"unicode"
+)
+
+type unitConversion struct {
+	units  StandardUnit
+	factor float64 // number of standard units in one of the original unit
+}
+
+// conversions maps normalized unit names onto their standard unit. Volumes are
+// converted to US fluid ounces and weights to avoirdupois ounces. Units that
+// count things rather than measure them are kept as Each with a factor of 1.
+var conversions = map[string]unitConversion{
+	// volume
+	"drop":        {FluidOunce, 1.0 / 576},
+	"smidgen":     {FluidOunce, 1.0 / 192},
+	"pinch":       {FluidOunce, 1.0 / 96},
+	"dash":        {FluidOunce, 1.0 / 48},
+	"teaspoon":    {FluidOunce, 1.0 / 6},
+	"tablespoon":  {FluidOunce, 1.0 / 2},
+	"fluid ounce": {FluidOunce, 1},
+	"cup":         {FluidOunce, 8},
+	"pint":        {FluidOunce, 16},
+	"quart":       {FluidOunce, 32},
+	"gallon":      {FluidOunce, 128},
+	"milliliter":  {FluidOunce, 0.033814},
+	"centiliter":  {FluidOunce, 0.33814},
+	"deciliter":   {FluidOunce, 3.3814},
+	"liter":       {FluidOunce, 33.814},
+
+	// weight
+	"ounce":     {Ounce, 1},
+	"pound":     {Ounce, 16},
+	"milligram": {Ounce, 0.000035274},
+	"gram":      {Ounce, 0.035274},
+	"kilogram":  {Ounce, 35.274},
+
+	// count
+	"whole":   {Each, 1},
+	"clove":   {Each, 1},
+	"can":     {Each, 1},
+	"jar":     {Each, 1},
+	"bottle":  {Each, 1},
+	"package": {Each, 1},
+	"bag":     {Each, 1},
+	"box":     {Each, 1},
+	"bunch":   {Each, 1},
+	"head":    {Each, 1},
+	"stalk":   {Each, 1},
+	"sprig":   {Each, 1},
+	"slice":   {Each, 1},
+	"stick":   {Each, 1},
+	"piece":   {Each, 1},
+}
+
+// unitAliases maps the spellings found in ingredient lines onto the keys of
+// conversions. Plurals and trailing periods are stripped before lookup, so
+// only the singular form of each alias needs to be listed.
+var unitAliases = map[string]string{
+	"drop":    "drop",
+	"smidgen": "smidgen",
+	"pinch":   "pinch",
+	"dash":    "dash",
+
+	"t":        "teaspoon",
+	"tsp":      "teaspoon",
+	"teaspoon": "teaspoon",
+
+	"tb":         "tablespoon",
+	"tbl":        "tablespoon",
+	"tbs":        "tablespoon",
+	"tbsp":       "tablespoon",
+	"tblsp":      "tablespoon",
+	"tablespoon": "tablespoon",
+
+	"fl oz":       "fluid ounce",
+	"fl. oz":      "fluid ounce",
+	"floz":        "fluid ounce",
+	"fluid ounce": "fluid ounce",
+	"fluid oz":    "fluid ounce",
+
+	"c":   "cup",
+	"cup": "cup",
+
+	"pt":   "pint",
+	"pint": "pint",
+
+	"qt":    "quart",
+	"quart": "quart",
+
+	"gal":    "gallon",
+	"gallon": "gallon",
+
+	"ml":         "milliliter",
+	"milliliter": "milliliter",
+	"millilitre": "milliliter",
+	"cl":         "centiliter",
+	"centiliter": "centiliter",
+	"centilitre": "centiliter",
+	"dl":         "deciliter",
+	"deciliter":  "deciliter",
+	"decilitre":  "deciliter",
+	"l":          "liter",
+	"liter":      "liter",
+	"litre":      "liter",
+
+	"oz":    "ounce",
+	"ounce": "ounce",
+
+	"lb":    "pound",
+	"pound": "pound",
+
+	"mg":          "milligram",
+	"milligram":   "milligram",
+	"milligramme": "milligram",
+	"g":           "gram",
+	"gr":          "gram",
+	"gram":        "gram",
+	"gramme":      "gram",
+	"kg":          "kilogram",
+	"kilo":        "kilogram",
+	"kilogram":    "kilogram",
+	"kilogramme":  "kilogram",
+
+	"whole":   "whole",
+	"clove":   "clove",
+	"can":     "can",
+	"canned":  "can",
+	"tin":     "can",
+	"jar":     "jar",
+	"bottle":  "bottle",
+	"package": "package",
+	"pkg":     "package",
+	"packet":  "package",
+	"bag":     "bag",
+	"box":     "box",
+	"bunch":   "bunch",
+	"head":    "head",
+	"stalk":   "stalk",
+	"sprig":   "sprig",
+	"slice":   "slice",
+	"stick":   "stick",
+	"piece":   "piece",
+	"pc":      "piece",
+}
+
+// normalizeUnit returns the conversions key for the given unit string, and
+// whether the unit is known.
+func normalizeUnit(units string) (string, bool) {
+	u := strings.TrimRight(strings.TrimSpace(units), ".")
+
+	// a capital T is the usual shorthand for tablespoon, a lowercase t for teaspoon
+	if u == "T" {
+		return "tablespoon", true
+	}
+	u = strings.ToLower(u)
+
+	if name, ok := unitAliases[u]; ok {
+		return name, true
+	}
+
+	// plurals: "cups", "pinches", "lbs"
+	for _, suffix := range []string{"es", "s"} {
+		if singular, ok := strings.CutSuffix(u, suffix); ok {
+			if name, ok := unitAliases[singular]; ok {
+				return name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// IsKnownUnit reports whether the given unit string can be converted into a
+// StandardUnit.
+func IsKnownUnit(units string) bool {
+	_, ok := normalizeUnit(units)
+	return ok
+}
+
+// StandardizeMeasure converts an amount in the given units into its standard
+// amount and units. Unknown units are treated as a count of whole things.
+func StandardizeMeasure(amount float64, units string) Measure {
+	m := Measure{
+		OriginalAmount: amount,
+		OriginalUnits:  units,
+		StandardAmount: amount,
+		StandardUnits:  Each,
+	}
+
+	name, ok := normalizeUnit(units)
+	if !ok {
+		return m
+	}
+
+	conversion := conversions[name]
+	m.StandardAmount = amount * conversion.factor
+	m.StandardUnits = conversion.units
+
+	return m
+}
+
+// unitFromLine finds the unit that follows the leading amount of an
+// ingredient line, e.g. "kg" in "1 1/2 kg potatoes". It is used for units
+// that the schollz parser does not recognize and reports as "whole", and for
+// units it only reads part of, such as the "oz" of "fl oz". Two-word units
+// are tried before single words.
+func unitFromLine(line string) (string, bool) {
+	words := strings.FieldsFunc(line, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '(' || r == ')' || r == ','
+	})
+
+	for i, word := range words {
+		if isAmountWord(word) {
+			continue
+		}
+		if i+1 < len(words) {
+			if pair := word + " " + words[i+1]; IsKnownUnit(pair) {
+				return strings.TrimRight(pair, "."), true
+			}
+		}
+		if IsKnownUnit(word) {
+			return strings.TrimRight(word, "."), true
+		}
+		return "", false
+	}
+
+	return "", false
+}
+
+func isAmountWord(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) && !unicode.Is(unicode.No, r) && !strings.ContainsRune("/.-", r) {
+			return false
+		}
+	}
+	return true
+}
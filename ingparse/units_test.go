@@ -0,0 +1,116 @@
+package ingparse
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStandardizeMeasure(t *testing.T) {
+	tests := []struct {
+		units      string
+		wantAmount float64
+		wantUnits  StandardUnit
+	}{
+		{"T", 0.5, FluidOunce},
+		{"T.", 0.5, FluidOunce},
+		{"t", 1.0 / 6, FluidOunce},
+		{"tsp", 1.0 / 6, FluidOunce},
+		{"tsps", 1.0 / 6, FluidOunce},
+		{"teaspoon", 1.0 / 6, FluidOunce},
+		{"tbsp", 0.5, FluidOunce},
+		{"Tbsp.", 0.5, FluidOunce},
+		{"tablespoons", 0.5, FluidOunce},
+		{"fl oz", 1, FluidOunce},
+		{"fl. oz", 1, FluidOunce},
+		{"fl. oz.", 1, FluidOunce},
+		{"floz", 1, FluidOunce},
+		{"fluid ounce", 1, FluidOunce},
+		{"fluid ounces", 1, FluidOunce},
+		{"fluid oz", 1, FluidOunce},
+		{"cups", 8, FluidOunce},
+		{"pinch", 1.0 / 96, FluidOunce},
+		{"pinches", 1.0 / 96, FluidOunce},
+		{"ml", 0.033814, FluidOunce},
+		{"l", 33.814, FluidOunce},
+		{"litres", 33.814, FluidOunce},
+		{"oz", 1, Ounce},
+		{"ounces", 1, Ounce},
+		{"lb", 16, Ounce},
+		{"lbs", 16, Ounce},
+		{"g", 0.035274, Ounce},
+		{"kg", 35.274, Ounce},
+		{"kilos", 35.274, Ounce},
+		{"cloves", 1, Each},
+		{"whole", 1, Each},
+		{"handful", 1, Each},
+		{"", 1, Each},
+	}
+
+	for _, tt := range tests {
+		m := StandardizeMeasure(1, tt.units)
+		if m.StandardUnits != tt.wantUnits || math.Abs(m.StandardAmount-tt.wantAmount) > 1e-9 {
+			t.Errorf("StandardizeMeasure(1, %q) = %v %v, want %v %v", tt.units, m.StandardAmount, m.StandardUnits, tt.wantAmount, tt.wantUnits)
+		}
+	}
+}
+
+func TestUnitFromLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   string
+		wantOk bool
+	}{
+		{"1 1/2 kg potatoes", "kg", true},
+		{"2 T sugar", "T", true},
+		{"1 fl oz rum", "fl oz", true},
+		{"2 fl. oz. rum", "fl. oz", true},
+		{"3 fluid ounces rum", "fluid ounces", true},
+		{"1 fluid oz rum", "fluid oz", true},
+		{"2 oz cheese", "oz", true},
+		{"1 (14 oz) can tomatoes", "oz", true},
+		{"3 eggs", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := unitFromLine(tt.line)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("unitFromLine(%q) = %q, %v, want %q, %v", tt.line, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestParseIngredientLineUnits(t *testing.T) {
+	tests := []struct {
+		line       string
+		wantAmount float64
+		wantUnits  StandardUnit
+	}{
+		{"2 T sugar", 1, FluidOunce},
+		{"2 Tbsp sugar", 1, FluidOunce},
+		{"3 t salt", 0.5, FluidOunce},
+		{"3 tsp salt", 0.5, FluidOunce},
+		{"1 fl oz rum", 1, FluidOunce},
+		{"2 fl. oz. rum", 2, FluidOunce},
+		{"3 fluid ounces rum", 3, FluidOunce},
+		{"1 fluid oz rum", 1, FluidOunce},
+		{"2 oz cheese", 2, Ounce},
+		{"1 kg flour", 35.274, Ounce},
+		{"2 lbs beef", 32, Ounce},
+		{"1 l milk", 33.814, FluidOunce},
+		{"1 pinch salt", 1.0 / 96, FluidOunce},
+		{"1 cup milk", 8, FluidOunce},
+	}
+
+	p := SchollzParser{}
+	for _, tt := range tests {
+		ing, err := p.ParseIngredientLine(tt.line)
+		if err != nil {
+			t.Errorf("ParseIngredientLine(%q): %v", tt.line, err)
+			continue
+		}
+		m := ing.Measure
+		if m.StandardUnits != tt.wantUnits || math.Abs(m.StandardAmount-tt.wantAmount) > 1e-9 {
+			t.Errorf("ParseIngredientLine(%q) = %v %v, want %v %v", tt.line, m.StandardAmount, m.StandardUnits, tt.wantAmount, tt.wantUnits)
+		}
+	}
+}
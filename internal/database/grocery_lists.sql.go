@@ -7,12 +7,13 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
 const createGroceryList = `-- name: CreateGroceryList :one
 INSERT INTO grocery_lists (created_at, updated_at, name, owner_id)
-VALUES (?, ?, ?, ?) RETURNING id, created_at, updated_at, name, owner_id
+VALUES (?, ?, ?, ?) RETURNING id, created_at, updated_at, name, owner_id, household_id
 `
 
 type CreateGroceryListParams struct {
@@ -36,12 +37,23 @@ func (q *Queries) CreateGroceryList(ctx context.Context, arg CreateGroceryListPa
 		&i.UpdatedAt,
 		&i.Name,
 		&i.OwnerID,
+		&i.HouseholdID,
 	)
 	return i, err
 }
 
+const deleteGroceryList = `-- name: DeleteGroceryList :exec
+DELETE FROM grocery_lists
+WHERE id = ?
+`
+
+func (q *Queries) DeleteGroceryList(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteGroceryList, id)
+	return err
+}
+
 const getGroceryList = `-- name: GetGroceryList :one
-SELECT id, created_at, updated_at, name, owner_id FROM grocery_lists
+SELECT id, created_at, updated_at, name, owner_id, household_id FROM grocery_lists
 WHERE id = ?
 `
 
@@ -54,17 +66,143 @@ func (q *Queries) GetGroceryList(ctx context.Context, id int64) (GroceryList, er
 		&i.UpdatedAt,
 		&i.Name,
 		&i.OwnerID,
+		&i.HouseholdID,
 	)
 	return i, err
 }
 
 const getGroceryListsForUser = `-- name: GetGroceryListsForUser :many
-SELECT id, created_at, updated_at, name, owner_id FROM grocery_lists
+SELECT id, created_at, updated_at, name, owner_id, household_id FROM grocery_lists
 WHERE owner_id = ?
+	OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?)
+`
+
+type GetGroceryListsForUserParams struct {
+	OwnerID  int64
+	MemberID int64
+}
+
+func (q *Queries) GetGroceryListsForUser(ctx context.Context, arg GetGroceryListsForUserParams) ([]GroceryList, error) {
+	rows, err := q.db.QueryContext(ctx, getGroceryListsForUser, arg.OwnerID, arg.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GroceryList
+	for rows.Next() {
+		var i GroceryList
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.OwnerID,
+			&i.HouseholdID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGroceryListsForUser = `-- name: ListGroceryListsForUser :many
+SELECT id, created_at, updated_at, name, owner_id, household_id FROM grocery_lists
+WHERE (owner_id = ?
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND id > ?
+ORDER BY id
+LIMIT ?
+`
+
+type ListGroceryListsForUserParams struct {
+	OwnerID      int64
+	MemberID     int64
+	NameContains string
+	CreatedAfter time.Time
+	AfterID      int64
+	Limit        int64
+}
+
+func (q *Queries) ListGroceryListsForUser(ctx context.Context, arg ListGroceryListsForUserParams) ([]GroceryList, error) {
+	rows, err := q.db.QueryContext(ctx, listGroceryListsForUser,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GroceryList
+	for rows.Next() {
+		var i GroceryList
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.OwnerID,
+			&i.HouseholdID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGroceryListsForUserByName = `-- name: ListGroceryListsForUserByName :many
+SELECT id, created_at, updated_at, name, owner_id, household_id FROM grocery_lists
+WHERE (owner_id = ?
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND (CAST(? AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) > (CAST(? AS TEXT), CAST(? AS INTEGER)))
+ORDER BY name COLLATE NOCASE, id
+LIMIT ?
 `
 
-func (q *Queries) GetGroceryListsForUser(ctx context.Context, ownerID int64) ([]GroceryList, error) {
-	rows, err := q.db.QueryContext(ctx, getGroceryListsForUser, ownerID)
+type ListGroceryListsForUserByNameParams struct {
+	OwnerID      int64
+	MemberID     int64
+	NameContains string
+	CreatedAfter time.Time
+	FromStart    bool
+	AfterName    string
+	AfterID      int64
+	Limit        int64
+}
+
+func (q *Queries) ListGroceryListsForUserByName(ctx context.Context, arg ListGroceryListsForUserByNameParams) ([]GroceryList, error) {
+	rows, err := q.db.QueryContext(ctx, listGroceryListsForUserByName,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.FromStart,
+		arg.AfterName,
+		arg.AfterID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +216,7 @@ func (q *Queries) GetGroceryListsForUser(ctx context.Context, ownerID int64) ([]
 			&i.UpdatedAt,
 			&i.Name,
 			&i.OwnerID,
+			&i.HouseholdID,
 		); err != nil {
 			return nil, err
 		}
@@ -91,3 +230,149 @@ func (q *Queries) GetGroceryListsForUser(ctx context.Context, ownerID int64) ([]
 	}
 	return items, nil
 }
+
+const listGroceryListsForUserByNameDesc = `-- name: ListGroceryListsForUserByNameDesc :many
+SELECT id, created_at, updated_at, name, owner_id, household_id FROM grocery_lists
+WHERE (owner_id = ?
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND (CAST(? AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) < (CAST(? AS TEXT), CAST(? AS INTEGER)))
+ORDER BY name COLLATE NOCASE DESC, id DESC
+LIMIT ?
+`
+
+type ListGroceryListsForUserByNameDescParams struct {
+	OwnerID      int64
+	MemberID     int64
+	NameContains string
+	CreatedAfter time.Time
+	FromStart    bool
+	BeforeName   string
+	BeforeID     int64
+	Limit        int64
+}
+
+func (q *Queries) ListGroceryListsForUserByNameDesc(ctx context.Context, arg ListGroceryListsForUserByNameDescParams) ([]GroceryList, error) {
+	rows, err := q.db.QueryContext(ctx, listGroceryListsForUserByNameDesc,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.FromStart,
+		arg.BeforeName,
+		arg.BeforeID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GroceryList
+	for rows.Next() {
+		var i GroceryList
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.OwnerID,
+			&i.HouseholdID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGroceryListsForUserDesc = `-- name: ListGroceryListsForUserDesc :many
+SELECT id, created_at, updated_at, name, owner_id, household_id FROM grocery_lists
+WHERE (owner_id = ?
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND id < ?
+ORDER BY id DESC
+LIMIT ?
+`
+
+type ListGroceryListsForUserDescParams struct {
+	OwnerID      int64
+	MemberID     int64
+	NameContains string
+	CreatedAfter time.Time
+	BeforeID     int64
+	Limit        int64
+}
+
+func (q *Queries) ListGroceryListsForUserDesc(ctx context.Context, arg ListGroceryListsForUserDescParams) ([]GroceryList, error) {
+	rows, err := q.db.QueryContext(ctx, listGroceryListsForUserDesc,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.BeforeID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GroceryList
+	for rows.Next() {
+		var i GroceryList
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.OwnerID,
+			&i.HouseholdID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setGroceryListHousehold = `-- name: SetGroceryListHousehold :one
+UPDATE grocery_lists
+SET updated_at = ?, household_id = ?
+WHERE id = ?
+RETURNING id, created_at, updated_at, name, owner_id, household_id
+`
+
+type SetGroceryListHouseholdParams struct {
+	UpdatedAt   time.Time
+	HouseholdID sql.NullInt64
+	ID          int64
+}
+
+func (q *Queries) SetGroceryListHousehold(ctx context.Context, arg SetGroceryListHouseholdParams) (GroceryList, error) {
+	row := q.db.QueryRowContext(ctx, setGroceryListHousehold, arg.UpdatedAt, arg.HouseholdID, arg.ID)
+	var i GroceryList
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.OwnerID,
+		&i.HouseholdID,
+	)
+	return i, err
+}
@@ -12,20 +12,21 @@ import (
 )
 
 const createIngredient = `-- name: CreateIngredient :one
-INSERT INTO ingredients(created_at, updated_at, name, description, amount, units, standard_amount, standard_units, recipe_id)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, name, description, recipe_id, amount, units, standard_amount, standard_units
+INSERT INTO ingredients(created_at, updated_at, name, description, amount, units, standard_amount, standard_units, recipe_id, canonical_ingredient_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, name, description, recipe_id, amount, units, standard_amount, standard_units, canonical_ingredient_id
 `
 
 type CreateIngredientParams struct {
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	Name           string
-	Description    sql.NullString
-	Amount         float64
-	Units          string
-	StandardAmount float64
-	StandardUnits  string
-	RecipeID       int64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	Name                  string
+	Description           sql.NullString
+	Amount                float64
+	Units                 string
+	StandardAmount        float64
+	StandardUnits         string
+	RecipeID              int64
+	CanonicalIngredientID sql.NullInt64
 }
 
 func (q *Queries) CreateIngredient(ctx context.Context, arg CreateIngredientParams) (Ingredient, error) {
@@ -39,6 +40,7 @@ func (q *Queries) CreateIngredient(ctx context.Context, arg CreateIngredientPara
 		arg.StandardAmount,
 		arg.StandardUnits,
 		arg.RecipeID,
+		arg.CanonicalIngredientID,
 	)
 	var i Ingredient
 	err := row.Scan(
@@ -52,12 +54,23 @@ func (q *Queries) CreateIngredient(ctx context.Context, arg CreateIngredientPara
 		&i.Units,
 		&i.StandardAmount,
 		&i.StandardUnits,
+		&i.CanonicalIngredientID,
 	)
 	return i, err
 }
 
+const deleteIngredientsForRecipe = `-- name: DeleteIngredientsForRecipe :exec
+DELETE FROM ingredients
+WHERE recipe_id = ?
+`
+
+func (q *Queries) DeleteIngredientsForRecipe(ctx context.Context, recipeID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteIngredientsForRecipe, recipeID)
+	return err
+}
+
 const getIngredient = `-- name: GetIngredient :one
-SELECT id, created_at, updated_at, name, description, recipe_id, amount, units, standard_amount, standard_units FROM ingredients
+SELECT id, created_at, updated_at, name, description, recipe_id, amount, units, standard_amount, standard_units, canonical_ingredient_id FROM ingredients
 WHERE id = ?
 `
 
@@ -75,12 +88,13 @@ func (q *Queries) GetIngredient(ctx context.Context, id int64) (Ingredient, erro
 		&i.Units,
 		&i.StandardAmount,
 		&i.StandardUnits,
+		&i.CanonicalIngredientID,
 	)
 	return i, err
 }
 
 const getIngredientsForRecipe = `-- name: GetIngredientsForRecipe :many
-SELECT id, created_at, updated_at, name, description, recipe_id, amount, units, standard_amount, standard_units FROM ingredients
+SELECT id, created_at, updated_at, name, description, recipe_id, amount, units, standard_amount, standard_units, canonical_ingredient_id FROM ingredients
 WHERE recipe_id = ?
 ORDER BY id
 `
@@ -105,6 +119,7 @@ func (q *Queries) GetIngredientsForRecipe(ctx context.Context, recipeID int64) (
 			&i.Units,
 			&i.StandardAmount,
 			&i.StandardUnits,
+			&i.CanonicalIngredientID,
 		); err != nil {
 			return nil, err
 		}
@@ -12,22 +12,24 @@ import (
 )
 
 const createItem = `-- name: CreateItem :one
-INSERT INTO items (created_at, updated_at, ingredient_id, grocery_list_id, meal_id, name, description, amount, units, standard_amount, standard_units)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete
+INSERT INTO items (created_at, updated_at, ingredient_id, canonical_ingredient_id, grocery_list_id, meal_id, name, description, amount, units, standard_amount, standard_units, category)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete, canonical_ingredient_id, category
 `
 
 type CreateItemParams struct {
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	IngredientID   sql.NullInt64
-	GroceryListID  int64
-	MealID         sql.NullInt64
-	Name           string
-	Description    sql.NullString
-	Amount         float64
-	Units          string
-	StandardAmount float64
-	StandardUnits  string
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	IngredientID          sql.NullInt64
+	CanonicalIngredientID sql.NullInt64
+	GroceryListID         int64
+	MealID                sql.NullInt64
+	Name                  string
+	Description           sql.NullString
+	Amount                float64
+	Units                 string
+	StandardAmount        float64
+	StandardUnits         string
+	Category              sql.NullString
 }
 
 func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) (Item, error) {
@@ -35,6 +37,7 @@ func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) (Item, e
 		arg.CreatedAt,
 		arg.UpdatedAt,
 		arg.IngredientID,
+		arg.CanonicalIngredientID,
 		arg.GroceryListID,
 		arg.MealID,
 		arg.Name,
@@ -43,6 +46,7 @@ func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) (Item, e
 		arg.Units,
 		arg.StandardAmount,
 		arg.StandardUnits,
+		arg.Category,
 	)
 	var i Item
 	err := row.Scan(
@@ -59,12 +63,44 @@ func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) (Item, e
 		&i.StandardAmount,
 		&i.StandardUnits,
 		&i.IsComplete,
+		&i.CanonicalIngredientID,
+		&i.Category,
 	)
 	return i, err
 }
 
+const deleteItem = `-- name: DeleteItem :exec
+DELETE FROM items
+WHERE id = ?
+`
+
+func (q *Queries) DeleteItem(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteItem, id)
+	return err
+}
+
+const deleteItemsForMeal = `-- name: DeleteItemsForMeal :exec
+DELETE FROM items
+WHERE meal_id = ?
+`
+
+func (q *Queries) DeleteItemsForMeal(ctx context.Context, mealID sql.NullInt64) error {
+	_, err := q.db.ExecContext(ctx, deleteItemsForMeal, mealID)
+	return err
+}
+
+const deleteItemsInGroceryList = `-- name: DeleteItemsInGroceryList :exec
+DELETE FROM items
+WHERE grocery_list_id = ?
+`
+
+func (q *Queries) DeleteItemsInGroceryList(ctx context.Context, groceryListID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteItemsInGroceryList, groceryListID)
+	return err
+}
+
 const getExtendedItem = `-- name: GetExtendedItem :one
-SELECT it.id, it.created_at, it.updated_at, it.grocery_list_id, it.meal_id, it.ingredient_id, it.name, it.description, it.amount, it.units, it.standard_amount, it.standard_units, it.is_complete, i.id, i.created_at, i.updated_at, i.name, i.description, i.recipe_id, i.amount, i.units, i.standard_amount, i.standard_units FROM items it
+SELECT it.id, it.created_at, it.updated_at, it.grocery_list_id, it.meal_id, it.ingredient_id, it.name, it.description, it.amount, it.units, it.standard_amount, it.standard_units, it.is_complete, it.canonical_ingredient_id, it.category, i.id, i.created_at, i.updated_at, i.name, i.description, i.recipe_id, i.amount, i.units, i.standard_amount, i.standard_units, i.canonical_ingredient_id FROM items it
 LEFT JOIN ingredients i ON it.ingredient_id = i.id
 WHERE it.id = ?
 `
@@ -91,6 +127,8 @@ func (q *Queries) GetExtendedItem(ctx context.Context, id int64) (GetExtendedIte
 		&i.Item.StandardAmount,
 		&i.Item.StandardUnits,
 		&i.Item.IsComplete,
+		&i.Item.CanonicalIngredientID,
+		&i.Item.Category,
 		&i.Ingredient.ID,
 		&i.Ingredient.CreatedAt,
 		&i.Ingredient.UpdatedAt,
@@ -101,12 +139,13 @@ func (q *Queries) GetExtendedItem(ctx context.Context, id int64) (GetExtendedIte
 		&i.Ingredient.Units,
 		&i.Ingredient.StandardAmount,
 		&i.Ingredient.StandardUnits,
+		&i.Ingredient.CanonicalIngredientID,
 	)
 	return i, err
 }
 
 const getExtendedItemsForGroceryList = `-- name: GetExtendedItemsForGroceryList :many
-SELECT it.id, it.created_at, it.updated_at, it.grocery_list_id, it.meal_id, it.ingredient_id, it.name, it.description, it.amount, it.units, it.standard_amount, it.standard_units, it.is_complete, i.id, i.created_at, i.updated_at, i.name, i.description, i.recipe_id, i.amount, i.units, i.standard_amount, i.standard_units FROM items it
+SELECT it.id, it.created_at, it.updated_at, it.grocery_list_id, it.meal_id, it.ingredient_id, it.name, it.description, it.amount, it.units, it.standard_amount, it.standard_units, it.is_complete, it.canonical_ingredient_id, it.category, i.id, i.created_at, i.updated_at, i.name, i.description, i.recipe_id, i.amount, i.units, i.standard_amount, i.standard_units, i.canonical_ingredient_id FROM items it
 LEFT JOIN ingredients i ON it.ingredient_id = i.id
 WHERE it.grocery_list_id = ?
 `
@@ -139,6 +178,8 @@ func (q *Queries) GetExtendedItemsForGroceryList(ctx context.Context, groceryLis
 			&i.Item.StandardAmount,
 			&i.Item.StandardUnits,
 			&i.Item.IsComplete,
+			&i.Item.CanonicalIngredientID,
+			&i.Item.Category,
 			&i.Ingredient.ID,
 			&i.Ingredient.CreatedAt,
 			&i.Ingredient.UpdatedAt,
@@ -149,6 +190,7 @@ func (q *Queries) GetExtendedItemsForGroceryList(ctx context.Context, groceryLis
 			&i.Ingredient.Units,
 			&i.Ingredient.StandardAmount,
 			&i.Ingredient.StandardUnits,
+			&i.Ingredient.CanonicalIngredientID,
 		); err != nil {
 			return nil, err
 		}
@@ -164,7 +206,7 @@ func (q *Queries) GetExtendedItemsForGroceryList(ctx context.Context, groceryLis
 }
 
 const getExtendedItemsForMeal = `-- name: GetExtendedItemsForMeal :many
-SELECT it.id, it.created_at, it.updated_at, it.grocery_list_id, it.meal_id, it.ingredient_id, it.name, it.description, it.amount, it.units, it.standard_amount, it.standard_units, it.is_complete, i.id, i.created_at, i.updated_at, i.name, i.description, i.recipe_id, i.amount, i.units, i.standard_amount, i.standard_units FROM items it
+SELECT it.id, it.created_at, it.updated_at, it.grocery_list_id, it.meal_id, it.ingredient_id, it.name, it.description, it.amount, it.units, it.standard_amount, it.standard_units, it.is_complete, it.canonical_ingredient_id, it.category, i.id, i.created_at, i.updated_at, i.name, i.description, i.recipe_id, i.amount, i.units, i.standard_amount, i.standard_units, i.canonical_ingredient_id FROM items it
 LEFT JOIN ingredients i ON it.ingredient_id = i.id
 WHERE it.meal_id = ?
 `
@@ -197,6 +239,8 @@ func (q *Queries) GetExtendedItemsForMeal(ctx context.Context, mealID sql.NullIn
 			&i.Item.StandardAmount,
 			&i.Item.StandardUnits,
 			&i.Item.IsComplete,
+			&i.Item.CanonicalIngredientID,
+			&i.Item.Category,
 			&i.Ingredient.ID,
 			&i.Ingredient.CreatedAt,
 			&i.Ingredient.UpdatedAt,
@@ -207,6 +251,7 @@ func (q *Queries) GetExtendedItemsForMeal(ctx context.Context, mealID sql.NullIn
 			&i.Ingredient.Units,
 			&i.Ingredient.StandardAmount,
 			&i.Ingredient.StandardUnits,
+			&i.Ingredient.CanonicalIngredientID,
 		); err != nil {
 			return nil, err
 		}
@@ -222,7 +267,7 @@ func (q *Queries) GetExtendedItemsForMeal(ctx context.Context, mealID sql.NullIn
 }
 
 const getItem = `-- name: GetItem :one
-SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete FROM items
+SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete, canonical_ingredient_id, category FROM items
 WHERE id = ?
 `
 
@@ -243,12 +288,14 @@ func (q *Queries) GetItem(ctx context.Context, id int64) (Item, error) {
 		&i.StandardAmount,
 		&i.StandardUnits,
 		&i.IsComplete,
+		&i.CanonicalIngredientID,
+		&i.Category,
 	)
 	return i, err
 }
 
 const getItemAndGroceryList = `-- name: GetItemAndGroceryList :one
-SELECT it.id, it.created_at, it.updated_at, it.grocery_list_id, it.meal_id, it.ingredient_id, it.name, it.description, it.amount, it.units, it.standard_amount, it.standard_units, it.is_complete, gl.id, gl.created_at, gl.updated_at, gl.name, gl.owner_id FROM items it
+SELECT it.id, it.created_at, it.updated_at, it.grocery_list_id, it.meal_id, it.ingredient_id, it.name, it.description, it.amount, it.units, it.standard_amount, it.standard_units, it.is_complete, it.canonical_ingredient_id, it.category, gl.id, gl.created_at, gl.updated_at, gl.name, gl.owner_id, gl.household_id FROM items it
 JOIN grocery_lists gl ON it.grocery_list_id = gl.id
 WHERE it.id = ?
 `
@@ -275,17 +322,20 @@ func (q *Queries) GetItemAndGroceryList(ctx context.Context, id int64) (GetItemA
 		&i.Item.StandardAmount,
 		&i.Item.StandardUnits,
 		&i.Item.IsComplete,
+		&i.Item.CanonicalIngredientID,
+		&i.Item.Category,
 		&i.GroceryList.ID,
 		&i.GroceryList.CreatedAt,
 		&i.GroceryList.UpdatedAt,
 		&i.GroceryList.Name,
 		&i.GroceryList.OwnerID,
+		&i.GroceryList.HouseholdID,
 	)
 	return i, err
 }
 
 const getItemsForGroceryList = `-- name: GetItemsForGroceryList :many
-SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete FROM items it 
+SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete, canonical_ingredient_id, category FROM items it 
 WHERE it.grocery_list_id = ?
 `
 
@@ -312,6 +362,8 @@ func (q *Queries) GetItemsForGroceryList(ctx context.Context, groceryListID int6
 			&i.StandardAmount,
 			&i.StandardUnits,
 			&i.IsComplete,
+			&i.CanonicalIngredientID,
+			&i.Category,
 		); err != nil {
 			return nil, err
 		}
@@ -327,7 +379,7 @@ func (q *Queries) GetItemsForGroceryList(ctx context.Context, groceryListID int6
 }
 
 const getItemsForGroceryListByName = `-- name: GetItemsForGroceryListByName :many
-SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete FROM items it 
+SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete, canonical_ingredient_id, category FROM items it 
 WHERE it.grocery_list_id = ? AND name = ?
 `
 
@@ -359,6 +411,8 @@ func (q *Queries) GetItemsForGroceryListByName(ctx context.Context, arg GetItems
 			&i.StandardAmount,
 			&i.StandardUnits,
 			&i.IsComplete,
+			&i.CanonicalIngredientID,
+			&i.Category,
 		); err != nil {
 			return nil, err
 		}
@@ -374,7 +428,7 @@ func (q *Queries) GetItemsForGroceryListByName(ctx context.Context, arg GetItems
 }
 
 const getItemsForMeal = `-- name: GetItemsForMeal :many
-SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete FROM items it 
+SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete, canonical_ingredient_id, category FROM items it 
 WHERE it.meal_id = ?
 `
 
@@ -401,6 +455,294 @@ func (q *Queries) GetItemsForMeal(ctx context.Context, mealID sql.NullInt64) ([]
 			&i.StandardAmount,
 			&i.StandardUnits,
 			&i.IsComplete,
+			&i.CanonicalIngredientID,
+			&i.Category,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemsForGroceryList = `-- name: ListItemsForGroceryList :many
+SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete, canonical_ingredient_id, category FROM items
+WHERE grocery_list_id = ?
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND ((is_complete = 0 AND CAST(? AS BOOLEAN))
+		OR (is_complete = 1 AND CAST(? AS BOOLEAN)))
+	AND id > ?
+ORDER BY id
+LIMIT ?
+`
+
+type ListItemsForGroceryListParams struct {
+	GroceryListID     int64
+	NameContains      string
+	CreatedAfter      time.Time
+	IncludeIncomplete bool
+	IncludeComplete   bool
+	AfterID           int64
+	Limit             int64
+}
+
+func (q *Queries) ListItemsForGroceryList(ctx context.Context, arg ListItemsForGroceryListParams) ([]Item, error) {
+	rows, err := q.db.QueryContext(ctx, listItemsForGroceryList,
+		arg.GroceryListID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.IncludeIncomplete,
+		arg.IncludeComplete,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Item
+	for rows.Next() {
+		var i Item
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.GroceryListID,
+			&i.MealID,
+			&i.IngredientID,
+			&i.Name,
+			&i.Description,
+			&i.Amount,
+			&i.Units,
+			&i.StandardAmount,
+			&i.StandardUnits,
+			&i.IsComplete,
+			&i.CanonicalIngredientID,
+			&i.Category,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemsForGroceryListByName = `-- name: ListItemsForGroceryListByName :many
+SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete, canonical_ingredient_id, category FROM items
+WHERE grocery_list_id = ?
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND ((is_complete = 0 AND CAST(? AS BOOLEAN))
+		OR (is_complete = 1 AND CAST(? AS BOOLEAN)))
+	AND (CAST(? AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) > (CAST(? AS TEXT), CAST(? AS INTEGER)))
+ORDER BY name COLLATE NOCASE, id
+LIMIT ?
+`
+
+type ListItemsForGroceryListByNameParams struct {
+	GroceryListID     int64
+	NameContains      string
+	CreatedAfter      time.Time
+	IncludeIncomplete bool
+	IncludeComplete   bool
+	FromStart         bool
+	AfterName         string
+	AfterID           int64
+	Limit             int64
+}
+
+func (q *Queries) ListItemsForGroceryListByName(ctx context.Context, arg ListItemsForGroceryListByNameParams) ([]Item, error) {
+	rows, err := q.db.QueryContext(ctx, listItemsForGroceryListByName,
+		arg.GroceryListID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.IncludeIncomplete,
+		arg.IncludeComplete,
+		arg.FromStart,
+		arg.AfterName,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Item
+	for rows.Next() {
+		var i Item
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.GroceryListID,
+			&i.MealID,
+			&i.IngredientID,
+			&i.Name,
+			&i.Description,
+			&i.Amount,
+			&i.Units,
+			&i.StandardAmount,
+			&i.StandardUnits,
+			&i.IsComplete,
+			&i.CanonicalIngredientID,
+			&i.Category,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemsForGroceryListByNameDesc = `-- name: ListItemsForGroceryListByNameDesc :many
+SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete, canonical_ingredient_id, category FROM items
+WHERE grocery_list_id = ?
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND ((is_complete = 0 AND CAST(? AS BOOLEAN))
+		OR (is_complete = 1 AND CAST(? AS BOOLEAN)))
+	AND (CAST(? AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) < (CAST(? AS TEXT), CAST(? AS INTEGER)))
+ORDER BY name COLLATE NOCASE DESC, id DESC
+LIMIT ?
+`
+
+type ListItemsForGroceryListByNameDescParams struct {
+	GroceryListID     int64
+	NameContains      string
+	CreatedAfter      time.Time
+	IncludeIncomplete bool
+	IncludeComplete   bool
+	FromStart         bool
+	BeforeName        string
+	BeforeID          int64
+	Limit             int64
+}
+
+func (q *Queries) ListItemsForGroceryListByNameDesc(ctx context.Context, arg ListItemsForGroceryListByNameDescParams) ([]Item, error) {
+	rows, err := q.db.QueryContext(ctx, listItemsForGroceryListByNameDesc,
+		arg.GroceryListID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.IncludeIncomplete,
+		arg.IncludeComplete,
+		arg.FromStart,
+		arg.BeforeName,
+		arg.BeforeID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Item
+	for rows.Next() {
+		var i Item
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.GroceryListID,
+			&i.MealID,
+			&i.IngredientID,
+			&i.Name,
+			&i.Description,
+			&i.Amount,
+			&i.Units,
+			&i.StandardAmount,
+			&i.StandardUnits,
+			&i.IsComplete,
+			&i.CanonicalIngredientID,
+			&i.Category,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemsForGroceryListDesc = `-- name: ListItemsForGroceryListDesc :many
+SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete, canonical_ingredient_id, category FROM items
+WHERE grocery_list_id = ?
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND ((is_complete = 0 AND CAST(? AS BOOLEAN))
+		OR (is_complete = 1 AND CAST(? AS BOOLEAN)))
+	AND id < ?
+ORDER BY id DESC
+LIMIT ?
+`
+
+type ListItemsForGroceryListDescParams struct {
+	GroceryListID     int64
+	NameContains      string
+	CreatedAfter      time.Time
+	IncludeIncomplete bool
+	IncludeComplete   bool
+	BeforeID          int64
+	Limit             int64
+}
+
+func (q *Queries) ListItemsForGroceryListDesc(ctx context.Context, arg ListItemsForGroceryListDescParams) ([]Item, error) {
+	rows, err := q.db.QueryContext(ctx, listItemsForGroceryListDesc,
+		arg.GroceryListID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.IncludeIncomplete,
+		arg.IncludeComplete,
+		arg.BeforeID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Item
+	for rows.Next() {
+		var i Item
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.GroceryListID,
+			&i.MealID,
+			&i.IngredientID,
+			&i.Name,
+			&i.Description,
+			&i.Amount,
+			&i.Units,
+			&i.StandardAmount,
+			&i.StandardUnits,
+			&i.IsComplete,
+			&i.CanonicalIngredientID,
+			&i.Category,
 		); err != nil {
 			return nil, err
 		}
@@ -431,3 +773,73 @@ func (q *Queries) SetIsComplete(ctx context.Context, arg SetIsCompleteParams) er
 	_, err := q.db.ExecContext(ctx, setIsComplete, arg.UpdatedAt, arg.IsComplete, arg.ID)
 	return err
 }
+
+const setItemAmounts = `-- name: SetItemAmounts :exec
+UPDATE items
+SET updated_at = ?, amount = ?, standard_amount = ?
+WHERE id = ?
+`
+
+type SetItemAmountsParams struct {
+	UpdatedAt      time.Time
+	Amount         float64
+	StandardAmount float64
+	ID             int64
+}
+
+func (q *Queries) SetItemAmounts(ctx context.Context, arg SetItemAmountsParams) error {
+	_, err := q.db.ExecContext(ctx, setItemAmounts,
+		arg.UpdatedAt,
+		arg.Amount,
+		arg.StandardAmount,
+		arg.ID,
+	)
+	return err
+}
+
+const setItemCategory = `-- name: SetItemCategory :one
+UPDATE items
+SET updated_at = ?, category = ?
+WHERE id = ?
+RETURNING id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete, canonical_ingredient_id, category
+`
+
+type SetItemCategoryParams struct {
+	UpdatedAt time.Time
+	Category  sql.NullString
+	ID        int64
+}
+
+func (q *Queries) SetItemCategory(ctx context.Context, arg SetItemCategoryParams) (Item, error) {
+	row := q.db.QueryRowContext(ctx, setItemCategory, arg.UpdatedAt, arg.Category, arg.ID)
+	var i Item
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GroceryListID,
+		&i.MealID,
+		&i.IngredientID,
+		&i.Name,
+		&i.Description,
+		&i.Amount,
+		&i.Units,
+		&i.StandardAmount,
+		&i.StandardUnits,
+		&i.IsComplete,
+		&i.CanonicalIngredientID,
+		&i.Category,
+	)
+	return i, err
+}
+
+const unlinkItemsFromRecipeIngredients = `-- name: UnlinkItemsFromRecipeIngredients :exec
+UPDATE items
+SET ingredient_id = NULL
+WHERE ingredient_id IN (SELECT id FROM ingredients WHERE recipe_id = ?)
+`
+
+func (q *Queries) UnlinkItemsFromRecipeIngredients(ctx context.Context, recipeID int64) error {
+	_, err := q.db.ExecContext(ctx, unlinkItemsFromRecipeIngredients, recipeID)
+	return err
+}
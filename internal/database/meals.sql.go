@@ -7,12 +7,25 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
+const countMealsForRecipe = `-- name: CountMealsForRecipe :one
+SELECT COUNT(*) FROM meals
+WHERE recipe_id = ?
+`
+
+func (q *Queries) CountMealsForRecipe(ctx context.Context, recipeID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMealsForRecipe, recipeID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createMeal = `-- name: CreateMeal :one
-INSERT INTO meals (created_at, updated_at, grocery_list_id, recipe_id)
-VALUES (?, ?, ?, ?) RETURNING id, created_at, updated_at, grocery_list_id, recipe_id
+INSERT INTO meals (created_at, updated_at, grocery_list_id, recipe_id, servings)
+VALUES (?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, grocery_list_id, recipe_id, servings
 `
 
 type CreateMealParams struct {
@@ -20,6 +33,7 @@ type CreateMealParams struct {
 	UpdatedAt     time.Time
 	GroceryListID int64
 	RecipeID      int64
+	Servings      sql.NullInt64
 }
 
 func (q *Queries) CreateMeal(ctx context.Context, arg CreateMealParams) (Meal, error) {
@@ -28,6 +42,7 @@ func (q *Queries) CreateMeal(ctx context.Context, arg CreateMealParams) (Meal, e
 		arg.UpdatedAt,
 		arg.GroceryListID,
 		arg.RecipeID,
+		arg.Servings,
 	)
 	var i Meal
 	err := row.Scan(
@@ -36,12 +51,33 @@ func (q *Queries) CreateMeal(ctx context.Context, arg CreateMealParams) (Meal, e
 		&i.UpdatedAt,
 		&i.GroceryListID,
 		&i.RecipeID,
+		&i.Servings,
 	)
 	return i, err
 }
 
+const deleteMeal = `-- name: DeleteMeal :exec
+DELETE FROM meals
+WHERE id = ?
+`
+
+func (q *Queries) DeleteMeal(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMeal, id)
+	return err
+}
+
+const deleteMealsInGroceryList = `-- name: DeleteMealsInGroceryList :exec
+DELETE FROM meals
+WHERE grocery_list_id = ?
+`
+
+func (q *Queries) DeleteMealsInGroceryList(ctx context.Context, groceryListID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMealsInGroceryList, groceryListID)
+	return err
+}
+
 const getExtendedMeal = `-- name: GetExtendedMeal :one
-SELECT m.id, m.created_at, m.updated_at, m.grocery_list_id, m.recipe_id, r.id, r.created_at, r.updated_at, r.name, r.description, r.url, r.prep_time, r.cook_time, r.total_time, r.owner_id from meals m 
+SELECT m.id, m.created_at, m.updated_at, m.grocery_list_id, m.recipe_id, m.servings, r.id, r.created_at, r.updated_at, r.name, r.description, r.url, r.owner_id, r.servings, r.household_id, r.prep_time_seconds, r.cook_time_seconds, r.total_time_seconds, r.time_seconds from meals m 
 JOIN recipes r ON m.recipe_id = r.id
 WHERE m.id = ?
 `
@@ -60,22 +96,26 @@ func (q *Queries) GetExtendedMeal(ctx context.Context, id int64) (GetExtendedMea
 		&i.Meal.UpdatedAt,
 		&i.Meal.GroceryListID,
 		&i.Meal.RecipeID,
+		&i.Meal.Servings,
 		&i.Recipe.ID,
 		&i.Recipe.CreatedAt,
 		&i.Recipe.UpdatedAt,
 		&i.Recipe.Name,
 		&i.Recipe.Description,
 		&i.Recipe.Url,
-		&i.Recipe.PrepTime,
-		&i.Recipe.CookTime,
-		&i.Recipe.TotalTime,
 		&i.Recipe.OwnerID,
+		&i.Recipe.Servings,
+		&i.Recipe.HouseholdID,
+		&i.Recipe.PrepTimeSeconds,
+		&i.Recipe.CookTimeSeconds,
+		&i.Recipe.TotalTimeSeconds,
+		&i.Recipe.TimeSeconds,
 	)
 	return i, err
 }
 
 const getExtendedMealsInGroceryList = `-- name: GetExtendedMealsInGroceryList :many
-SELECT m.id, m.created_at, m.updated_at, m.grocery_list_id, m.recipe_id, r.id, r.created_at, r.updated_at, r.name, r.description, r.url, r.prep_time, r.cook_time, r.total_time, r.owner_id from meals m 
+SELECT m.id, m.created_at, m.updated_at, m.grocery_list_id, m.recipe_id, m.servings, r.id, r.created_at, r.updated_at, r.name, r.description, r.url, r.owner_id, r.servings, r.household_id, r.prep_time_seconds, r.cook_time_seconds, r.total_time_seconds, r.time_seconds from meals m 
 JOIN recipes r ON m.recipe_id = r.id
 WHERE m.grocery_list_id = ?
 `
@@ -100,16 +140,20 @@ func (q *Queries) GetExtendedMealsInGroceryList(ctx context.Context, groceryList
 			&i.Meal.UpdatedAt,
 			&i.Meal.GroceryListID,
 			&i.Meal.RecipeID,
+			&i.Meal.Servings,
 			&i.Recipe.ID,
 			&i.Recipe.CreatedAt,
 			&i.Recipe.UpdatedAt,
 			&i.Recipe.Name,
 			&i.Recipe.Description,
 			&i.Recipe.Url,
-			&i.Recipe.PrepTime,
-			&i.Recipe.CookTime,
-			&i.Recipe.TotalTime,
 			&i.Recipe.OwnerID,
+			&i.Recipe.Servings,
+			&i.Recipe.HouseholdID,
+			&i.Recipe.PrepTimeSeconds,
+			&i.Recipe.CookTimeSeconds,
+			&i.Recipe.TotalTimeSeconds,
+			&i.Recipe.TimeSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -125,7 +169,7 @@ func (q *Queries) GetExtendedMealsInGroceryList(ctx context.Context, groceryList
 }
 
 const getMeal = `-- name: GetMeal :one
-SELECT id, created_at, updated_at, grocery_list_id, recipe_id FROM meals
+SELECT id, created_at, updated_at, grocery_list_id, recipe_id, servings FROM meals
 WHERE id = ?
 `
 
@@ -138,12 +182,13 @@ func (q *Queries) GetMeal(ctx context.Context, id int64) (Meal, error) {
 		&i.UpdatedAt,
 		&i.GroceryListID,
 		&i.RecipeID,
+		&i.Servings,
 	)
 	return i, err
 }
 
 const getMealsInGroceryList = `-- name: GetMealsInGroceryList :many
-SELECT id, created_at, updated_at, grocery_list_id, recipe_id FROM meals m 
+SELECT id, created_at, updated_at, grocery_list_id, recipe_id, servings FROM meals m 
 WHERE m.grocery_list_id = ?
 `
 
@@ -162,6 +207,7 @@ func (q *Queries) GetMealsInGroceryList(ctx context.Context, groceryListID int64
 			&i.UpdatedAt,
 			&i.GroceryListID,
 			&i.RecipeID,
+			&i.Servings,
 		); err != nil {
 			return nil, err
 		}
@@ -175,3 +221,30 @@ func (q *Queries) GetMealsInGroceryList(ctx context.Context, groceryListID int64
 	}
 	return items, nil
 }
+
+const setMealServings = `-- name: SetMealServings :one
+UPDATE meals
+SET updated_at = ?, servings = ?
+WHERE id = ?
+RETURNING id, created_at, updated_at, grocery_list_id, recipe_id, servings
+`
+
+type SetMealServingsParams struct {
+	UpdatedAt time.Time
+	Servings  sql.NullInt64
+	ID        int64
+}
+
+func (q *Queries) SetMealServings(ctx context.Context, arg SetMealServingsParams) (Meal, error) {
+	row := q.db.QueryRowContext(ctx, setMealServings, arg.UpdatedAt, arg.Servings, arg.ID)
+	var i Meal
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GroceryListID,
+		&i.RecipeID,
+		&i.Servings,
+	)
+	return i, err
+}
@@ -9,41 +9,115 @@ import (
 	"time"
 )
 
+type ApiKey struct {
+	ID         int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	OwnerID    int64
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scope      string
+	LastUsedAt sql.NullTime
+}
+
+type CalendarFeed struct {
+	ID         int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	OwnerID    int64
+	TokenHash  string
+	LastUsedAt sql.NullTime
+}
+
+type CanonicalIngredient struct {
+	ID       int64
+	Name     string
+	Category sql.NullString
+}
+
+type CanonicalIngredientSynonym struct {
+	Synonym               string
+	CanonicalIngredientID int64
+}
+
 type GroceryList struct {
+	ID          int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Name        string
+	OwnerID     int64
+	HouseholdID sql.NullInt64
+}
+
+type GroceryListChange struct {
+	ID            int64
+	GroceryListID int64
+	Entity        string
+	EntityID      int64
+	Deleted       bool
+}
+
+type Household struct {
 	ID        int64
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Name      string
-	OwnerID   int64
+}
+
+type HouseholdMember struct {
+	HouseholdID int64
+	UserID      int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Role        string
 }
 
 type Ingredient struct {
-	ID             int64
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	Name           string
-	Description    sql.NullString
-	RecipeID       int64
-	Amount         float64
-	Units          string
-	StandardAmount float64
-	StandardUnits  string
+	ID                    int64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	Name                  string
+	Description           sql.NullString
+	RecipeID              int64
+	Amount                float64
+	Units                 string
+	StandardAmount        float64
+	StandardUnits         string
+	CanonicalIngredientID sql.NullInt64
+}
+
+type Instruction struct {
+	ID        int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	RecipeID  int64
+	Step      int64
+	Text      string
 }
 
 type Item struct {
-	ID             int64
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	GroceryListID  int64
-	MealID         sql.NullInt64
-	IngredientID   sql.NullInt64
-	Name           string
-	Description    sql.NullString
-	Amount         float64
-	Units          string
+	ID                    int64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	GroceryListID         int64
+	MealID                sql.NullInt64
+	IngredientID          sql.NullInt64
+	Name                  string
+	Description           sql.NullString
+	Amount                float64
+	Units                 string
+	StandardAmount        float64
+	StandardUnits         string
+	IsComplete            bool
+	CanonicalIngredientID sql.NullInt64
+	Category              sql.NullString
+}
+
+type ItemRestock struct {
+	ItemID         int64
+	PantryItemID   int64
 	StandardAmount float64
-	StandardUnits  string
-	IsComplete     bool
 }
 
 type Meal struct {
@@ -52,19 +126,124 @@ type Meal struct {
 	UpdatedAt     time.Time
 	GroceryListID int64
 	RecipeID      int64
+	Servings      sql.NullInt64
 }
 
-type Recipe struct {
+type MealPantryUse struct {
+	MealID         int64
+	PantryItemID   int64
+	StandardAmount float64
+}
+
+type PantryItem struct {
+	ID                    int64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	OwnerID               sql.NullInt64
+	HouseholdID           sql.NullInt64
+	CanonicalIngredientID int64
+	StandardAmount        float64
+	StandardUnits         string
+	AlwaysStocked         bool
+}
+
+type PasswordResetToken struct {
+	ID        int64
+	CreatedAt time.Time
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+}
+
+type PlannedMeal struct {
 	ID          int64
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
-	Name        string
-	Description sql.NullString
-	Url         sql.NullString
-	PrepTime    sql.NullString
-	CookTime    sql.NullString
-	TotalTime   sql.NullString
 	OwnerID     int64
+	HouseholdID sql.NullInt64
+	Date        string
+	Slot        string
+	SlotName    sql.NullString
+	RecipeID    int64
+	Servings    sql.NullInt64
+	MealID      sql.NullInt64
+}
+
+type Recipe struct {
+	ID               int64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Name             string
+	Description      sql.NullString
+	Url              sql.NullString
+	OwnerID          int64
+	Servings         sql.NullInt64
+	HouseholdID      sql.NullInt64
+	PrepTimeSeconds  sql.NullInt64
+	CookTimeSeconds  sql.NullInt64
+	TotalTimeSeconds sql.NullInt64
+	TimeSeconds      sql.NullInt64
+}
+
+type RecipeImage struct {
+	RecipeID    int64
+	CreatedAt   time.Time
+	BlobKey     string
+	ContentType string
+	Size        int64
+	SourceUrl   sql.NullString
+}
+
+type RecipeImport struct {
+	ID        int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	OwnerID   int64
+	Url       string
+	Status    string
+	Attempts  int64
+	RecipeID  sql.NullInt64
+	ErrorCode sql.NullString
+}
+
+type RecipeSearch struct {
+	Name        string
+	Description string
+	Ingredients string
+}
+
+type RecipeTag struct {
+	RecipeID  int64
+	Name      string
+	Kind      string
+	CreatedAt time.Time
+}
+
+type RefreshToken struct {
+	ID           int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	UserID       int64
+	TokenHash    string
+	ExpiresAt    time.Time
+	RevokedAt    sql.NullTime
+	ReplacedByID sql.NullInt64
+}
+
+type Store struct {
+	ID        int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	OwnerID   int64
+	Name      string
+}
+
+type StoreAisle struct {
+	StoreID  int64
+	Category string
+	Position int64
+	Name     sql.NullString
 }
 
 type User struct {
@@ -75,4 +254,6 @@ type User struct {
 	HashedPassword string
 	FirstName      sql.NullString
 	LastName       sql.NullString
+	TokenVersion   int64
+	Email          sql.NullString
 }
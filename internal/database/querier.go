@@ -10,33 +10,155 @@ import (
 )
 
 type Querier interface {
+	AddMealPantryUse(ctx context.Context, arg AddMealPantryUseParams) error
+	AddRecipeTag(ctx context.Context, arg AddRecipeTagParams) error
+	AddToPantryItem(ctx context.Context, arg AddToPantryItemParams) error
+	ClaimRecipeImport(ctx context.Context, arg ClaimRecipeImportParams) (RecipeImport, error)
+	CountHouseholdOwners(ctx context.Context, householdID int64) (int64, error)
+	CountMealsForRecipe(ctx context.Context, recipeID int64) (int64, error)
+	CountPlannedMealsForRecipe(ctx context.Context, recipeID int64) (int64, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
 	CreateGroceryList(ctx context.Context, arg CreateGroceryListParams) (GroceryList, error)
+	CreateHousehold(ctx context.Context, arg CreateHouseholdParams) (Household, error)
+	CreateHouseholdMember(ctx context.Context, arg CreateHouseholdMemberParams) (HouseholdMember, error)
 	CreateIngredient(ctx context.Context, arg CreateIngredientParams) (Ingredient, error)
+	CreateInstruction(ctx context.Context, arg CreateInstructionParams) (Instruction, error)
 	CreateItem(ctx context.Context, arg CreateItemParams) (Item, error)
+	CreateItemRestock(ctx context.Context, arg CreateItemRestockParams) error
 	CreateMeal(ctx context.Context, arg CreateMealParams) (Meal, error)
+	CreatePantryItem(ctx context.Context, arg CreatePantryItemParams) (PantryItem, error)
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error)
+	CreatePlannedMeal(ctx context.Context, arg CreatePlannedMealParams) (PlannedMeal, error)
 	CreateRecipe(ctx context.Context, arg CreateRecipeParams) (Recipe, error)
+	CreateRecipeImport(ctx context.Context, arg CreateRecipeImportParams) (RecipeImport, error)
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
+	CreateStore(ctx context.Context, arg CreateStoreParams) (Store, error)
+	CreateStoreAisle(ctx context.Context, arg CreateStoreAisleParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeleteApiKey(ctx context.Context, id int64) error
+	DeleteCalendarFeedForUser(ctx context.Context, ownerID int64) error
+	DeleteGroceryList(ctx context.Context, id int64) error
+	DeleteGroceryListChanges(ctx context.Context, groceryListID int64) error
+	DeleteHousehold(ctx context.Context, id int64) error
+	DeleteHouseholdMember(ctx context.Context, arg DeleteHouseholdMemberParams) error
+	DeleteIngredientsForRecipe(ctx context.Context, recipeID int64) error
+	DeleteInstructionsForRecipe(ctx context.Context, recipeID int64) error
+	DeleteItem(ctx context.Context, id int64) error
+	DeleteItemRestock(ctx context.Context, itemID int64) error
+	DeleteItemsForMeal(ctx context.Context, mealID sql.NullInt64) error
+	DeleteItemsInGroceryList(ctx context.Context, groceryListID int64) error
+	DeleteMeal(ctx context.Context, id int64) error
+	DeleteMealPantryUses(ctx context.Context, mealID int64) error
+	DeleteMealPantryUsesInGroceryList(ctx context.Context, groceryListID int64) error
+	DeleteMealsInGroceryList(ctx context.Context, groceryListID int64) error
+	DeletePantryItem(ctx context.Context, id int64) error
+	DeletePlannedMeal(ctx context.Context, id int64) error
+	DeleteRecipe(ctx context.Context, id int64) error
+	DeleteRecipeImage(ctx context.Context, recipeID int64) error
+	DeleteRecipeTag(ctx context.Context, arg DeleteRecipeTagParams) (int64, error)
+	DeleteStore(ctx context.Context, id int64) error
+	DeleteStoreAisles(ctx context.Context, storeID int64) error
+	DeleteTagsForRecipe(ctx context.Context, recipeID int64) error
+	FinishRecipeImport(ctx context.Context, arg FinishRecipeImportParams) error
+	GetApiKey(ctx context.Context, id int64) (ApiKey, error)
+	GetApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	GetApiKeysForUser(ctx context.Context, ownerID int64) ([]ApiKey, error)
+	GetCalendarFeedByHash(ctx context.Context, tokenHash string) (CalendarFeed, error)
+	GetCalendarFeedForUser(ctx context.Context, ownerID int64) (CalendarFeed, error)
+	GetCanonicalIngredient(ctx context.Context, id int64) (CanonicalIngredient, error)
+	GetCanonicalIngredientBySynonym(ctx context.Context, synonym string) (CanonicalIngredient, error)
+	GetCanonicalIngredientsInGroceryList(ctx context.Context, groceryListID int64) ([]CanonicalIngredient, error)
 	GetExtendedItem(ctx context.Context, id int64) (GetExtendedItemRow, error)
 	GetExtendedItemsForGroceryList(ctx context.Context, groceryListID int64) ([]GetExtendedItemsForGroceryListRow, error)
 	GetExtendedItemsForMeal(ctx context.Context, mealID sql.NullInt64) ([]GetExtendedItemsForMealRow, error)
 	GetExtendedMeal(ctx context.Context, id int64) (GetExtendedMealRow, error)
 	GetExtendedMealsInGroceryList(ctx context.Context, groceryListID int64) ([]GetExtendedMealsInGroceryListRow, error)
+	GetExtendedPlannedMeal(ctx context.Context, id int64) (GetExtendedPlannedMealRow, error)
+	GetExtendedPlannedMealsForUser(ctx context.Context, arg GetExtendedPlannedMealsForUserParams) ([]GetExtendedPlannedMealsForUserRow, error)
 	GetGroceryList(ctx context.Context, id int64) (GroceryList, error)
-	GetGroceryListsForUser(ctx context.Context, ownerID int64) ([]GroceryList, error)
+	GetGroceryListChanges(ctx context.Context, arg GetGroceryListChangesParams) ([]GroceryListChange, error)
+	GetGroceryListsForUser(ctx context.Context, arg GetGroceryListsForUserParams) ([]GroceryList, error)
+	GetHousehold(ctx context.Context, id int64) (Household, error)
+	GetHouseholdMember(ctx context.Context, arg GetHouseholdMemberParams) (HouseholdMember, error)
+	GetHouseholdMembers(ctx context.Context, householdID int64) ([]GetHouseholdMembersRow, error)
+	GetHouseholdPantryItemForIngredient(ctx context.Context, arg GetHouseholdPantryItemForIngredientParams) (PantryItem, error)
+	GetHouseholdsForUser(ctx context.Context, userID int64) ([]GetHouseholdsForUserRow, error)
 	GetIngredient(ctx context.Context, id int64) (Ingredient, error)
 	GetIngredientsForRecipe(ctx context.Context, recipeID int64) ([]Ingredient, error)
+	GetInstructionsForRecipe(ctx context.Context, recipeID int64) ([]Instruction, error)
 	GetItem(ctx context.Context, id int64) (Item, error)
 	GetItemAndGroceryList(ctx context.Context, id int64) (GetItemAndGroceryListRow, error)
+	GetItemRestock(ctx context.Context, itemID int64) (ItemRestock, error)
 	GetItemsForGroceryList(ctx context.Context, groceryListID int64) ([]Item, error)
 	GetItemsForGroceryListByName(ctx context.Context, arg GetItemsForGroceryListByNameParams) ([]Item, error)
 	GetItemsForMeal(ctx context.Context, mealID sql.NullInt64) ([]Item, error)
+	GetLatestGroceryListChange(ctx context.Context, groceryListID int64) (int64, error)
 	GetMeal(ctx context.Context, id int64) (Meal, error)
 	GetMealsInGroceryList(ctx context.Context, groceryListID int64) ([]Meal, error)
+	GetPantryItem(ctx context.Context, id int64) (GetPantryItemRow, error)
+	GetPantryItemReserved(ctx context.Context, pantryItemID int64) (float64, error)
+	GetPantryItemsForHousehold(ctx context.Context, householdID sql.NullInt64) ([]GetPantryItemsForHouseholdRow, error)
+	GetPantryItemsForUser(ctx context.Context, ownerID sql.NullInt64) ([]GetPantryItemsForUserRow, error)
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (PasswordResetToken, error)
 	GetRecipe(ctx context.Context, id int64) (Recipe, error)
-	GetRecipesForUser(ctx context.Context, ownerID int64) ([]Recipe, error)
+	GetRecipeImage(ctx context.Context, recipeID int64) (RecipeImage, error)
+	GetRecipeImport(ctx context.Context, id int64) (RecipeImport, error)
+	GetRecipeImportsForUser(ctx context.Context, ownerID int64) ([]RecipeImport, error)
+	GetRecipeTag(ctx context.Context, arg GetRecipeTagParams) (RecipeTag, error)
+	GetRecipesForUser(ctx context.Context, arg GetRecipesForUserParams) ([]Recipe, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error)
+	GetStore(ctx context.Context, id int64) (Store, error)
+	GetStoreAisles(ctx context.Context, storeID int64) ([]StoreAisle, error)
+	GetStoresForUser(ctx context.Context, ownerID int64) ([]Store, error)
+	GetTagCountsForUser(ctx context.Context, arg GetTagCountsForUserParams) ([]GetTagCountsForUserRow, error)
+	GetTagsForRecipe(ctx context.Context, recipeID int64) ([]RecipeTag, error)
 	GetUser(ctx context.Context, id int64) (User, error)
+	GetUserByEmail(ctx context.Context, email sql.NullString) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
+	GetUserPantryItemForIngredient(ctx context.Context, arg GetUserPantryItemForIngredientParams) (PantryItem, error)
+	IncrementUserTokenVersion(ctx context.Context, arg IncrementUserTokenVersionParams) (User, error)
+	ListGroceryListsForUser(ctx context.Context, arg ListGroceryListsForUserParams) ([]GroceryList, error)
+	ListGroceryListsForUserByName(ctx context.Context, arg ListGroceryListsForUserByNameParams) ([]GroceryList, error)
+	ListGroceryListsForUserByNameDesc(ctx context.Context, arg ListGroceryListsForUserByNameDescParams) ([]GroceryList, error)
+	ListGroceryListsForUserDesc(ctx context.Context, arg ListGroceryListsForUserDescParams) ([]GroceryList, error)
+	ListItemsForGroceryList(ctx context.Context, arg ListItemsForGroceryListParams) ([]Item, error)
+	ListItemsForGroceryListByName(ctx context.Context, arg ListItemsForGroceryListByNameParams) ([]Item, error)
+	ListItemsForGroceryListByNameDesc(ctx context.Context, arg ListItemsForGroceryListByNameDescParams) ([]Item, error)
+	ListItemsForGroceryListDesc(ctx context.Context, arg ListItemsForGroceryListDescParams) ([]Item, error)
+	ListRecipesForUser(ctx context.Context, arg ListRecipesForUserParams) ([]Recipe, error)
+	ListRecipesForUserByName(ctx context.Context, arg ListRecipesForUserByNameParams) ([]Recipe, error)
+	ListRecipesForUserByNameDesc(ctx context.Context, arg ListRecipesForUserByNameDescParams) ([]Recipe, error)
+	ListRecipesForUserByTime(ctx context.Context, arg ListRecipesForUserByTimeParams) ([]Recipe, error)
+	ListRecipesForUserByTimeDesc(ctx context.Context, arg ListRecipesForUserByTimeDescParams) ([]Recipe, error)
+	ListRecipesForUserDesc(ctx context.Context, arg ListRecipesForUserDescParams) ([]Recipe, error)
+	ReleaseMealPantryUse(ctx context.Context, arg ReleaseMealPantryUseParams) error
+	RevokeRefreshToken(ctx context.Context, arg RevokeRefreshTokenParams) (int64, error)
+	RevokeRefreshTokensForUser(ctx context.Context, arg RevokeRefreshTokensForUserParams) error
+	ScaleMealPantryUses(ctx context.Context, arg ScaleMealPantryUsesParams) error
+	SearchRecipesForUser(ctx context.Context, arg SearchRecipesForUserParams) ([]SearchRecipesForUserRow, error)
+	SetApiKeyLastUsed(ctx context.Context, arg SetApiKeyLastUsedParams) error
+	SetCalendarFeedLastUsed(ctx context.Context, arg SetCalendarFeedLastUsedParams) error
+	SetGroceryListHousehold(ctx context.Context, arg SetGroceryListHouseholdParams) (GroceryList, error)
+	SetHouseholdMemberRole(ctx context.Context, arg SetHouseholdMemberRoleParams) (HouseholdMember, error)
 	SetIsComplete(ctx context.Context, arg SetIsCompleteParams) error
+	SetItemAmounts(ctx context.Context, arg SetItemAmountsParams) error
+	SetItemCategory(ctx context.Context, arg SetItemCategoryParams) (Item, error)
+	SetMealServings(ctx context.Context, arg SetMealServingsParams) (Meal, error)
+	SetPlannedMealMeal(ctx context.Context, arg SetPlannedMealMealParams) error
+	SetRecipeHousehold(ctx context.Context, arg SetRecipeHouseholdParams) (Recipe, error)
+	SetUserPassword(ctx context.Context, arg SetUserPasswordParams) error
+	TakeFromPantryItem(ctx context.Context, arg TakeFromPantryItemParams) error
+	UnlinkItemsFromRecipeIngredients(ctx context.Context, recipeID int64) error
+	UnlinkPlannedMealsFromMeal(ctx context.Context, mealID sql.NullInt64) error
+	UnlinkPlannedMealsInGroceryList(ctx context.Context, groceryListID int64) error
+	UpdatePantryItem(ctx context.Context, arg UpdatePantryItemParams) error
+	UpdatePlannedMeal(ctx context.Context, arg UpdatePlannedMealParams) (PlannedMeal, error)
+	UpdateRecipe(ctx context.Context, arg UpdateRecipeParams) (Recipe, error)
+	UpdateStore(ctx context.Context, arg UpdateStoreParams) (Store, error)
+	UpsertCalendarFeed(ctx context.Context, arg UpsertCalendarFeedParams) (CalendarFeed, error)
+	UpsertRecipeImage(ctx context.Context, arg UpsertRecipeImageParams) (RecipeImage, error)
+	UsePasswordResetToken(ctx context.Context, arg UsePasswordResetTokenParams) (int64, error)
+	UsePasswordResetTokensForUser(ctx context.Context, arg UsePasswordResetTokensForUserParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -12,20 +12,21 @@ import (
 )
 
 const createRecipe = `-- name: CreateRecipe :one
-INSERT INTO recipes(created_at, updated_at, name, description, url, prep_time, cook_time, total_time, owner_id)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, name, description, url, prep_time, cook_time, total_time, owner_id
+INSERT INTO recipes(created_at, updated_at, name, description, url, prep_time_seconds, cook_time_seconds, total_time_seconds, servings, owner_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds
 `
 
 type CreateRecipeParams struct {
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Name        string
-	Description sql.NullString
-	Url         sql.NullString
-	PrepTime    sql.NullString
-	CookTime    sql.NullString
-	TotalTime   sql.NullString
-	OwnerID     int64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Name             string
+	Description      sql.NullString
+	Url              sql.NullString
+	PrepTimeSeconds  sql.NullInt64
+	CookTimeSeconds  sql.NullInt64
+	TotalTimeSeconds sql.NullInt64
+	Servings         sql.NullInt64
+	OwnerID          int64
 }
 
 func (q *Queries) CreateRecipe(ctx context.Context, arg CreateRecipeParams) (Recipe, error) {
@@ -35,9 +36,10 @@ func (q *Queries) CreateRecipe(ctx context.Context, arg CreateRecipeParams) (Rec
 		arg.Name,
 		arg.Description,
 		arg.Url,
-		arg.PrepTime,
-		arg.CookTime,
-		arg.TotalTime,
+		arg.PrepTimeSeconds,
+		arg.CookTimeSeconds,
+		arg.TotalTimeSeconds,
+		arg.Servings,
 		arg.OwnerID,
 	)
 	var i Recipe
@@ -48,16 +50,29 @@ func (q *Queries) CreateRecipe(ctx context.Context, arg CreateRecipeParams) (Rec
 		&i.Name,
 		&i.Description,
 		&i.Url,
-		&i.PrepTime,
-		&i.CookTime,
-		&i.TotalTime,
 		&i.OwnerID,
+		&i.Servings,
+		&i.HouseholdID,
+		&i.PrepTimeSeconds,
+		&i.CookTimeSeconds,
+		&i.TotalTimeSeconds,
+		&i.TimeSeconds,
 	)
 	return i, err
 }
 
+const deleteRecipe = `-- name: DeleteRecipe :exec
+DELETE FROM recipes
+WHERE id = ?
+`
+
+func (q *Queries) DeleteRecipe(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteRecipe, id)
+	return err
+}
+
 const getRecipe = `-- name: GetRecipe :one
-SELECT id, created_at, updated_at, name, description, url, prep_time, cook_time, total_time, owner_id FROM recipes
+SELECT id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds FROM recipes
 WHERE id = ?
 `
 
@@ -71,21 +86,346 @@ func (q *Queries) GetRecipe(ctx context.Context, id int64) (Recipe, error) {
 		&i.Name,
 		&i.Description,
 		&i.Url,
-		&i.PrepTime,
-		&i.CookTime,
-		&i.TotalTime,
 		&i.OwnerID,
+		&i.Servings,
+		&i.HouseholdID,
+		&i.PrepTimeSeconds,
+		&i.CookTimeSeconds,
+		&i.TotalTimeSeconds,
+		&i.TimeSeconds,
 	)
 	return i, err
 }
 
 const getRecipesForUser = `-- name: GetRecipesForUser :many
-SELECT id, created_at, updated_at, name, description, url, prep_time, cook_time, total_time, owner_id FROM recipes
+SELECT id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds FROM recipes
 WHERE owner_id = ?
+	OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?)
+`
+
+type GetRecipesForUserParams struct {
+	OwnerID  int64
+	MemberID int64
+}
+
+func (q *Queries) GetRecipesForUser(ctx context.Context, arg GetRecipesForUserParams) ([]Recipe, error) {
+	rows, err := q.db.QueryContext(ctx, getRecipesForUser, arg.OwnerID, arg.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recipe
+	for rows.Next() {
+		var i Recipe
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Description,
+			&i.Url,
+			&i.OwnerID,
+			&i.Servings,
+			&i.HouseholdID,
+			&i.PrepTimeSeconds,
+			&i.CookTimeSeconds,
+			&i.TotalTimeSeconds,
+			&i.TimeSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesForUser = `-- name: ListRecipesForUser :many
+SELECT id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds FROM recipes
+WHERE (owner_id = ?
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(? AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(? AS TEXT)))) >= CAST(? AS INTEGER)
+	AND id > ?
+ORDER BY id
+LIMIT ?
+`
+
+type ListRecipesForUserParams struct {
+	OwnerID        int64
+	MemberID       int64
+	NameContains   string
+	CreatedAfter   time.Time
+	MaxTimeSeconds int64
+	Tags           string
+	TagsNeeded     int64
+	AfterID        int64
+	Limit          int64
+}
+
+func (q *Queries) ListRecipesForUser(ctx context.Context, arg ListRecipesForUserParams) ([]Recipe, error) {
+	rows, err := q.db.QueryContext(ctx, listRecipesForUser,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.MaxTimeSeconds,
+		arg.Tags,
+		arg.TagsNeeded,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recipe
+	for rows.Next() {
+		var i Recipe
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Description,
+			&i.Url,
+			&i.OwnerID,
+			&i.Servings,
+			&i.HouseholdID,
+			&i.PrepTimeSeconds,
+			&i.CookTimeSeconds,
+			&i.TotalTimeSeconds,
+			&i.TimeSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesForUserByName = `-- name: ListRecipesForUserByName :many
+SELECT id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds FROM recipes
+WHERE (owner_id = ?
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(? AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(? AS TEXT)))) >= CAST(? AS INTEGER)
+	AND (CAST(? AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) > (CAST(? AS TEXT), CAST(? AS INTEGER)))
+ORDER BY name COLLATE NOCASE, id
+LIMIT ?
+`
+
+type ListRecipesForUserByNameParams struct {
+	OwnerID        int64
+	MemberID       int64
+	NameContains   string
+	CreatedAfter   time.Time
+	MaxTimeSeconds int64
+	Tags           string
+	TagsNeeded     int64
+	FromStart      bool
+	AfterName      string
+	AfterID        int64
+	Limit          int64
+}
+
+func (q *Queries) ListRecipesForUserByName(ctx context.Context, arg ListRecipesForUserByNameParams) ([]Recipe, error) {
+	rows, err := q.db.QueryContext(ctx, listRecipesForUserByName,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.MaxTimeSeconds,
+		arg.Tags,
+		arg.TagsNeeded,
+		arg.FromStart,
+		arg.AfterName,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recipe
+	for rows.Next() {
+		var i Recipe
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Description,
+			&i.Url,
+			&i.OwnerID,
+			&i.Servings,
+			&i.HouseholdID,
+			&i.PrepTimeSeconds,
+			&i.CookTimeSeconds,
+			&i.TotalTimeSeconds,
+			&i.TimeSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesForUserByNameDesc = `-- name: ListRecipesForUserByNameDesc :many
+SELECT id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds FROM recipes
+WHERE (owner_id = ?
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(? AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(? AS TEXT)))) >= CAST(? AS INTEGER)
+	AND (CAST(? AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) < (CAST(? AS TEXT), CAST(? AS INTEGER)))
+ORDER BY name COLLATE NOCASE DESC, id DESC
+LIMIT ?;
+
+-- the list queries keep recipes that have at least tags_needed of the tags,
+-- which are a JSON array of distinct names
+
+-- recipes that do not say how long they take come last in both orders
+`
+
+type ListRecipesForUserByNameDescParams struct {
+	OwnerID        int64
+	MemberID       int64
+	NameContains   string
+	CreatedAfter   time.Time
+	MaxTimeSeconds int64
+	Tags           string
+	TagsNeeded     int64
+	FromStart      bool
+	BeforeName     string
+	BeforeID       int64
+	Limit          int64
+}
+
+func (q *Queries) ListRecipesForUserByNameDesc(ctx context.Context, arg ListRecipesForUserByNameDescParams) ([]Recipe, error) {
+	rows, err := q.db.QueryContext(ctx, listRecipesForUserByNameDesc,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.MaxTimeSeconds,
+		arg.Tags,
+		arg.TagsNeeded,
+		arg.FromStart,
+		arg.BeforeName,
+		arg.BeforeID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recipe
+	for rows.Next() {
+		var i Recipe
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Description,
+			&i.Url,
+			&i.OwnerID,
+			&i.Servings,
+			&i.HouseholdID,
+			&i.PrepTimeSeconds,
+			&i.CookTimeSeconds,
+			&i.TotalTimeSeconds,
+			&i.TimeSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesForUserByTime = `-- name: ListRecipesForUserByTime :many
+SELECT id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds FROM recipes
+WHERE (owner_id = ?
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(? AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(? AS TEXT)))) >= CAST(? AS INTEGER)
+	AND (CAST(? AS BOOLEAN)
+		OR (coalesce(time_seconds, 9223372036854775807), id) > (CAST(? AS INTEGER), CAST(? AS INTEGER)))
+ORDER BY coalesce(time_seconds, 9223372036854775807), id
+LIMIT ?
 `
 
-func (q *Queries) GetRecipesForUser(ctx context.Context, ownerID int64) ([]Recipe, error) {
-	rows, err := q.db.QueryContext(ctx, getRecipesForUser, ownerID)
+type ListRecipesForUserByTimeParams struct {
+	OwnerID        int64
+	MemberID       int64
+	NameContains   string
+	CreatedAfter   time.Time
+	MaxTimeSeconds int64
+	Tags           string
+	TagsNeeded     int64
+	FromStart      bool
+	AfterSeconds   int64
+	AfterID        int64
+	Limit          int64
+}
+
+func (q *Queries) ListRecipesForUserByTime(ctx context.Context, arg ListRecipesForUserByTimeParams) ([]Recipe, error) {
+	rows, err := q.db.QueryContext(ctx, listRecipesForUserByTime,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.MaxTimeSeconds,
+		arg.Tags,
+		arg.TagsNeeded,
+		arg.FromStart,
+		arg.AfterSeconds,
+		arg.AfterID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -100,10 +440,13 @@ func (q *Queries) GetRecipesForUser(ctx context.Context, ownerID int64) ([]Recip
 			&i.Name,
 			&i.Description,
 			&i.Url,
-			&i.PrepTime,
-			&i.CookTime,
-			&i.TotalTime,
 			&i.OwnerID,
+			&i.Servings,
+			&i.HouseholdID,
+			&i.PrepTimeSeconds,
+			&i.CookTimeSeconds,
+			&i.TotalTimeSeconds,
+			&i.TimeSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -117,3 +460,312 @@ func (q *Queries) GetRecipesForUser(ctx context.Context, ownerID int64) ([]Recip
 	}
 	return items, nil
 }
+
+const listRecipesForUserByTimeDesc = `-- name: ListRecipesForUserByTimeDesc :many
+SELECT id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds FROM recipes
+WHERE (owner_id = ?
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(? AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(? AS TEXT)))) >= CAST(? AS INTEGER)
+	AND (CAST(? AS BOOLEAN)
+		OR (coalesce(time_seconds, -1), id) < (CAST(? AS INTEGER), CAST(? AS INTEGER)))
+ORDER BY coalesce(time_seconds, -1) DESC, id DESC
+LIMIT ?
+`
+
+type ListRecipesForUserByTimeDescParams struct {
+	OwnerID        int64
+	MemberID       int64
+	NameContains   string
+	CreatedAfter   time.Time
+	MaxTimeSeconds int64
+	Tags           string
+	TagsNeeded     int64
+	FromStart      bool
+	BeforeSeconds  int64
+	BeforeID       int64
+	Limit          int64
+}
+
+func (q *Queries) ListRecipesForUserByTimeDesc(ctx context.Context, arg ListRecipesForUserByTimeDescParams) ([]Recipe, error) {
+	rows, err := q.db.QueryContext(ctx, listRecipesForUserByTimeDesc,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.MaxTimeSeconds,
+		arg.Tags,
+		arg.TagsNeeded,
+		arg.FromStart,
+		arg.BeforeSeconds,
+		arg.BeforeID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recipe
+	for rows.Next() {
+		var i Recipe
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Description,
+			&i.Url,
+			&i.OwnerID,
+			&i.Servings,
+			&i.HouseholdID,
+			&i.PrepTimeSeconds,
+			&i.CookTimeSeconds,
+			&i.TotalTimeSeconds,
+			&i.TimeSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipesForUserDesc = `-- name: ListRecipesForUserDesc :many
+SELECT id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds FROM recipes
+WHERE (owner_id = ?
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+	AND instr(lower(name), lower(CAST(? AS TEXT))) > 0
+	AND created_at > ?
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(? AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(? AS TEXT)))) >= CAST(? AS INTEGER)
+	AND id < ?
+ORDER BY id DESC
+LIMIT ?
+`
+
+type ListRecipesForUserDescParams struct {
+	OwnerID        int64
+	MemberID       int64
+	NameContains   string
+	CreatedAfter   time.Time
+	MaxTimeSeconds int64
+	Tags           string
+	TagsNeeded     int64
+	BeforeID       int64
+	Limit          int64
+}
+
+func (q *Queries) ListRecipesForUserDesc(ctx context.Context, arg ListRecipesForUserDescParams) ([]Recipe, error) {
+	rows, err := q.db.QueryContext(ctx, listRecipesForUserDesc,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.NameContains,
+		arg.CreatedAfter,
+		arg.MaxTimeSeconds,
+		arg.Tags,
+		arg.TagsNeeded,
+		arg.BeforeID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recipe
+	for rows.Next() {
+		var i Recipe
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Description,
+			&i.Url,
+			&i.OwnerID,
+			&i.Servings,
+			&i.HouseholdID,
+			&i.PrepTimeSeconds,
+			&i.CookTimeSeconds,
+			&i.TotalTimeSeconds,
+			&i.TimeSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchRecipesForUser = `-- name: SearchRecipesForUser :many
+SELECT recipes.id, recipes.created_at, recipes.updated_at, recipes.name, recipes.description, recipes.url, recipes.owner_id, recipes.servings, recipes.household_id, recipes.prep_time_seconds, recipes.cook_time_seconds, recipes.total_time_seconds, recipes.time_seconds, highlight(recipe_search, 0, char(2), char(3)) AS name_highlight, snippet(recipe_search, 1, char(2), char(3), '…', 16) AS description_snippet, snippet(recipe_search, 2, char(2), char(3), '…', 16) AS ingredients_snippet, bm25(recipe_search, 10.0, 2.0, 5.0) AS rank
+FROM recipe_search
+JOIN recipes ON recipes.id = recipe_search.rowid
+WHERE recipe_search MATCH ?
+	AND (recipes.owner_id = ?
+		OR recipes.household_id IN (SELECT household_id FROM household_members WHERE user_id = ?))
+ORDER BY rank
+LIMIT ?
+`
+
+type SearchRecipesForUserParams struct {
+	Query    string
+	OwnerID  int64
+	MemberID int64
+	Limit    int64
+}
+
+type SearchRecipesForUserRow struct {
+	Recipe             Recipe
+	NameHighlight      string
+	DescriptionSnippet string
+	IngredientsSnippet string
+	Rank               float64
+}
+
+func (q *Queries) SearchRecipesForUser(ctx context.Context, arg SearchRecipesForUserParams) ([]SearchRecipesForUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchRecipesForUser,
+		arg.Query,
+		arg.OwnerID,
+		arg.MemberID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchRecipesForUserRow
+	for rows.Next() {
+		var i SearchRecipesForUserRow
+		if err := rows.Scan(
+			&i.Recipe.ID,
+			&i.Recipe.CreatedAt,
+			&i.Recipe.UpdatedAt,
+			&i.Recipe.Name,
+			&i.Recipe.Description,
+			&i.Recipe.Url,
+			&i.Recipe.OwnerID,
+			&i.Recipe.Servings,
+			&i.Recipe.HouseholdID,
+			&i.Recipe.PrepTimeSeconds,
+			&i.Recipe.CookTimeSeconds,
+			&i.Recipe.TotalTimeSeconds,
+			&i.Recipe.TimeSeconds,
+			&i.NameHighlight,
+			&i.DescriptionSnippet,
+			&i.IngredientsSnippet,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setRecipeHousehold = `-- name: SetRecipeHousehold :one
+UPDATE recipes
+SET updated_at = ?, household_id = ?
+WHERE id = ?
+RETURNING id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds
+`
+
+type SetRecipeHouseholdParams struct {
+	UpdatedAt   time.Time
+	HouseholdID sql.NullInt64
+	ID          int64
+}
+
+func (q *Queries) SetRecipeHousehold(ctx context.Context, arg SetRecipeHouseholdParams) (Recipe, error) {
+	row := q.db.QueryRowContext(ctx, setRecipeHousehold, arg.UpdatedAt, arg.HouseholdID, arg.ID)
+	var i Recipe
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Description,
+		&i.Url,
+		&i.OwnerID,
+		&i.Servings,
+		&i.HouseholdID,
+		&i.PrepTimeSeconds,
+		&i.CookTimeSeconds,
+		&i.TotalTimeSeconds,
+		&i.TimeSeconds,
+	)
+	return i, err
+}
+
+const updateRecipe = `-- name: UpdateRecipe :one
+UPDATE recipes
+SET updated_at = ?, name = ?, description = ?, url = ?, prep_time_seconds = ?, cook_time_seconds = ?, total_time_seconds = ?, servings = ?
+WHERE id = ?
+RETURNING id, created_at, updated_at, name, description, url, owner_id, servings, household_id, prep_time_seconds, cook_time_seconds, total_time_seconds, time_seconds
+`
+
+type UpdateRecipeParams struct {
+	UpdatedAt        time.Time
+	Name             string
+	Description      sql.NullString
+	Url              sql.NullString
+	PrepTimeSeconds  sql.NullInt64
+	CookTimeSeconds  sql.NullInt64
+	TotalTimeSeconds sql.NullInt64
+	Servings         sql.NullInt64
+	ID               int64
+}
+
+func (q *Queries) UpdateRecipe(ctx context.Context, arg UpdateRecipeParams) (Recipe, error) {
+	row := q.db.QueryRowContext(ctx, updateRecipe,
+		arg.UpdatedAt,
+		arg.Name,
+		arg.Description,
+		arg.Url,
+		arg.PrepTimeSeconds,
+		arg.CookTimeSeconds,
+		arg.TotalTimeSeconds,
+		arg.Servings,
+		arg.ID,
+	)
+	var i Recipe
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Description,
+		&i.Url,
+		&i.OwnerID,
+		&i.Servings,
+		&i.HouseholdID,
+		&i.PrepTimeSeconds,
+		&i.CookTimeSeconds,
+		&i.TotalTimeSeconds,
+		&i.TimeSeconds,
+	)
+	return i, err
+}
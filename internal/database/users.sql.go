@@ -12,8 +12,8 @@ import (
 )
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (created_at, updated_at, username, hashed_password, first_name, last_name)
-VALUES (?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, username, hashed_password, first_name, last_name
+INSERT INTO users (created_at, updated_at, username, hashed_password, first_name, last_name, email)
+VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, username, hashed_password, first_name, last_name, token_version, email
 `
 
 type CreateUserParams struct {
@@ -23,6 +23,7 @@ type CreateUserParams struct {
 	HashedPassword string
 	FirstName      sql.NullString
 	LastName       sql.NullString
+	Email          sql.NullString
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -33,6 +34,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.HashedPassword,
 		arg.FirstName,
 		arg.LastName,
+		arg.Email,
 	)
 	var i User
 	err := row.Scan(
@@ -43,12 +45,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.HashedPassword,
 		&i.FirstName,
 		&i.LastName,
+		&i.TokenVersion,
+		&i.Email,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, created_at, updated_at, username, hashed_password, first_name, last_name FROM users
+SELECT id, created_at, updated_at, username, hashed_password, first_name, last_name, token_version, email FROM users
 WHERE id = ?
 `
 
@@ -63,12 +67,36 @@ func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
 		&i.HashedPassword,
 		&i.FirstName,
 		&i.LastName,
+		&i.TokenVersion,
+		&i.Email,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, created_at, updated_at, username, hashed_password, first_name, last_name, token_version, email FROM users
+WHERE email = ?
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email sql.NullString) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Username,
+		&i.HashedPassword,
+		&i.FirstName,
+		&i.LastName,
+		&i.TokenVersion,
+		&i.Email,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, created_at, updated_at, username, hashed_password, first_name, last_name FROM users
+SELECT id, created_at, updated_at, username, hashed_password, first_name, last_name, token_version, email FROM users
 WHERE username = ?
 `
 
@@ -83,6 +111,52 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.HashedPassword,
 		&i.FirstName,
 		&i.LastName,
+		&i.TokenVersion,
+		&i.Email,
+	)
+	return i, err
+}
+
+const incrementUserTokenVersion = `-- name: IncrementUserTokenVersion :one
+UPDATE users SET updated_at = ?, token_version = token_version + 1
+WHERE id = ?
+RETURNING id, created_at, updated_at, username, hashed_password, first_name, last_name, token_version, email
+`
+
+type IncrementUserTokenVersionParams struct {
+	UpdatedAt time.Time
+	ID        int64
+}
+
+func (q *Queries) IncrementUserTokenVersion(ctx context.Context, arg IncrementUserTokenVersionParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, incrementUserTokenVersion, arg.UpdatedAt, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Username,
+		&i.HashedPassword,
+		&i.FirstName,
+		&i.LastName,
+		&i.TokenVersion,
+		&i.Email,
 	)
 	return i, err
 }
+
+const setUserPassword = `-- name: SetUserPassword :exec
+UPDATE users SET updated_at = ?, hashed_password = ?
+WHERE id = ?
+`
+
+type SetUserPasswordParams struct {
+	UpdatedAt      time.Time
+	HashedPassword string
+	ID             int64
+}
+
+func (q *Queries) SetUserPassword(ctx context.Context, arg SetUserPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, setUserPassword, arg.UpdatedAt, arg.HashedPassword, arg.ID)
+	return err
+}
@@ -0,0 +1,126 @@
+// Package dbconn opens SQLite databases with foreign key constraints enforced.
+// SQLite only enforces them on connections that turn them on, and libsql
+// connections over HTTP start a new stream, with the default settings, every
+// time they are reused, so they are turned on for each session.
+package dbconn
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+const enableForeignKeys = "PRAGMA foreign_keys = ON"
+
+// Open opens a database like sql.Open, with foreign keys enforced on every
+// connection.
+func Open(driverName string, dataSourceName string) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	d := db.Driver()
+	if err := db.Close(); err != nil {
+		return nil, err
+	}
+
+	return sql.OpenDB(connector{driver: d, dsn: dataSourceName}), nil
+}
+
+type connector struct {
+	driver driver.Driver
+	dsn    string
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	inner, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	cn := &conn{Conn: inner}
+	if err := cn.enableForeignKeys(ctx); err != nil {
+		inner.Close()
+		return nil, err
+	}
+
+	return cn, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// conn passes everything through to the driver's connection, and turns
+// foreign keys back on whenever the session is reset.
+type conn struct {
+	driver.Conn
+}
+
+func (c *conn) enableForeignKeys(ctx context.Context) error {
+	_, err := c.ExecContext(ctx, enableForeignKeys, nil)
+	if !errors.Is(err, driver.ErrSkip) {
+		return err
+	}
+
+	// drivers that cannot execute directly have the statement prepared
+	stmt, err := c.Conn.Prepare(enableForeignKeys)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(nil)
+	return err
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		if err := resetter.ResetSession(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := c.enableForeignKeys(ctx); err != nil {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
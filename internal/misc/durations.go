@@ -0,0 +1,101 @@
+package misc
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidISO8601Duration = errors.New("invalid ISO 8601 duration")
+
+// ParseISO8601Duration parses durations such as "PT1H30M" or "P1DT2H". Years,
+// months and weeks are only accepted when they are zero, as in the
+// "P0Y0M0DT1H" some sites write, since their length is not fixed. Only the
+// seconds may have a fractional part.
+func ParseISO8601Duration(s string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(strings.ToUpper(strings.TrimSpace(s)), "P")
+	if !ok || rest == "" {
+		return 0, ErrInvalidISO8601Duration
+	}
+
+	var d time.Duration
+	inTime := false
+
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime {
+				return 0, ErrInvalidISO8601Duration
+			}
+			inTime = true
+			rest = rest[1:]
+			if rest == "" {
+				return 0, ErrInvalidISO8601Duration
+			}
+			continue
+		}
+
+		end := strings.IndexAny(rest, "YMWDHS")
+		if end <= 0 || strings.Trim(rest[:end], "0123456789.") != "" {
+			return 0, ErrInvalidISO8601Duration
+		}
+
+		value, err := strconv.ParseFloat(rest[:end], 64)
+		if err != nil {
+			return 0, ErrInvalidISO8601Duration
+		}
+
+		var unit time.Duration
+		switch {
+		case strings.IndexByte("YMW", rest[end]) >= 0 && !inTime && value == 0:
+			unit = 0
+		case rest[end] == 'D' && !inTime:
+			unit = 24 * time.Hour
+		case rest[end] == 'H' && inTime:
+			unit = time.Hour
+		case rest[end] == 'M' && inTime:
+			unit = time.Minute
+		case rest[end] == 'S' && inTime:
+			unit = time.Second
+		default:
+			return 0, ErrInvalidISO8601Duration
+		}
+
+		if unit != time.Second && value != float64(int64(value)) {
+			return 0, ErrInvalidISO8601Duration
+		}
+
+		if value*float64(unit) >= float64(math.MaxInt64-d) {
+			return 0, ErrInvalidISO8601Duration
+		}
+
+		d += time.Duration(value * float64(unit))
+		rest = rest[end+1:]
+	}
+
+	return d, nil
+}
+
+// FormatISO8601Duration writes a duration as ISO 8601, like "PT1H30M", in whole
+// seconds. Hours are not carried into days, since a day is not always 24 hours
+// long.
+func FormatISO8601Duration(d time.Duration) string {
+	seconds := int64(d.Round(time.Second) / time.Second)
+	if seconds <= 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	b.WriteString("PT")
+	if h := seconds / 3600; h > 0 {
+		b.WriteString(strconv.FormatInt(h, 10) + "H")
+	}
+	if m := seconds % 3600 / 60; m > 0 {
+		b.WriteString(strconv.FormatInt(m, 10) + "M")
+	}
+	if s := seconds % 60; s > 0 {
+		b.WriteString(strconv.FormatInt(s, 10) + "S")
+	}
+	return b.String()
+}
@@ -0,0 +1,115 @@
+package misc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISO8601Duration(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{s: "PT1H30M", want: 90 * time.Minute},
+		{s: "PT45M", want: 45 * time.Minute},
+		{s: "PT20S", want: 20 * time.Second},
+		{s: "PT1H2M3S", want: time.Hour + 2*time.Minute + 3*time.Second},
+		{s: "PT1.5S", want: 1500 * time.Millisecond},
+		{s: "PT0S", want: 0},
+		{s: "pt20m", want: 20 * time.Minute},
+		{s: " PT5M ", want: 5 * time.Minute},
+		{s: "P1D", want: 24 * time.Hour},
+		{s: "P1DT2H", want: 26 * time.Hour},
+		{s: "P30D", want: 30 * 24 * time.Hour},
+		{s: "P31D", want: 31 * 24 * time.Hour},
+		{s: "P0Y0M0DT1H", want: time.Hour},
+		{s: "P0Y0M0DT0H30M", want: 30 * time.Minute},
+		{s: "P0W", want: 0},
+		{s: "P0MT1M", want: time.Minute},
+
+		{s: "", wantErr: true},
+		{s: "P", wantErr: true},
+		{s: "PT", wantErr: true},
+		{s: "P1DT", wantErr: true},
+		{s: "1H30M", wantErr: true},
+		{s: "P1Y", wantErr: true},
+		{s: "P1M", wantErr: true},
+		{s: "P2W", wantErr: true},
+		{s: "P1Y2M3DT4H", wantErr: true},
+		{s: "PT1Y", wantErr: true},
+		{s: "PT1W", wantErr: true},
+		{s: "PT1D", wantErr: true},
+		{s: "P1H", wantErr: true},
+		{s: "P1S", wantErr: true},
+		{s: "PT1.5H", wantErr: true},
+		{s: "PT1.5M", wantErr: true},
+		{s: "P1.5D", wantErr: true},
+		{s: "PT1HT2M", wantErr: true},
+		{s: "PTH", wantErr: true},
+		{s: "PT-1H", wantErr: true},
+		{s: "PT1E3S", wantErr: true},
+		{s: "PTNANS", wantErr: true},
+		{s: "PT99999999999H", wantErr: true},
+		{s: "1h30m0s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseISO8601Duration(tt.s)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseISO8601Duration(%q) = %v, want error", tt.s, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseISO8601Duration(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+}
+
+func TestFormatISO8601Duration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "PT0S"},
+		{-time.Minute, "PT0S"},
+		{400 * time.Millisecond, "PT0S"},
+		{1500 * time.Millisecond, "PT2S"},
+		{20 * time.Second, "PT20S"},
+		{45 * time.Minute, "PT45M"},
+		{90 * time.Minute, "PT1H30M"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "PT1H2M3S"},
+		{26 * time.Hour, "PT26H"},
+		{30 * 24 * time.Hour, "PT720H"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatISO8601Duration(tt.d); got != tt.want {
+			t.Errorf("FormatISO8601Duration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestISO8601DurationRoundTrip(t *testing.T) {
+	durations := []time.Duration{
+		0,
+		time.Second,
+		59 * time.Second,
+		time.Minute,
+		time.Hour,
+		time.Hour + time.Second,
+		90 * time.Minute,
+		26*time.Hour + 3*time.Minute + 4*time.Second,
+		30 * 24 * time.Hour,
+	}
+
+	for _, d := range durations {
+		s := FormatISO8601Duration(d)
+		got, err := ParseISO8601Duration(s)
+		if err != nil || got != d {
+			t.Errorf("ParseISO8601Duration(FormatISO8601Duration(%v) = %q) = %v, %v", d, s, got, err)
+		}
+	}
+}
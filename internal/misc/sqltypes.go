@@ -1,6 +1,9 @@
 package misc
 
-import "database/sql"
+import (
+	"database/sql"
+	"time"
+)
 
 func SqlNullStringFromOkString(s string, ok bool) sql.NullString {
 	return sql.NullString{
@@ -8,3 +11,23 @@ func SqlNullStringFromOkString(s string, ok bool) sql.NullString {
 		Valid:  ok,
 	}
 }
+
+func SqlNullStringFromString(s string) sql.NullString {
+	return sql.NullString{
+		String: s,
+		Valid:  s != "",
+	}
+}
+
+func SqlNullInt64FromPositiveInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{
+		Int64: i,
+		Valid: i > 0,
+	}
+}
+
+// SqlNullInt64FromDuration stores a duration as whole seconds, NULL unless it
+// lasts at least half a second.
+func SqlNullInt64FromDuration(d time.Duration) sql.NullInt64 {
+	return SqlNullInt64FromPositiveInt64(int64(d.Round(time.Second) / time.Second))
+}
@@ -0,0 +1,24 @@
+package misc
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// IsWebURL reports whether s is an absolute http or https url without control
+// characters, the only kind of url recipes link to. Since such urls cannot
+// contain line breaks, they are safe to write into line based formats such as
+// iCalendar.
+func IsWebURL(s string) bool {
+	if strings.IndexFunc(s, unicode.IsControl) >= 0 {
+		return false
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,28 @@
+package misc
+
+import "testing"
+
+func TestIsWebURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://example.com/recipes/soup", true},
+		{"http://example.com/soup?servings=4#steps", true},
+		{"", false},
+		{"example.com/soup", false},
+		{"/recipes/soup", false},
+		{"ftp://example.com/soup", false},
+		{"javascript:alert(1)", false},
+		{"https:///soup", false},
+		{"https://example.com/soup\r\nEND:VEVENT", false},
+		{"https://example.com/soup\nBEGIN:VEVENT", false},
+		{"https://example.com/\x00soup", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsWebURL(tt.url); got != tt.want {
+			t.Errorf("IsWebURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
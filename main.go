@@ -1,14 +1,22 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"log"
+	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/snorman7384/recipe-wizard/api"
+	"github.com/snorman7384/recipe-wizard/blobstore"
 	"github.com/snorman7384/recipe-wizard/domain"
+	"github.com/snorman7384/recipe-wizard/events"
 	"github.com/snorman7384/recipe-wizard/ingparse"
+	"github.com/snorman7384/recipe-wizard/internal/dbconn"
+	"github.com/snorman7384/recipe-wizard/mailer"
+	"github.com/snorman7384/recipe-wizard/recscrape"
 	_ "github.com/tursodatabase/libsql-client-go/libsql"
 )
 
@@ -31,7 +39,7 @@ func main() {
 		log.Fatal("Could not load database url")
 	}
 
-	db, err := sql.Open("libsql", dbUrl)
+	db, err := dbconn.Open("libsql", dbUrl)
 
 	if err != nil {
 		log.Fatal("Could not open database connection")
@@ -43,14 +51,84 @@ func main() {
 		log.Fatal("Could not locate JWT secret")
 	}
 
+	var mail mailer.Mailer
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		smtpPort := 587
+		if s := os.Getenv("SMTP_PORT"); s != "" {
+			smtpPort, err = strconv.Atoi(s)
+			if err != nil {
+				log.Fatal("SMTP_PORT must be an integer")
+			}
+		}
+		mail = mailer.SMTPMailer{
+			Host:     smtpHost,
+			Port:     smtpPort,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("MAIL_FROM"),
+		}
+	} else if mailLog := os.Getenv("MAIL_LOG_FILE"); mailLog != "" {
+		f, err := os.OpenFile(mailLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			log.Fatal("Could not open mail log file")
+		}
+		defer f.Close()
+		mail = &mailer.LogMailer{Out: f, From: os.Getenv("MAIL_FROM")}
+	} else {
+		log.Println("SMTP_HOST is not set, emails will be written to the log")
+		mail = &mailer.LogMailer{Out: os.Stderr, From: os.Getenv("MAIL_FROM")}
+	}
+
+	var blobs blobstore.BlobStore
+	if bucket := os.Getenv("S3_BUCKET"); bucket != "" {
+		endpoint := os.Getenv("S3_ENDPOINT")
+		if endpoint == "" {
+			log.Fatal("S3_ENDPOINT must be set along with S3_BUCKET")
+		}
+		region := os.Getenv("S3_REGION")
+		if region == "" {
+			region = "us-east-1"
+		}
+		blobs = blobstore.S3Store{
+			Endpoint:        endpoint,
+			Region:          region,
+			Bucket:          bucket,
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			Client:          &http.Client{Timeout: 30 * time.Second},
+		}
+	} else {
+		blobDir := os.Getenv("BLOB_DIR")
+		if blobDir == "" {
+			blobDir = "blobs"
+		}
+		log.Println("S3_BUCKET is not set, recipe images will be stored in", blobDir)
+		blobs = blobstore.LocalStore{Dir: blobDir}
+	}
+
 	c := api.Config{
 		Domain: domain.Config{
 			DB:               db,
 			IngredientParser: ingparse.SchollzParser{},
+			RecipeScraper:    recscrape.GoRecipeScraper{Fetcher: &recscrape.Fetcher{}},
+			Mailer:           mail,
+			PasswordResetURL: os.Getenv("PASSWORD_RESET_URL"),
+			Events:           &events.Broker{},
+			Blobs:            blobs,
 		},
 		JwtSecret: []byte(jwtSecret),
 		Port:      port,
 	}
 
+	importWorkers := 4
+	if s := os.Getenv("IMPORT_WORKERS"); s != "" {
+		importWorkers, err = strconv.Atoi(s)
+		if err != nil || importWorkers < 1 {
+			log.Fatal("IMPORT_WORKERS must be a positive integer")
+		}
+	}
+
+	go c.Domain.RunRecipeImportWorkers(context.Background(), importWorkers, 5*time.Second)
+
 	c.Serve()
 }
@@ -0,0 +1,215 @@
+package recscrape
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrUnsupportedScheme = errors.New("only http and https urls can be fetched")
+	ErrForbiddenAddress  = errors.New("url resolves to an address that may not be fetched")
+	ErrTooManyRedirects  = errors.New("url redirected too many times")
+	ErrResponseTooLarge  = errors.New("response body is too large")
+	ErrFetchTimeout      = errors.New("fetching the url timed out")
+	ErrUnexpectedStatus  = errors.New("url responded with an unexpected status")
+)
+
+const (
+	DefaultFetchTimeout = 10 * time.Second
+	DefaultMaxBodySize  = 5 << 20
+	DefaultMaxRedirects = 5
+)
+
+// deniedPrefixes are address ranges that are not reachable on the public
+// internet, on top of the loopback, private, link-local, multicast and
+// unspecified ranges checked by deniedAddr.
+var deniedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, which can reach IPv4 private ranges
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local-use NAT64
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+}
+
+func deniedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return true
+	}
+
+	for _, prefix := range deniedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Fetcher fetches pages from user supplied urls. Every connection is checked
+// against the denied address ranges after DNS resolution, so a hostname
+// cannot be used to reach internal services. The zero value uses the
+// default limits. A Fetcher must not be copied after first use.
+type Fetcher struct {
+	Timeout      time.Duration // for the whole fetch, including redirects
+	MaxBodySize  int64         // in bytes
+	MaxRedirects int
+
+	once   sync.Once
+	client *http.Client
+}
+
+func (f *Fetcher) timeout() time.Duration {
+	if f.Timeout <= 0 {
+		return DefaultFetchTimeout
+	}
+	return f.Timeout
+}
+
+func (f *Fetcher) maxBodySize() int64 {
+	if f.MaxBodySize <= 0 {
+		return DefaultMaxBodySize
+	}
+	return f.MaxBodySize
+}
+
+func (f *Fetcher) maxRedirects() int {
+	if f.MaxRedirects <= 0 {
+		return DefaultMaxRedirects
+	}
+	return f.MaxRedirects
+}
+
+func (f *Fetcher) newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: f.timeout(),
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || deniedAddr(addrPort.Addr()) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		},
+	}
+
+	transport := &http.Transport{
+		// a proxy would make the connection, so its address is the only one checked
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   f.timeout(),
+		ResponseHeaderTimeout: f.timeout(),
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > f.maxRedirects() {
+				return ErrTooManyRedirects
+			}
+			return checkScheme(req.URL)
+		},
+	}
+}
+
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrUnsupportedScheme
+	}
+	return nil
+}
+
+// Fetch returns the body of the page at rawURL.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	body, _, err := f.fetch(ctx, rawURL, "text/html,application/xhtml+xml", f.maxBodySize())
+	return body, err
+}
+
+// FetchImage returns the image at rawURL and the content type it was served
+// with, which may be empty or wrong. Images are limited to maxSize bytes
+// rather than MaxBodySize, since they are usually larger than pages.
+func (f *Fetcher) FetchImage(ctx context.Context, rawURL string, maxSize int64) ([]byte, string, error) {
+	return f.fetch(ctx, rawURL, "image/avif,image/webp,image/png,image/jpeg,image/*;q=0.8", maxSize)
+}
+
+func (f *Fetcher) fetch(ctx context.Context, rawURL string, accept string, maxSize int64) ([]byte, string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := checkScheme(u); err != nil {
+		return nil, "", err
+	}
+	if u.Hostname() == "" {
+		return nil, "", fmt.Errorf("url has no host: %s", rawURL)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.timeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", accept)
+
+	f.once.Do(func() { f.client = f.newClient() })
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return nil, "", f.fetchError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, "", fmt.Errorf("%w: %s", ErrUnexpectedStatus, res.Status)
+	}
+
+	if res.ContentLength > maxSize {
+		return nil, "", ErrResponseTooLarge
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxSize+1))
+	if err != nil {
+		return nil, "", f.fetchError(ctx, err)
+	}
+	if int64(len(body)) > maxSize {
+		return nil, "", ErrResponseTooLarge
+	}
+
+	return body, res.Header.Get("Content-Type"), nil
+}
+
+func (f *Fetcher) fetchError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrFetchTimeout
+	}
+
+	for _, e := range []error{ErrForbiddenAddress, ErrTooManyRedirects, ErrUnsupportedScheme} {
+		if errors.Is(err, e) {
+			return e
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,127 @@
+package recscrape
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kkyr/go-recipe"
+	gorecipe "github.com/kkyr/go-recipe/pkg/recipe"
+)
+
+// Recipe is the data a scraper extracts from a recipe page. Fields that could
+// not be found are left as their zero value.
+type Recipe struct {
+	Name         string
+	Description  string
+	Yield        string // as written, e.g. "4 servings"
+	PrepTime     time.Duration
+	CookTime     time.Duration
+	TotalTime    time.Duration
+	Ingredients  []string // one ingredient per line
+	Instructions []string // one step per line, in order
+	Categories   []string // e.g. "Dessert"
+	Cuisines     []string // e.g. "Italian"
+	Keywords     []string
+	ImageURL     string // as written, so it may be relative to the page
+}
+
+type RecipeScraper interface {
+	// ScrapeURL fetches the page at url and extracts the recipe from it.
+	ScrapeURL(ctx context.Context, url string) (Recipe, error)
+	// ScrapeHTML extracts the recipe from an HTML page that has already been
+	// fetched. The url may be empty; when given, it is used to pick a scraper
+	// specific to the site.
+	ScrapeHTML(url string, html []byte) (Recipe, error)
+	// ScrapeJSONLD extracts the recipe from a schema.org Recipe JSON-LD
+	// document.
+	ScrapeJSONLD(doc []byte) (Recipe, error)
+	// FetchImage fetches the image of a recipe, of at most maxSize bytes, and
+	// returns it with the content type it was served with.
+	FetchImage(ctx context.Context, url string, maxSize int64) ([]byte, string, error)
+}
+
+// GoRecipeScraper scrapes recipes with github.com/kkyr/go-recipe, which
+// understands schema.org recipe data as well as a few sites that lack it.
+//
+// go-recipe fetches the schema.org context to process JSON-LD, so documents
+// that are already at hand are read without it where possible. Pages are
+// fetched by the Fetcher, or with the default limits when it is nil.
+type GoRecipeScraper struct {
+	Fetcher *Fetcher
+}
+
+var defaultFetcher = &Fetcher{}
+
+func (s GoRecipeScraper) fetcher() *Fetcher {
+	if s.Fetcher == nil {
+		return defaultFetcher
+	}
+	return s.Fetcher
+}
+
+func (s GoRecipeScraper) ScrapeURL(ctx context.Context, url string) (Recipe, error) {
+	html, err := s.fetcher().Fetch(ctx, url)
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	return s.ScrapeHTML(url, html)
+}
+
+func (s GoRecipeScraper) ScrapeHTML(url string, html []byte) (Recipe, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(html))
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	var found *Recipe
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, sel *goquery.Selection) bool {
+		r, err := recipeFromJSONLD([]byte(sel.Text()))
+		if err != nil {
+			return true
+		}
+		found = &r
+		return false
+	})
+	if found != nil {
+		return *found, nil
+	}
+
+	scraper, err := gorecipe.ScrapeHTML(url, bytes.NewReader(html))
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	return s.convertRecipe(scraper), nil
+}
+
+func (s GoRecipeScraper) ScrapeJSONLD(doc []byte) (Recipe, error) {
+	return recipeFromJSONLD(doc)
+}
+
+func (s GoRecipeScraper) FetchImage(ctx context.Context, url string, maxSize int64) ([]byte, string, error) {
+	return s.fetcher().FetchImage(ctx, url, maxSize)
+}
+
+func (s GoRecipeScraper) convertRecipe(scraper recipe.Scraper) Recipe {
+	var r Recipe
+
+	r.Name, _ = scraper.Name()
+	r.Description, _ = scraper.Description()
+	r.Yield, _ = scraper.Yields()
+	r.PrepTime, _ = scraper.PrepTime()
+	r.CookTime, _ = scraper.CookTime()
+	r.TotalTime, _ = scraper.TotalTime()
+	r.Ingredients, _ = scraper.Ingredients()
+	r.Instructions, _ = scraper.Instructions()
+	r.Categories, _ = scraper.Categories()
+	r.Cuisines, _ = scraper.Cuisine()
+	r.ImageURL, _ = scraper.ImageURL()
+
+	r.Name = strings.TrimSpace(r.Name)
+
+	return r
+}
@@ -0,0 +1,212 @@
+package recscrape
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/snorman7384/recipe-wizard/internal/misc"
+)
+
+var ErrNoRecipe = errors.New("no schema.org Recipe found in document")
+
+// recipeFromJSONLD extracts a Recipe from a schema.org JSON-LD document. The
+// document may be a Recipe node, an array of nodes or a graph that contains
+// one. The schema.org context is assumed rather than fetched, so properties
+// are read by the names they are written with.
+func recipeFromJSONLD(doc []byte) (Recipe, error) {
+	var v any
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return Recipe{}, err
+	}
+
+	node, ok := findRecipeNode(v)
+	if !ok {
+		return Recipe{}, ErrNoRecipe
+	}
+
+	var r Recipe
+
+	r.Name = schemaText(node["name"])
+	r.Description = schemaText(node["description"])
+	r.Yield = schemaYield(node["recipeYield"])
+	r.PrepTime, _ = misc.ParseISO8601Duration(schemaText(node["prepTime"]))
+	r.CookTime, _ = misc.ParseISO8601Duration(schemaText(node["cookTime"]))
+	r.TotalTime, _ = misc.ParseISO8601Duration(schemaText(node["totalTime"]))
+
+	ingredients, ok := node["recipeIngredient"]
+	if !ok {
+		// the property was called "ingredients" in older versions of the schema
+		ingredients = node["ingredients"]
+	}
+	for _, ingredient := range asArray(ingredients) {
+		if line := schemaText(ingredient); line != "" {
+			r.Ingredients = append(r.Ingredients, line)
+		}
+	}
+
+	r.Instructions = schemaInstructions(node["recipeInstructions"])
+
+	r.Categories = schemaList(node["recipeCategory"])
+	r.Cuisines = schemaList(node["recipeCuisine"])
+	r.Keywords = schemaList(node["keywords"])
+	r.ImageURL = schemaImage(node["image"])
+
+	return r, nil
+}
+
+func findRecipeNode(v any) (map[string]any, bool) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			if node, ok := findRecipeNode(item); ok {
+				return node, true
+			}
+		}
+	case map[string]any:
+		for _, t := range asArray(v["@type"]) {
+			if isRecipeType(t) {
+				return v, true
+			}
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findRecipeNode(graph)
+		}
+	}
+	return nil, false
+}
+
+func isRecipeType(t any) bool {
+	s, ok := t.(string)
+	if !ok {
+		return false
+	}
+	s = strings.TrimPrefix(s, "http://schema.org/")
+	s = strings.TrimPrefix(s, "https://schema.org/")
+	s = strings.TrimPrefix(s, "schema:")
+	return s == "Recipe"
+}
+
+func asArray(v any) []any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	default:
+		return []any{v}
+	}
+}
+
+// schemaText returns the text of a property value, which may be a plain
+// string, a value object or a list of either.
+func schemaText(v any) string {
+	switch v := v.(type) {
+	case string:
+		return cleanText(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		for _, item := range v {
+			if s := schemaText(item); s != "" {
+				return s
+			}
+		}
+	case map[string]any:
+		if value, ok := v["@value"]; ok {
+			return schemaText(value)
+		}
+	}
+	return ""
+}
+
+// schemaList returns the values of a property that may be given as a list, or
+// as one string of comma separated values, as keywords usually are.
+func schemaList(v any) []string {
+	var values []string
+	for _, item := range asArray(v) {
+		if node, ok := item.(map[string]any); ok && node["@value"] == nil {
+			// a DefinedTerm or similar node
+			item = node["name"]
+		}
+		for _, value := range strings.Split(schemaText(item), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// schemaImage returns the url of the first image, which may be given as a
+// url, an ImageObject or a list of either.
+func schemaImage(v any) string {
+	for _, item := range asArray(v) {
+		if node, ok := item.(map[string]any); ok {
+			if url, ok := node["url"]; ok {
+				item = url
+			} else {
+				item = node["contentUrl"]
+			}
+		}
+		if url := schemaText(item); url != "" {
+			return url
+		}
+	}
+	return ""
+}
+
+// schemaYield prefers the yield that states a number, since sites often give
+// both "4" and "4 servings", or "1 loaf" and "12 slices".
+func schemaYield(v any) string {
+	var first string
+	for _, item := range asArray(v) {
+		s := schemaText(item)
+		if first == "" {
+			first = s
+		}
+		if strings.ContainsAny(s, "0123456789") {
+			return s
+		}
+	}
+	return first
+}
+
+// schemaInstructions flattens recipeInstructions, which may be a block of
+// text, a list of strings, or HowToStep and HowToSection nodes, into steps.
+func schemaInstructions(v any) []string {
+	var steps []string
+
+	switch v := v.(type) {
+	case string:
+		for _, line := range strings.Split(html.UnescapeString(v), "\n") {
+			if step := cleanText(line); step != "" {
+				steps = append(steps, step)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			steps = append(steps, schemaInstructions(item)...)
+		}
+	case map[string]any:
+		if elements, ok := v["itemListElement"]; ok {
+			// HowToSection or ItemList
+			return schemaInstructions(elements)
+		}
+		step := schemaText(v["text"])
+		if step == "" {
+			step = schemaText(v["name"])
+		}
+		if step != "" {
+			steps = append(steps, step)
+		}
+	}
+
+	return steps
+}
+
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
@@ -0,0 +1,24 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (created_at, updated_at, owner_id, name, prefix, key_hash, scope)
+VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetApiKey :one
+SELECT * FROM api_keys
+WHERE id = ?;
+
+-- name: GetApiKeyByHash :one
+SELECT * FROM api_keys
+WHERE key_hash = ?;
+
+-- name: GetApiKeysForUser :many
+SELECT * FROM api_keys
+WHERE owner_id = ?
+ORDER BY id;
+
+-- name: SetApiKeyLastUsed :exec
+UPDATE api_keys SET last_used_at = ?
+WHERE id = ?;
+
+-- name: DeleteApiKey :exec
+DELETE FROM api_keys
+WHERE id = ?;
@@ -0,0 +1,25 @@
+-- name: UpsertCalendarFeed :one
+INSERT INTO calendar_feeds (created_at, updated_at, owner_id, token_hash)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (owner_id) DO UPDATE SET
+	created_at = excluded.created_at,
+	updated_at = excluded.updated_at,
+	token_hash = excluded.token_hash,
+	last_used_at = NULL
+RETURNING *;
+
+-- name: GetCalendarFeedForUser :one
+SELECT * FROM calendar_feeds
+WHERE owner_id = ?;
+
+-- name: GetCalendarFeedByHash :one
+SELECT * FROM calendar_feeds
+WHERE token_hash = ?;
+
+-- name: SetCalendarFeedLastUsed :exec
+UPDATE calendar_feeds SET last_used_at = ?
+WHERE id = ?;
+
+-- name: DeleteCalendarFeedForUser :exec
+DELETE FROM calendar_feeds
+WHERE owner_id = ?;
@@ -0,0 +1,11 @@
+-- name: GetCanonicalIngredient :one
+SELECT * FROM canonical_ingredients
+WHERE id = ?;
+
+-- name: GetCanonicalIngredientBySynonym :one
+SELECT * FROM canonical_ingredients
+WHERE id = (SELECT canonical_ingredient_id FROM canonical_ingredient_synonyms WHERE synonym = ?);
+
+-- name: GetCanonicalIngredientsInGroceryList :many
+SELECT * FROM canonical_ingredients
+WHERE id IN (SELECT canonical_ingredient_id FROM items WHERE grocery_list_id = ?);
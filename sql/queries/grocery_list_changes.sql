@@ -0,0 +1,16 @@
+-- name: GetLatestGroceryListChange :one
+SELECT id FROM grocery_list_changes
+WHERE grocery_list_id = ?
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: GetGroceryListChanges :many
+SELECT * FROM grocery_list_changes
+WHERE grocery_list_id = sqlc.arg(grocery_list_id)
+	AND id > sqlc.arg(after_id)
+	AND id <= sqlc.arg(until_id)
+ORDER BY id;
+
+-- name: DeleteGroceryListChanges :exec
+DELETE FROM grocery_list_changes
+WHERE grocery_list_id = ?;
@@ -8,4 +8,57 @@ WHERE id = ?;
 
 -- name: GetGroceryListsForUser :many
 SELECT * FROM grocery_lists
-WHERE owner_id = ?;
+WHERE owner_id = sqlc.arg(owner_id)
+	OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id));
+
+-- name: DeleteGroceryList :exec
+DELETE FROM grocery_lists
+WHERE id = ?;
+
+-- name: ListGroceryListsForUser :many
+SELECT * FROM grocery_lists
+WHERE (owner_id = sqlc.arg(owner_id)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit);
+
+-- name: ListGroceryListsForUserDesc :many
+SELECT * FROM grocery_lists
+WHERE (owner_id = sqlc.arg(owner_id)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND id < sqlc.arg(before_id)
+ORDER BY id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListGroceryListsForUserByName :many
+SELECT * FROM grocery_lists
+WHERE (owner_id = sqlc.arg(owner_id)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND (CAST(sqlc.arg(from_start) AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) > (CAST(sqlc.arg(after_name) AS TEXT), CAST(sqlc.arg(after_id) AS INTEGER)))
+ORDER BY name COLLATE NOCASE, id
+LIMIT sqlc.arg(limit);
+
+-- name: ListGroceryListsForUserByNameDesc :many
+SELECT * FROM grocery_lists
+WHERE (owner_id = sqlc.arg(owner_id)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND (CAST(sqlc.arg(from_start) AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) < (CAST(sqlc.arg(before_name) AS TEXT), CAST(sqlc.arg(before_id) AS INTEGER)))
+ORDER BY name COLLATE NOCASE DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: SetGroceryListHousehold :one
+UPDATE grocery_lists
+SET updated_at = ?, household_id = ?
+WHERE id = ?
+RETURNING *;
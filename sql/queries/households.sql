@@ -0,0 +1,45 @@
+-- name: CreateHousehold :one
+INSERT INTO households (created_at, updated_at, name)
+VALUES (?, ?, ?) RETURNING *;
+
+-- name: GetHousehold :one
+SELECT * FROM households
+WHERE id = ?;
+
+-- name: GetHouseholdsForUser :many
+SELECT sqlc.embed(h), hm.role FROM households h
+JOIN household_members hm ON hm.household_id = h.id
+WHERE hm.user_id = ?
+ORDER BY h.id;
+
+-- name: DeleteHousehold :exec
+DELETE FROM households
+WHERE id = ?;
+
+-- name: CreateHouseholdMember :one
+INSERT INTO household_members (household_id, user_id, created_at, updated_at, role)
+VALUES (?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetHouseholdMember :one
+SELECT * FROM household_members
+WHERE household_id = ? AND user_id = ?;
+
+-- name: GetHouseholdMembers :many
+SELECT sqlc.embed(hm), u.username FROM household_members hm
+JOIN users u ON hm.user_id = u.id
+WHERE hm.household_id = ?
+ORDER BY hm.created_at, hm.user_id;
+
+-- name: SetHouseholdMemberRole :one
+UPDATE household_members
+SET updated_at = ?, role = ?
+WHERE household_id = ? AND user_id = ?
+RETURNING *;
+
+-- name: DeleteHouseholdMember :exec
+DELETE FROM household_members
+WHERE household_id = ? AND user_id = ?;
+
+-- name: CountHouseholdOwners :one
+SELECT COUNT(*) FROM household_members
+WHERE household_id = ? AND role = 'owner';
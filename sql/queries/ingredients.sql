@@ -1,6 +1,6 @@
 -- name: CreateIngredient :one
-INSERT INTO ingredients(created_at, updated_at, name, description, amount, units, standard_amount, standard_units, recipe_id)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+INSERT INTO ingredients(created_at, updated_at, name, description, amount, units, standard_amount, standard_units, recipe_id, canonical_ingredient_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
 
 -- name: GetIngredient :one
 SELECT * FROM ingredients
@@ -10,3 +10,7 @@ WHERE id = ?;
 SELECT * FROM ingredients
 WHERE recipe_id = ?
 ORDER BY id;
+
+-- name: DeleteIngredientsForRecipe :exec
+DELETE FROM ingredients
+WHERE recipe_id = ?;
@@ -0,0 +1,12 @@
+-- name: CreateInstruction :one
+INSERT INTO instructions (created_at, updated_at, recipe_id, step, text)
+VALUES (?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetInstructionsForRecipe :many
+SELECT * FROM instructions
+WHERE recipe_id = ?
+ORDER BY step;
+
+-- name: DeleteInstructionsForRecipe :exec
+DELETE FROM instructions
+WHERE recipe_id = ?;
@@ -1,6 +1,6 @@
 -- name: CreateItem :one
-INSERT INTO items (created_at, updated_at, ingredient_id, grocery_list_id, meal_id, name, description, amount, units, standard_amount, standard_units)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+INSERT INTO items (created_at, updated_at, ingredient_id, canonical_ingredient_id, grocery_list_id, meal_id, name, description, amount, units, standard_amount, standard_units, category)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
 
 -- name: GetItem :one
 SELECT * FROM items
@@ -42,3 +42,77 @@ WHERE it.grocery_list_id = ? AND name = ?;
 UPDATE items
 SET updated_at = ?, is_complete = ?
 WHERE id = ?;
+
+-- name: SetItemCategory :one
+UPDATE items
+SET updated_at = ?, category = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: UnlinkItemsFromRecipeIngredients :exec
+UPDATE items
+SET ingredient_id = NULL
+WHERE ingredient_id IN (SELECT id FROM ingredients WHERE recipe_id = ?);
+
+-- name: DeleteItem :exec
+DELETE FROM items
+WHERE id = ?;
+
+-- name: DeleteItemsForMeal :exec
+DELETE FROM items
+WHERE meal_id = ?;
+
+-- name: DeleteItemsInGroceryList :exec
+DELETE FROM items
+WHERE grocery_list_id = ?;
+
+-- name: SetItemAmounts :exec
+UPDATE items
+SET updated_at = ?, amount = ?, standard_amount = ?
+WHERE id = ?;
+
+-- name: ListItemsForGroceryList :many
+SELECT * FROM items
+WHERE grocery_list_id = sqlc.arg(grocery_list_id)
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND ((is_complete = 0 AND CAST(sqlc.arg(include_incomplete) AS BOOLEAN))
+		OR (is_complete = 1 AND CAST(sqlc.arg(include_complete) AS BOOLEAN)))
+	AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit);
+
+-- name: ListItemsForGroceryListDesc :many
+SELECT * FROM items
+WHERE grocery_list_id = sqlc.arg(grocery_list_id)
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND ((is_complete = 0 AND CAST(sqlc.arg(include_incomplete) AS BOOLEAN))
+		OR (is_complete = 1 AND CAST(sqlc.arg(include_complete) AS BOOLEAN)))
+	AND id < sqlc.arg(before_id)
+ORDER BY id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListItemsForGroceryListByName :many
+SELECT * FROM items
+WHERE grocery_list_id = sqlc.arg(grocery_list_id)
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND ((is_complete = 0 AND CAST(sqlc.arg(include_incomplete) AS BOOLEAN))
+		OR (is_complete = 1 AND CAST(sqlc.arg(include_complete) AS BOOLEAN)))
+	AND (CAST(sqlc.arg(from_start) AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) > (CAST(sqlc.arg(after_name) AS TEXT), CAST(sqlc.arg(after_id) AS INTEGER)))
+ORDER BY name COLLATE NOCASE, id
+LIMIT sqlc.arg(limit);
+
+-- name: ListItemsForGroceryListByNameDesc :many
+SELECT * FROM items
+WHERE grocery_list_id = sqlc.arg(grocery_list_id)
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND ((is_complete = 0 AND CAST(sqlc.arg(include_incomplete) AS BOOLEAN))
+		OR (is_complete = 1 AND CAST(sqlc.arg(include_complete) AS BOOLEAN)))
+	AND (CAST(sqlc.arg(from_start) AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) < (CAST(sqlc.arg(before_name) AS TEXT), CAST(sqlc.arg(before_id) AS INTEGER)))
+ORDER BY name COLLATE NOCASE DESC, id DESC
+LIMIT sqlc.arg(limit);
@@ -1,6 +1,6 @@
 -- name: CreateMeal :one
-INSERT INTO meals (created_at, updated_at, grocery_list_id, recipe_id)
-VALUES (?, ?, ?, ?) RETURNING *;
+INSERT INTO meals (created_at, updated_at, grocery_list_id, recipe_id, servings)
+VALUES (?, ?, ?, ?, ?) RETURNING *;
 
 -- name: GetMeal :one
 SELECT * FROM meals
@@ -19,3 +19,21 @@ WHERE m.id = ?;
 SELECT sqlc.embed(m), sqlc.embed(r) from meals m 
 JOIN recipes r ON m.recipe_id = r.id
 WHERE m.grocery_list_id = ?;
+
+-- name: CountMealsForRecipe :one
+SELECT COUNT(*) FROM meals
+WHERE recipe_id = ?;
+
+-- name: DeleteMeal :exec
+DELETE FROM meals
+WHERE id = ?;
+
+-- name: DeleteMealsInGroceryList :exec
+DELETE FROM meals
+WHERE grocery_list_id = ?;
+
+-- name: SetMealServings :one
+UPDATE meals
+SET updated_at = ?, servings = ?
+WHERE id = ?
+RETURNING *;
@@ -0,0 +1,86 @@
+-- name: CreatePantryItem :one
+INSERT INTO pantry_items (created_at, updated_at, owner_id, household_id, canonical_ingredient_id, standard_amount, standard_units, always_stocked)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetPantryItem :one
+SELECT sqlc.embed(p), ci.name FROM pantry_items p
+JOIN canonical_ingredients ci ON p.canonical_ingredient_id = ci.id
+WHERE p.id = ?;
+
+-- name: GetPantryItemsForUser :many
+SELECT sqlc.embed(p), ci.name FROM pantry_items p
+JOIN canonical_ingredients ci ON p.canonical_ingredient_id = ci.id
+WHERE p.owner_id = ?
+ORDER BY ci.name;
+
+-- name: GetPantryItemsForHousehold :many
+SELECT sqlc.embed(p), ci.name FROM pantry_items p
+JOIN canonical_ingredients ci ON p.canonical_ingredient_id = ci.id
+WHERE p.household_id = ?
+ORDER BY ci.name;
+
+-- name: GetUserPantryItemForIngredient :one
+SELECT * FROM pantry_items
+WHERE owner_id = ? AND canonical_ingredient_id = ?;
+
+-- name: GetHouseholdPantryItemForIngredient :one
+SELECT * FROM pantry_items
+WHERE household_id = ? AND canonical_ingredient_id = ?;
+
+-- name: UpdatePantryItem :exec
+UPDATE pantry_items
+SET updated_at = ?, standard_amount = ?, standard_units = ?, always_stocked = ?
+WHERE id = ?;
+
+-- name: AddToPantryItem :exec
+UPDATE pantry_items
+SET updated_at = sqlc.arg(updated_at), standard_amount = standard_amount + CAST(sqlc.arg(amount) AS REAL)
+WHERE id = sqlc.arg(id);
+
+-- name: DeletePantryItem :exec
+DELETE FROM pantry_items
+WHERE id = ?;
+
+-- name: GetPantryItemReserved :one
+SELECT CAST(COALESCE(SUM(standard_amount), 0) AS REAL) FROM meal_pantry_uses
+WHERE pantry_item_id = ?;
+
+-- name: AddMealPantryUse :exec
+INSERT INTO meal_pantry_uses (meal_id, pantry_item_id, standard_amount)
+VALUES (?, ?, ?)
+ON CONFLICT (meal_id, pantry_item_id) DO UPDATE SET standard_amount = standard_amount + excluded.standard_amount;
+
+-- name: ScaleMealPantryUses :exec
+UPDATE meal_pantry_uses
+SET standard_amount = standard_amount * CAST(sqlc.arg(ratio) AS REAL)
+WHERE meal_id = sqlc.arg(meal_id);
+
+-- name: DeleteMealPantryUses :exec
+DELETE FROM meal_pantry_uses
+WHERE meal_id = ?;
+
+-- name: DeleteMealPantryUsesInGroceryList :exec
+DELETE FROM meal_pantry_uses
+WHERE meal_id IN (SELECT id FROM meals WHERE grocery_list_id = ?);
+
+-- name: TakeFromPantryItem :exec
+UPDATE pantry_items
+SET updated_at = sqlc.arg(updated_at), standard_amount = MAX(standard_amount - CAST(sqlc.arg(amount) AS REAL), 0)
+WHERE id = sqlc.arg(id);
+
+-- name: ReleaseMealPantryUse :exec
+UPDATE meal_pantry_uses
+SET standard_amount = MAX(standard_amount - CAST(sqlc.arg(amount) AS REAL), 0)
+WHERE meal_id = sqlc.arg(meal_id) AND pantry_item_id = sqlc.arg(pantry_item_id);
+
+-- name: CreateItemRestock :exec
+INSERT INTO item_restocks (item_id, pantry_item_id, standard_amount)
+VALUES (?, ?, ?);
+
+-- name: GetItemRestock :one
+SELECT * FROM item_restocks
+WHERE item_id = ?;
+
+-- name: DeleteItemRestock :exec
+DELETE FROM item_restocks
+WHERE item_id = ?;
@@ -0,0 +1,15 @@
+-- name: CreatePasswordResetToken :one
+INSERT INTO password_reset_tokens (created_at, user_id, token_hash, expires_at)
+VALUES (?, ?, ?, ?) RETURNING *;
+
+-- name: GetPasswordResetTokenByHash :one
+SELECT * FROM password_reset_tokens
+WHERE token_hash = ?;
+
+-- name: UsePasswordResetToken :execrows
+UPDATE password_reset_tokens SET used_at = ?
+WHERE id = ? AND used_at IS NULL;
+
+-- name: UsePasswordResetTokensForUser :exec
+UPDATE password_reset_tokens SET used_at = ?
+WHERE user_id = ? AND used_at IS NULL;
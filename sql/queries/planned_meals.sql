@@ -0,0 +1,45 @@
+-- name: CreatePlannedMeal :one
+INSERT INTO planned_meals (created_at, updated_at, owner_id, household_id, date, slot, slot_name, recipe_id, servings)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetExtendedPlannedMeal :one
+SELECT sqlc.embed(pm), sqlc.embed(r) FROM planned_meals pm
+JOIN recipes r ON pm.recipe_id = r.id
+WHERE pm.id = ?;
+
+-- name: GetExtendedPlannedMealsForUser :many
+SELECT sqlc.embed(pm), sqlc.embed(r) FROM planned_meals pm
+JOIN recipes r ON pm.recipe_id = r.id
+WHERE (pm.owner_id = sqlc.arg(owner_id)
+		OR pm.household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND pm.date >= sqlc.arg(from_date) AND pm.date <= sqlc.arg(to_date)
+ORDER BY pm.date, pm.id;
+
+-- name: UpdatePlannedMeal :one
+UPDATE planned_meals
+SET updated_at = ?, household_id = ?, date = ?, slot = ?, slot_name = ?, recipe_id = ?, servings = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: SetPlannedMealMeal :exec
+UPDATE planned_meals
+SET meal_id = ?
+WHERE id = ?;
+
+-- name: UnlinkPlannedMealsFromMeal :exec
+UPDATE planned_meals
+SET meal_id = NULL
+WHERE meal_id = ?;
+
+-- name: UnlinkPlannedMealsInGroceryList :exec
+UPDATE planned_meals
+SET meal_id = NULL
+WHERE meal_id IN (SELECT id FROM meals WHERE grocery_list_id = ?);
+
+-- name: CountPlannedMealsForRecipe :one
+SELECT COUNT(*) FROM planned_meals
+WHERE recipe_id = ?;
+
+-- name: DeletePlannedMeal :exec
+DELETE FROM planned_meals
+WHERE id = ?;
@@ -0,0 +1,18 @@
+-- name: UpsertRecipeImage :one
+INSERT INTO recipe_images (recipe_id, created_at, blob_key, content_type, size, source_url)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (recipe_id) DO UPDATE SET
+	created_at = excluded.created_at,
+	blob_key = excluded.blob_key,
+	content_type = excluded.content_type,
+	size = excluded.size,
+	source_url = excluded.source_url
+RETURNING *;
+
+-- name: GetRecipeImage :one
+SELECT * FROM recipe_images
+WHERE recipe_id = ?;
+
+-- name: DeleteRecipeImage :exec
+DELETE FROM recipe_images
+WHERE recipe_id = ?;
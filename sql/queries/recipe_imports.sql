@@ -0,0 +1,29 @@
+-- name: CreateRecipeImport :one
+INSERT INTO recipe_imports (created_at, updated_at, owner_id, url)
+VALUES (?, ?, ?, ?) RETURNING *;
+
+-- name: GetRecipeImport :one
+SELECT * FROM recipe_imports
+WHERE id = ?;
+
+-- name: GetRecipeImportsForUser :many
+SELECT * FROM recipe_imports
+WHERE owner_id = ?
+ORDER BY id DESC;
+
+-- name: ClaimRecipeImport :one
+UPDATE recipe_imports
+SET status = 'running', attempts = attempts + 1, updated_at = sqlc.arg(now)
+WHERE id = (
+	SELECT id FROM recipe_imports
+	WHERE status = 'pending'
+		OR (status = 'running' AND updated_at < sqlc.arg(stale_before))
+	ORDER BY id
+	LIMIT 1
+)
+RETURNING *;
+
+-- name: FinishRecipeImport :exec
+UPDATE recipe_imports
+SET status = ?, updated_at = ?, recipe_id = ?, error_code = ?
+WHERE id = ?;
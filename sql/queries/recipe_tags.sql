@@ -0,0 +1,30 @@
+-- name: AddRecipeTag :exec
+INSERT INTO recipe_tags (recipe_id, name, kind, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (recipe_id, name) DO NOTHING;
+
+-- name: GetRecipeTag :one
+SELECT * FROM recipe_tags
+WHERE recipe_id = ? AND name = ?;
+
+-- name: GetTagsForRecipe :many
+SELECT * FROM recipe_tags
+WHERE recipe_id = ?
+ORDER BY name;
+
+-- name: DeleteRecipeTag :execrows
+DELETE FROM recipe_tags
+WHERE recipe_id = ? AND name = ?;
+
+-- name: DeleteTagsForRecipe :exec
+DELETE FROM recipe_tags
+WHERE recipe_id = ?;
+
+-- name: GetTagCountsForUser :many
+SELECT recipe_tags.name, count(*) AS recipe_count
+FROM recipe_tags
+JOIN recipes ON recipes.id = recipe_tags.recipe_id
+WHERE recipes.owner_id = sqlc.arg(owner_id)
+	OR recipes.household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id))
+GROUP BY recipe_tags.name
+ORDER BY recipe_count DESC, recipe_tags.name;
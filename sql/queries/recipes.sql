@@ -1,6 +1,6 @@
 -- name: CreateRecipe :one
-INSERT INTO recipes(created_at, updated_at, name, description, url, prep_time, cook_time, total_time, owner_id)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+INSERT INTO recipes(created_at, updated_at, name, description, url, prep_time_seconds, cook_time_seconds, total_time_seconds, servings, owner_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
 
 -- name: GetRecipe :one
 SELECT * FROM recipes
@@ -8,4 +8,128 @@ WHERE id = ?;
 
 -- name: GetRecipesForUser :many
 SELECT * FROM recipes
-WHERE owner_id = ?;
+WHERE owner_id = sqlc.arg(owner_id)
+	OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id));
+
+-- name: UpdateRecipe :one
+UPDATE recipes
+SET updated_at = ?, name = ?, description = ?, url = ?, prep_time_seconds = ?, cook_time_seconds = ?, total_time_seconds = ?, servings = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteRecipe :exec
+DELETE FROM recipes
+WHERE id = ?;
+
+-- name: ListRecipesForUser :many
+SELECT * FROM recipes
+WHERE (owner_id = sqlc.arg(owner_id)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(sqlc.arg(max_time_seconds) AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(sqlc.arg(tags) AS TEXT)))) >= CAST(sqlc.arg(tags_needed) AS INTEGER)
+	AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit);
+
+-- name: ListRecipesForUserDesc :many
+SELECT * FROM recipes
+WHERE (owner_id = sqlc.arg(owner_id)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(sqlc.arg(max_time_seconds) AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(sqlc.arg(tags) AS TEXT)))) >= CAST(sqlc.arg(tags_needed) AS INTEGER)
+	AND id < sqlc.arg(before_id)
+ORDER BY id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListRecipesForUserByName :many
+SELECT * FROM recipes
+WHERE (owner_id = sqlc.arg(owner_id)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(sqlc.arg(max_time_seconds) AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(sqlc.arg(tags) AS TEXT)))) >= CAST(sqlc.arg(tags_needed) AS INTEGER)
+	AND (CAST(sqlc.arg(from_start) AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) > (CAST(sqlc.arg(after_name) AS TEXT), CAST(sqlc.arg(after_id) AS INTEGER)))
+ORDER BY name COLLATE NOCASE, id
+LIMIT sqlc.arg(limit);
+
+-- name: ListRecipesForUserByNameDesc :many
+SELECT * FROM recipes
+WHERE (owner_id = sqlc.arg(owner_id)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(sqlc.arg(max_time_seconds) AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(sqlc.arg(tags) AS TEXT)))) >= CAST(sqlc.arg(tags_needed) AS INTEGER)
+	AND (CAST(sqlc.arg(from_start) AS BOOLEAN)
+		OR (name COLLATE NOCASE, id) < (CAST(sqlc.arg(before_name) AS TEXT), CAST(sqlc.arg(before_id) AS INTEGER)))
+ORDER BY name COLLATE NOCASE DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- the list queries keep recipes that have at least tags_needed of the tags,
+-- which are a JSON array of distinct names
+
+-- recipes that do not say how long they take come last in both orders
+
+-- name: ListRecipesForUserByTime :many
+SELECT * FROM recipes
+WHERE (owner_id = sqlc.arg(owner_id)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(sqlc.arg(max_time_seconds) AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(sqlc.arg(tags) AS TEXT)))) >= CAST(sqlc.arg(tags_needed) AS INTEGER)
+	AND (CAST(sqlc.arg(from_start) AS BOOLEAN)
+		OR (coalesce(time_seconds, 9223372036854775807), id) > (CAST(sqlc.arg(after_seconds) AS INTEGER), CAST(sqlc.arg(after_id) AS INTEGER)))
+ORDER BY coalesce(time_seconds, 9223372036854775807), id
+LIMIT sqlc.arg(limit);
+
+-- name: ListRecipesForUserByTimeDesc :many
+SELECT * FROM recipes
+WHERE (owner_id = sqlc.arg(owner_id)
+		OR household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+	AND instr(lower(name), lower(CAST(sqlc.arg(name_contains) AS TEXT))) > 0
+	AND created_at > sqlc.arg(created_after)
+	AND coalesce(time_seconds, 9223372036854775807) <= CAST(sqlc.arg(max_time_seconds) AS INTEGER)
+	AND (SELECT count(*) FROM recipe_tags
+		WHERE recipe_tags.recipe_id = recipes.id
+			AND recipe_tags.name IN (SELECT value FROM json_each(CAST(sqlc.arg(tags) AS TEXT)))) >= CAST(sqlc.arg(tags_needed) AS INTEGER)
+	AND (CAST(sqlc.arg(from_start) AS BOOLEAN)
+		OR (coalesce(time_seconds, -1), id) < (CAST(sqlc.arg(before_seconds) AS INTEGER), CAST(sqlc.arg(before_id) AS INTEGER)))
+ORDER BY coalesce(time_seconds, -1) DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: SearchRecipesForUser :many
+SELECT sqlc.embed(recipes),
+	highlight(recipe_search, 0, char(2), char(3)) AS name_highlight,
+	snippet(recipe_search, 1, char(2), char(3), '…', 16) AS description_snippet,
+	snippet(recipe_search, 2, char(2), char(3), '…', 16) AS ingredients_snippet,
+	bm25(recipe_search, 10.0, 2.0, 5.0) AS rank
+FROM recipe_search
+JOIN recipes ON recipes.id = recipe_search.rowid
+WHERE recipe_search MATCH sqlc.arg(query)
+	AND (recipes.owner_id = sqlc.arg(owner_id)
+		OR recipes.household_id IN (SELECT household_id FROM household_members WHERE user_id = sqlc.arg(member_id)))
+ORDER BY rank
+LIMIT sqlc.arg(limit);
+
+-- name: SetRecipeHousehold :one
+UPDATE recipes
+SET updated_at = ?, household_id = ?
+WHERE id = ?
+RETURNING *;
@@ -0,0 +1,15 @@
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (created_at, updated_at, user_id, token_hash, expires_at)
+VALUES (?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetRefreshTokenByHash :one
+SELECT * FROM refresh_tokens
+WHERE token_hash = ?;
+
+-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens SET updated_at = ?, revoked_at = ?, replaced_by_id = ?
+WHERE id = ? AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokensForUser :exec
+UPDATE refresh_tokens SET updated_at = ?, revoked_at = ?
+WHERE user_id = ? AND revoked_at IS NULL;
@@ -0,0 +1,35 @@
+-- name: CreateStore :one
+INSERT INTO stores (created_at, updated_at, owner_id, name)
+VALUES (?, ?, ?, ?) RETURNING *;
+
+-- name: GetStore :one
+SELECT * FROM stores
+WHERE id = ?;
+
+-- name: GetStoresForUser :many
+SELECT * FROM stores
+WHERE owner_id = ?
+ORDER BY id;
+
+-- name: UpdateStore :one
+UPDATE stores
+SET updated_at = ?, name = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteStore :exec
+DELETE FROM stores
+WHERE id = ?;
+
+-- name: CreateStoreAisle :exec
+INSERT INTO store_aisles (store_id, category, position, name)
+VALUES (?, ?, ?, ?);
+
+-- name: GetStoreAisles :many
+SELECT * FROM store_aisles
+WHERE store_id = ?
+ORDER BY position;
+
+-- name: DeleteStoreAisles :exec
+DELETE FROM store_aisles
+WHERE store_id = ?;
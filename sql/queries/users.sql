@@ -1,6 +1,6 @@
 -- name: CreateUser :one
-INSERT INTO users (created_at, updated_at, username, hashed_password, first_name, last_name)
-VALUES (?, ?, ?, ?, ?, ?) RETURNING *;
+INSERT INTO users (created_at, updated_at, username, hashed_password, first_name, last_name, email)
+VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING *;
 
 -- name: GetUser :one
 SELECT * FROM users
@@ -10,3 +10,16 @@ WHERE id = ?;
 -- name: GetUserByUsername :one
 SELECT * FROM users
 WHERE username = ?;
+
+-- name: IncrementUserTokenVersion :one
+UPDATE users SET updated_at = ?, token_version = token_version + 1
+WHERE id = ?
+RETURNING *;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE email = ?;
+
+-- name: SetUserPassword :exec
+UPDATE users SET updated_at = ?, hashed_password = ?
+WHERE id = ?;
@@ -0,0 +1,206 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- +goose StatementBegin
+-- SQLite cannot add constraints to existing tables, so every table is rebuilt.
+-- Foreign key enforcement has to be off while the old tables are dropped.
+--
+-- Rows that point at rows which no longer exist, such as recipes from before
+-- they had owners, which were given an owner id of -1, are dealt with the way
+-- the new constraints would have: they are deleted when the reference cascades
+-- or restricts, and the reference is cleared when it is set to NULL. Each
+-- table is checked against the tables already rebuilt, so that the rows of a
+-- deleted recipe go with it.
+PRAGMA foreign_keys = OFF;
+
+BEGIN;
+
+CREATE TABLE recipes_new (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	url VARCHAR(512),
+	prep_time TEXT,
+	cook_time TEXT,
+	total_time TEXT,
+	owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE
+);
+INSERT INTO recipes_new (id, created_at, updated_at, name, description, url, prep_time, cook_time, total_time, owner_id)
+SELECT id, created_at, updated_at, name, description, url, prep_time, cook_time, total_time, owner_id FROM recipes
+WHERE owner_id IN (SELECT id FROM users);
+DROP TABLE recipes;
+ALTER TABLE recipes_new RENAME TO recipes;
+
+CREATE TABLE ingredients_new (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	amount DOUBLE NOT NULL,
+	units VARCHAR(32) NOT NULL,
+	standard_amount DOUBLE NOT NULL,
+	standard_units VARCHAR(32) NOT NULL
+);
+INSERT INTO ingredients_new (id, created_at, updated_at, name, description, recipe_id, amount, units, standard_amount, standard_units)
+SELECT id, created_at, updated_at, name, description, recipe_id, amount, units, standard_amount, standard_units FROM ingredients
+WHERE recipe_id IN (SELECT id FROM recipes);
+DROP TABLE ingredients;
+ALTER TABLE ingredients_new RENAME TO ingredients;
+
+CREATE TABLE grocery_lists_new (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	name TEXT NOT NULL,
+	owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE
+);
+INSERT INTO grocery_lists_new (id, created_at, updated_at, name, owner_id)
+SELECT id, created_at, updated_at, name, owner_id FROM grocery_lists
+WHERE owner_id IN (SELECT id FROM users);
+DROP TABLE grocery_lists;
+ALTER TABLE grocery_lists_new RENAME TO grocery_lists;
+
+-- a recipe cannot be deleted while meals still use it
+CREATE TABLE meals_new (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	grocery_list_id INTEGER NOT NULL REFERENCES grocery_lists (id) ON DELETE CASCADE,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE RESTRICT
+);
+INSERT INTO meals_new (id, created_at, updated_at, grocery_list_id, recipe_id)
+SELECT id, created_at, updated_at, grocery_list_id, recipe_id FROM meals
+WHERE grocery_list_id IN (SELECT id FROM grocery_lists) AND recipe_id IN (SELECT id FROM recipes);
+DROP TABLE meals;
+ALTER TABLE meals_new RENAME TO meals;
+
+CREATE TABLE items_new (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	grocery_list_id INTEGER NOT NULL REFERENCES grocery_lists (id) ON DELETE CASCADE,
+	meal_id INTEGER REFERENCES meals (id) ON DELETE CASCADE,
+	ingredient_id INTEGER REFERENCES ingredients (id) ON DELETE SET NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	amount DOUBLE NOT NULL,
+	units VARCHAR(32) NOT NULL,
+	standard_amount DOUBLE NOT NULL,
+	standard_units VARCHAR(32) NOT NULL,
+	is_complete BOOLEAN NOT NULL DEFAULT FALSE
+);
+INSERT INTO items_new (id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete)
+SELECT id, created_at, updated_at, grocery_list_id, meal_id,
+	CASE WHEN ingredient_id IN (SELECT id FROM ingredients) THEN ingredient_id END,
+	name, description, amount, units, standard_amount, standard_units, is_complete
+FROM items
+WHERE grocery_list_id IN (SELECT id FROM grocery_lists) AND (meal_id IS NULL OR meal_id IN (SELECT id FROM meals));
+DROP TABLE items;
+ALTER TABLE items_new RENAME TO items;
+
+CREATE INDEX ingredients_recipe_id_idx ON ingredients (recipe_id);
+CREATE INDEX meals_grocery_list_id_idx ON meals (grocery_list_id);
+CREATE INDEX meals_recipe_id_idx ON meals (recipe_id);
+CREATE INDEX items_grocery_list_id_idx ON items (grocery_list_id);
+CREATE INDEX items_meal_id_idx ON items (meal_id);
+CREATE INDEX items_ingredient_id_idx ON items (ingredient_id);
+
+-- fail, and roll back, if any row still breaks a constraint
+CREATE TEMP TABLE foreign_key_violations (
+	table_name TEXT,
+	CONSTRAINT foreign_key_check_failed CHECK (table_name IS NULL)
+);
+INSERT INTO foreign_key_violations SELECT "table" FROM pragma_foreign_key_check;
+DROP TABLE foreign_key_violations;
+
+COMMIT;
+
+PRAGMA foreign_keys = ON;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+PRAGMA foreign_keys = OFF;
+
+BEGIN;
+
+CREATE TABLE items_old (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	grocery_list_id INTEGER NOT NULL,
+	meal_id INTEGER,
+	ingredient_id INTEGER,
+	name TEXT NOT NULL,
+	description TEXT,
+	amount DOUBLE NOT NULL,
+	units VARCHAR(32) NOT NULL,
+	standard_amount DOUBLE NOT NULL,
+	standard_units VARCHAR(32) NOT NULL,
+	is_complete BOOLEAN NOT NULL DEFAULT FALSE
+);
+INSERT INTO items_old SELECT * FROM items;
+DROP TABLE items;
+ALTER TABLE items_old RENAME TO items;
+
+CREATE TABLE meals_old (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	grocery_list_id INTEGER NOT NULL,
+	recipe_id INTEGER NOT NULL
+);
+INSERT INTO meals_old SELECT * FROM meals;
+DROP TABLE meals;
+ALTER TABLE meals_old RENAME TO meals;
+
+CREATE TABLE grocery_lists_old (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	name TEXT NOT NULL,
+	owner_id INTEGER NOT NULL
+);
+INSERT INTO grocery_lists_old SELECT * FROM grocery_lists;
+DROP TABLE grocery_lists;
+ALTER TABLE grocery_lists_old RENAME TO grocery_lists;
+
+CREATE TABLE ingredients_old (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	recipe_id INTEGER NOT NULL,
+	amount DOUBLE NOT NULL,
+	units VARCHAR(32) NOT NULL,
+	standard_amount DOUBLE NOT NULL,
+	standard_units VARCHAR(32) NOT NULL
+);
+INSERT INTO ingredients_old SELECT * FROM ingredients;
+DROP TABLE ingredients;
+ALTER TABLE ingredients_old RENAME TO ingredients;
+
+CREATE TABLE recipes_old (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	url VARCHAR(512),
+	prep_time TEXT,
+	cook_time TEXT,
+	total_time TEXT,
+	owner_id INTEGER NOT NULL DEFAULT -1
+);
+INSERT INTO recipes_old SELECT * FROM recipes;
+DROP TABLE recipes;
+ALTER TABLE recipes_old RENAME TO recipes;
+
+COMMIT;
+
+PRAGMA foreign_keys = ON;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE instructions (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	step INTEGER NOT NULL,
+	text TEXT NOT NULL,
+	UNIQUE (recipe_id, step)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE instructions;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE recipes
+	ADD COLUMN servings INTEGER;
+ALTER TABLE meals
+	ADD COLUMN servings INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipes DROP servings;
+ALTER TABLE meals DROP servings;
+-- +goose StatementEnd
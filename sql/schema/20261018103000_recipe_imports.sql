@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE recipe_imports (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	url TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+	attempts INTEGER NOT NULL DEFAULT 0,
+	recipe_id INTEGER REFERENCES recipes (id) ON DELETE SET NULL,
+	error_code TEXT
+);
+CREATE INDEX recipe_imports_owner_id_idx ON recipe_imports (owner_id);
+CREATE INDEX recipe_imports_status_idx ON recipe_imports (status, updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE recipe_imports;
+-- +goose StatementEnd
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE VIRTUAL TABLE recipe_search USING fts5 (
+	name,
+	description,
+	ingredients,
+	tokenize = 'porter unicode61 remove_diacritics 2'
+);
+
+-- the rowid of each row is the id of its recipe
+INSERT INTO recipe_search (rowid, name, description, ingredients)
+SELECT r.id, r.name, coalesce(r.description, ''),
+	coalesce((SELECT group_concat(i.name, ' ') FROM ingredients i WHERE i.recipe_id = r.id), '')
+FROM recipes r;
+
+CREATE TRIGGER recipe_search_recipe_insert AFTER INSERT ON recipes
+BEGIN
+	INSERT INTO recipe_search (rowid, name, description, ingredients)
+	VALUES (new.id, new.name, coalesce(new.description, ''), '');
+END;
+
+CREATE TRIGGER recipe_search_recipe_update AFTER UPDATE OF name, description ON recipes
+BEGIN
+	UPDATE recipe_search
+	SET name = new.name, description = coalesce(new.description, '')
+	WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER recipe_search_recipe_delete AFTER DELETE ON recipes
+BEGIN
+	DELETE FROM recipe_search WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER recipe_search_ingredient_insert AFTER INSERT ON ingredients
+BEGIN
+	UPDATE recipe_search
+	SET ingredients = coalesce((SELECT group_concat(name, ' ') FROM ingredients WHERE recipe_id = new.recipe_id), '')
+	WHERE rowid = new.recipe_id;
+END;
+
+CREATE TRIGGER recipe_search_ingredient_update AFTER UPDATE OF name, recipe_id ON ingredients
+BEGIN
+	UPDATE recipe_search
+	SET ingredients = coalesce((SELECT group_concat(name, ' ') FROM ingredients WHERE recipe_id = old.recipe_id), '')
+	WHERE rowid = old.recipe_id;
+	UPDATE recipe_search
+	SET ingredients = coalesce((SELECT group_concat(name, ' ') FROM ingredients WHERE recipe_id = new.recipe_id), '')
+	WHERE rowid = new.recipe_id;
+END;
+
+CREATE TRIGGER recipe_search_ingredient_delete AFTER DELETE ON ingredients
+BEGIN
+	UPDATE recipe_search
+	SET ingredients = coalesce((SELECT group_concat(name, ' ') FROM ingredients WHERE recipe_id = old.recipe_id), '')
+	WHERE rowid = old.recipe_id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER recipe_search_ingredient_delete;
+DROP TRIGGER recipe_search_ingredient_update;
+DROP TRIGGER recipe_search_ingredient_insert;
+DROP TRIGGER recipe_search_recipe_delete;
+DROP TRIGGER recipe_search_recipe_update;
+DROP TRIGGER recipe_search_recipe_insert;
+DROP TABLE recipe_search;
+-- +goose StatementEnd
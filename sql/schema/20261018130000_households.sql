@@ -0,0 +1,95 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- +goose StatementBegin
+BEGIN;
+
+CREATE TABLE households (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	name TEXT NOT NULL
+);
+
+CREATE TABLE household_members (
+	household_id INTEGER NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	role TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+	PRIMARY KEY (household_id, user_id)
+);
+CREATE INDEX household_members_user_id_idx ON household_members (user_id);
+
+-- recipes and grocery lists without a household are only shared with their owner
+ALTER TABLE recipes ADD COLUMN household_id INTEGER REFERENCES households (id) ON DELETE SET NULL;
+CREATE INDEX recipes_household_id_idx ON recipes (household_id);
+ALTER TABLE grocery_lists ADD COLUMN household_id INTEGER REFERENCES households (id) ON DELETE SET NULL;
+CREATE INDEX grocery_lists_household_id_idx ON grocery_lists (household_id);
+
+COMMIT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- SQLite cannot drop a column that references another table, so recipes and
+-- grocery lists are rebuilt without it.
+PRAGMA foreign_keys = OFF;
+
+BEGIN;
+
+CREATE TABLE grocery_lists_old (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	name TEXT NOT NULL,
+	owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE
+);
+INSERT INTO grocery_lists_old (id, created_at, updated_at, name, owner_id)
+SELECT id, created_at, updated_at, name, owner_id FROM grocery_lists;
+DROP TABLE grocery_lists;
+ALTER TABLE grocery_lists_old RENAME TO grocery_lists;
+
+CREATE TABLE recipes_old (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	url VARCHAR(512),
+	prep_time TEXT,
+	cook_time TEXT,
+	total_time TEXT,
+	owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	servings INTEGER
+);
+INSERT INTO recipes_old (id, created_at, updated_at, name, description, url, prep_time, cook_time, total_time, owner_id, servings)
+SELECT id, created_at, updated_at, name, description, url, prep_time, cook_time, total_time, owner_id, servings FROM recipes;
+DROP TABLE recipes;
+ALTER TABLE recipes_old RENAME TO recipes;
+
+-- dropping recipes dropped its search triggers too
+CREATE TRIGGER recipe_search_recipe_insert AFTER INSERT ON recipes
+BEGIN
+	INSERT INTO recipe_search (rowid, name, description, ingredients)
+	VALUES (new.id, new.name, coalesce(new.description, ''), '');
+END;
+
+CREATE TRIGGER recipe_search_recipe_update AFTER UPDATE OF name, description ON recipes
+BEGIN
+	UPDATE recipe_search
+	SET name = new.name, description = coalesce(new.description, '')
+	WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER recipe_search_recipe_delete AFTER DELETE ON recipes
+BEGIN
+	DELETE FROM recipe_search WHERE rowid = old.id;
+END;
+
+DROP TABLE household_members;
+DROP TABLE households;
+
+COMMIT;
+
+PRAGMA foreign_keys = ON;
+-- +goose StatementEnd
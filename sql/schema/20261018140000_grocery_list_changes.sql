@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+-- every change to the items and meals of a grocery list is recorded here, so
+-- that clients can fetch what changed since they last synced. AUTOINCREMENT
+-- keeps ids from being reused, so they only ever grow.
+CREATE TABLE grocery_list_changes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	grocery_list_id INTEGER NOT NULL REFERENCES grocery_lists (id) ON DELETE CASCADE,
+	entity TEXT NOT NULL CHECK (entity IN ('item', 'meal')),
+	entity_id INTEGER NOT NULL,
+	deleted BOOLEAN NOT NULL
+);
+CREATE INDEX grocery_list_changes_grocery_list_id_idx ON grocery_list_changes (grocery_list_id, id);
+
+CREATE TRIGGER grocery_list_changes_item_insert AFTER INSERT ON items
+BEGIN
+	INSERT INTO grocery_list_changes (grocery_list_id, entity, entity_id, deleted)
+	VALUES (new.grocery_list_id, 'item', new.id, 0);
+END;
+
+CREATE TRIGGER grocery_list_changes_item_update AFTER UPDATE ON items
+BEGIN
+	INSERT INTO grocery_list_changes (grocery_list_id, entity, entity_id, deleted)
+	VALUES (new.grocery_list_id, 'item', new.id, 0);
+END;
+
+CREATE TRIGGER grocery_list_changes_item_delete AFTER DELETE ON items
+BEGIN
+	INSERT INTO grocery_list_changes (grocery_list_id, entity, entity_id, deleted)
+	VALUES (old.grocery_list_id, 'item', old.id, 1);
+END;
+
+CREATE TRIGGER grocery_list_changes_meal_insert AFTER INSERT ON meals
+BEGIN
+	INSERT INTO grocery_list_changes (grocery_list_id, entity, entity_id, deleted)
+	VALUES (new.grocery_list_id, 'meal', new.id, 0);
+END;
+
+CREATE TRIGGER grocery_list_changes_meal_update AFTER UPDATE ON meals
+BEGIN
+	INSERT INTO grocery_list_changes (grocery_list_id, entity, entity_id, deleted)
+	VALUES (new.grocery_list_id, 'meal', new.id, 0);
+END;
+
+CREATE TRIGGER grocery_list_changes_meal_delete AFTER DELETE ON meals
+BEGIN
+	INSERT INTO grocery_list_changes (grocery_list_id, entity, entity_id, deleted)
+	VALUES (old.grocery_list_id, 'meal', old.id, 1);
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER grocery_list_changes_meal_delete;
+DROP TRIGGER grocery_list_changes_meal_update;
+DROP TRIGGER grocery_list_changes_meal_insert;
+DROP TRIGGER grocery_list_changes_item_delete;
+DROP TRIGGER grocery_list_changes_item_update;
+DROP TRIGGER grocery_list_changes_item_insert;
+DROP TABLE grocery_list_changes;
+-- +goose StatementEnd
@@ -0,0 +1,330 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- +goose StatementBegin
+BEGIN;
+
+-- canonical_ingredients is a catalog of ingredients, so that "onions", "yellow
+-- onion" and "Onions, diced" all end up as the same thing on a grocery list.
+-- Names the catalog does not know are left unlinked.
+CREATE TABLE canonical_ingredients (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL UNIQUE
+);
+
+-- synonyms are names, normalized by ingparse.NormalizeName, that stand for a
+-- canonical ingredient. Every canonical ingredient is a synonym of itself.
+CREATE TABLE canonical_ingredient_synonyms (
+	synonym TEXT PRIMARY KEY,
+	canonical_ingredient_id INTEGER NOT NULL REFERENCES canonical_ingredients (id) ON DELETE CASCADE
+);
+CREATE INDEX canonical_ingredient_synonyms_canonical_ingredient_id_idx ON canonical_ingredient_synonyms (canonical_ingredient_id);
+
+ALTER TABLE ingredients ADD COLUMN canonical_ingredient_id INTEGER REFERENCES canonical_ingredients (id) ON DELETE SET NULL;
+ALTER TABLE items ADD COLUMN canonical_ingredient_id INTEGER REFERENCES canonical_ingredients (id) ON DELETE SET NULL;
+CREATE INDEX items_canonical_ingredient_id_idx ON items (grocery_list_id, canonical_ingredient_id);
+
+INSERT INTO canonical_ingredients (id, name) VALUES
+	(1, 'onion'),
+	(2, 'red onion'),
+	(3, 'scallion'),
+	(4, 'shallot'),
+	(5, 'garlic'),
+	(6, 'bell pepper'),
+	(7, 'jalapeño'),
+	(8, 'tomato'),
+	(9, 'cherry tomato'),
+	(10, 'potato'),
+	(11, 'sweet potato'),
+	(12, 'carrot'),
+	(13, 'celery'),
+	(14, 'cilantro'),
+	(15, 'parsley'),
+	(16, 'basil'),
+	(17, 'lemon'),
+	(18, 'lemon juice'),
+	(19, 'lime'),
+	(20, 'lime juice'),
+	(21, 'egg'),
+	(22, 'butter'),
+	(23, 'milk'),
+	(24, 'heavy cream'),
+	(25, 'sour cream'),
+	(26, 'parmesan'),
+	(27, 'cheddar'),
+	(28, 'mozzarella'),
+	(29, 'flour'),
+	(30, 'sugar'),
+	(31, 'brown sugar'),
+	(32, 'powdered sugar'),
+	(33, 'salt'),
+	(34, 'black pepper'),
+	(35, 'olive oil'),
+	(36, 'vegetable oil'),
+	(37, 'baking soda'),
+	(38, 'baking powder'),
+	(39, 'vanilla extract'),
+	(40, 'chicken breast'),
+	(41, 'chicken thigh'),
+	(42, 'ground beef'),
+	(43, 'chicken broth'),
+	(44, 'vegetable broth'),
+	(45, 'beef broth'),
+	(46, 'soy sauce'),
+	(47, 'rice'),
+	(48, 'water');
+
+INSERT INTO canonical_ingredient_synonyms (synonym, canonical_ingredient_id) VALUES
+	('onion', 1),
+	('yellow onion', 1),
+	('white onion', 1),
+	('brown onion', 1),
+	('spanish onion', 1),
+	('sweet onion', 1),
+	('red onion', 2),
+	('purple onion', 2),
+	('scallion', 3),
+	('green onion', 3),
+	('spring onion', 3),
+	('shallot', 4),
+	('garlic', 5),
+	('garlic clove', 5),
+	('clove garlic', 5),
+	('cloves garlic', 5),
+	('clove of garlic', 5),
+	('cloves of garlic', 5),
+	('bell pepper', 6),
+	('red bell pepper', 6),
+	('green bell pepper', 6),
+	('yellow bell pepper', 6),
+	('orange bell pepper', 6),
+	('capsicum', 6),
+	('jalapeño', 7),
+	('jalapeno', 7),
+	('jalapeño pepper', 7),
+	('jalapeno pepper', 7),
+	('tomato', 8),
+	('roma tomato', 8),
+	('plum tomato', 8),
+	('vine tomato', 8),
+	('cherry tomato', 9),
+	('grape tomato', 9),
+	('potato', 10),
+	('russet potato', 10),
+	('yukon gold potato', 10),
+	('white potato', 10),
+	('sweet potato', 11),
+	('yam', 11),
+	('carrot', 12),
+	('celery', 13),
+	('celery stalk', 13),
+	('celery rib', 13),
+	('stalk celery', 13),
+	('rib celery', 13),
+	('cilantro', 14),
+	('coriander leaf', 14),
+	('parsley', 15),
+	('flat-leaf parsley', 15),
+	('italian parsley', 15),
+	('curly parsley', 15),
+	('basil', 16),
+	('sweet basil', 16),
+	('basil leaf', 16),
+	('lemon', 17),
+	('lemon juice', 18),
+	('juice of lemon', 18),
+	('lime', 19),
+	('lime juice', 20),
+	('juice of lime', 20),
+	('egg', 21),
+	('whole egg', 21),
+	('butter', 22),
+	('unsalted butter', 22),
+	('salted butter', 22),
+	('milk', 23),
+	('whole milk', 23),
+	('heavy cream', 24),
+	('heavy whipping cream', 24),
+	('whipping cream', 24),
+	('double cream', 24),
+	('sour cream', 25),
+	('parmesan', 26),
+	('parmesan cheese', 26),
+	('parmigiano-reggiano', 26),
+	('parmigiano reggiano', 26),
+	('cheddar', 27),
+	('cheddar cheese', 27),
+	('sharp cheddar', 27),
+	('sharp cheddar cheese', 27),
+	('mozzarella', 28),
+	('mozzarella cheese', 28),
+	('flour', 29),
+	('all-purpose flour', 29),
+	('all purpose flour', 29),
+	('plain flour', 29),
+	('white flour', 29),
+	('sugar', 30),
+	('granulated sugar', 30),
+	('white sugar', 30),
+	('caster sugar', 30),
+	('brown sugar', 31),
+	('light brown sugar', 31),
+	('dark brown sugar', 31),
+	('powdered sugar', 32),
+	('confectioners sugar', 32),
+	('icing sugar', 32),
+	('salt', 33),
+	('kosher salt', 33),
+	('sea salt', 33),
+	('table salt', 33),
+	('fine salt', 33),
+	('black pepper', 34),
+	('pepper', 34),
+	('ground black pepper', 34),
+	('ground pepper', 34),
+	('black peppercorn', 34),
+	('olive oil', 35),
+	('extra virgin olive oil', 35),
+	('extra-virgin olive oil', 35),
+	('evoo', 35),
+	('vegetable oil', 36),
+	('canola oil', 36),
+	('neutral oil', 36),
+	('baking soda', 37),
+	('bicarbonate of soda', 37),
+	('sodium bicarbonate', 37),
+	('baking powder', 38),
+	('vanilla extract', 39),
+	('vanilla', 39),
+	('pure vanilla extract', 39),
+	('chicken breast', 40),
+	('boneless skinless chicken breast', 40),
+	('skinless chicken breast', 40),
+	('chicken thigh', 41),
+	('boneless skinless chicken thigh', 41),
+	('ground beef', 42),
+	('beef mince', 42),
+	('lean ground beef', 42),
+	('chicken broth', 43),
+	('chicken stock', 43),
+	('vegetable broth', 44),
+	('vegetable stock', 44),
+	('beef broth', 45),
+	('beef stock', 45),
+	('soy sauce', 46),
+	('light soy sauce', 46),
+	('shoyu', 46),
+	('rice', 47),
+	('white rice', 47),
+	('long grain rice', 47),
+	('long-grain rice', 47),
+	('water', 48),
+	('cold water', 48),
+	('warm water', 48),
+	('hot water', 48),
+	('boiling water', 48);
+
+COMMIT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- SQLite cannot drop a column that references another table, so items and
+-- ingredients are rebuilt without it.
+PRAGMA foreign_keys = OFF;
+
+BEGIN;
+
+CREATE TABLE items_old (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	grocery_list_id INTEGER NOT NULL REFERENCES grocery_lists (id) ON DELETE CASCADE,
+	meal_id INTEGER REFERENCES meals (id) ON DELETE CASCADE,
+	ingredient_id INTEGER REFERENCES ingredients (id) ON DELETE SET NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	amount DOUBLE NOT NULL,
+	units VARCHAR(32) NOT NULL,
+	standard_amount DOUBLE NOT NULL,
+	standard_units VARCHAR(32) NOT NULL,
+	is_complete BOOLEAN NOT NULL DEFAULT FALSE
+);
+INSERT INTO items_old (id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete)
+SELECT id, created_at, updated_at, grocery_list_id, meal_id, ingredient_id, name, description, amount, units, standard_amount, standard_units, is_complete FROM items;
+DROP TABLE items;
+ALTER TABLE items_old RENAME TO items;
+
+-- dropping items dropped its indexes and change triggers too
+CREATE INDEX items_grocery_list_id_idx ON items (grocery_list_id);
+CREATE INDEX items_ingredient_id_idx ON items (ingredient_id);
+CREATE INDEX items_meal_id_idx ON items (meal_id);
+
+CREATE TRIGGER grocery_list_changes_item_insert AFTER INSERT ON items
+BEGIN
+	INSERT INTO grocery_list_changes (grocery_list_id, entity, entity_id, deleted)
+	VALUES (new.grocery_list_id, 'item', new.id, 0);
+END;
+
+CREATE TRIGGER grocery_list_changes_item_update AFTER UPDATE ON items
+BEGIN
+	INSERT INTO grocery_list_changes (grocery_list_id, entity, entity_id, deleted)
+	VALUES (new.grocery_list_id, 'item', new.id, 0);
+END;
+
+CREATE TRIGGER grocery_list_changes_item_delete AFTER DELETE ON items
+BEGIN
+	INSERT INTO grocery_list_changes (grocery_list_id, entity, entity_id, deleted)
+	VALUES (old.grocery_list_id, 'item', old.id, 1);
+END;
+
+CREATE TABLE ingredients_old (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	amount DOUBLE NOT NULL,
+	units VARCHAR(32) NOT NULL,
+	standard_amount DOUBLE NOT NULL,
+	standard_units VARCHAR(32) NOT NULL
+);
+INSERT INTO ingredients_old (id, created_at, updated_at, name, description, recipe_id, amount, units, standard_amount, standard_units)
+SELECT id, created_at, updated_at, name, description, recipe_id, amount, units, standard_amount, standard_units FROM ingredients;
+DROP TABLE ingredients;
+ALTER TABLE ingredients_old RENAME TO ingredients;
+
+-- dropping ingredients dropped its index and search triggers too
+CREATE INDEX ingredients_recipe_id_idx ON ingredients (recipe_id);
+
+CREATE TRIGGER recipe_search_ingredient_insert AFTER INSERT ON ingredients
+BEGIN
+	UPDATE recipe_search
+	SET ingredients = coalesce((SELECT group_concat(name, ' ') FROM ingredients WHERE recipe_id = new.recipe_id), '')
+	WHERE rowid = new.recipe_id;
+END;
+
+CREATE TRIGGER recipe_search_ingredient_update AFTER UPDATE OF name, recipe_id ON ingredients
+BEGIN
+	UPDATE recipe_search
+	SET ingredients = coalesce((SELECT group_concat(name, ' ') FROM ingredients WHERE recipe_id = old.recipe_id), '')
+	WHERE rowid = old.recipe_id;
+	UPDATE recipe_search
+	SET ingredients = coalesce((SELECT group_concat(name, ' ') FROM ingredients WHERE recipe_id = new.recipe_id), '')
+	WHERE rowid = new.recipe_id;
+END;
+
+CREATE TRIGGER recipe_search_ingredient_delete AFTER DELETE ON ingredients
+BEGIN
+	UPDATE recipe_search
+	SET ingredients = coalesce((SELECT group_concat(name, ' ') FROM ingredients WHERE recipe_id = old.recipe_id), '')
+	WHERE rowid = old.recipe_id;
+END;
+
+DROP TABLE canonical_ingredient_synonyms;
+DROP TABLE canonical_ingredients;
+
+COMMIT;
+
+PRAGMA foreign_keys = ON;
+-- +goose StatementEnd
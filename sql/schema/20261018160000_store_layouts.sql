@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the category is the section of a store an ingredient is found in. Items take
+-- the category of their catalog entry when they are created, and users may
+-- change it afterwards.
+ALTER TABLE canonical_ingredients ADD COLUMN category TEXT CHECK (category IN ('produce', 'bakery', 'deli', 'meat', 'seafood', 'dairy', 'frozen', 'pantry', 'baking', 'spices', 'beverages', 'household', 'other'));
+ALTER TABLE items ADD COLUMN category TEXT CHECK (category IN ('produce', 'bakery', 'deli', 'meat', 'seafood', 'dairy', 'frozen', 'pantry', 'baking', 'spices', 'beverages', 'household', 'other'));
+
+UPDATE canonical_ingredients SET category = 'produce'
+WHERE name IN ('onion', 'red onion', 'scallion', 'shallot', 'garlic', 'bell pepper', 'jalapeño', 'tomato', 'cherry tomato', 'potato', 'sweet potato', 'carrot', 'celery', 'cilantro', 'parsley', 'basil', 'lemon', 'lemon juice', 'lime', 'lime juice');
+UPDATE canonical_ingredients SET category = 'dairy'
+WHERE name IN ('egg', 'butter', 'milk', 'heavy cream', 'sour cream', 'parmesan', 'cheddar', 'mozzarella');
+UPDATE canonical_ingredients SET category = 'baking'
+WHERE name IN ('flour', 'sugar', 'brown sugar', 'powdered sugar', 'baking soda', 'baking powder', 'vanilla extract');
+UPDATE canonical_ingredients SET category = 'spices'
+WHERE name IN ('salt', 'black pepper');
+UPDATE canonical_ingredients SET category = 'pantry'
+WHERE name IN ('olive oil', 'vegetable oil', 'chicken broth', 'vegetable broth', 'beef broth', 'soy sauce', 'rice');
+UPDATE canonical_ingredients SET category = 'meat'
+WHERE name IN ('chicken breast', 'chicken thigh', 'ground beef');
+
+UPDATE items SET category = (SELECT category FROM canonical_ingredients WHERE id = items.canonical_ingredient_id)
+WHERE canonical_ingredient_id IS NOT NULL;
+
+-- stores are laid out by their owner as an ordered list of aisles, each
+-- holding one category, so grocery lists can be sorted in walking order
+CREATE TABLE stores (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	name TEXT NOT NULL
+);
+CREATE INDEX stores_owner_id_idx ON stores (owner_id);
+
+CREATE TABLE store_aisles (
+	store_id INTEGER NOT NULL REFERENCES stores (id) ON DELETE CASCADE,
+	category TEXT NOT NULL CHECK (category IN ('produce', 'bakery', 'deli', 'meat', 'seafood', 'dairy', 'frozen', 'pantry', 'baking', 'spices', 'beverages', 'household', 'other')),
+	position INTEGER NOT NULL,
+	name TEXT,
+	PRIMARY KEY (store_id, category)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE store_aisles;
+DROP TABLE stores;
+ALTER TABLE items DROP COLUMN category;
+ALTER TABLE canonical_ingredients DROP COLUMN category;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- pantry_items are what a user, or a household, has on hand. Each holds one
+-- ingredient of the catalog, in the standard units it is measured in.
+-- Ingredients that are always stocked are never added to grocery lists.
+CREATE TABLE pantry_items (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	owner_id INTEGER REFERENCES users (id) ON DELETE CASCADE,
+	household_id INTEGER REFERENCES households (id) ON DELETE CASCADE,
+	canonical_ingredient_id INTEGER NOT NULL REFERENCES canonical_ingredients (id) ON DELETE CASCADE,
+	standard_amount REAL NOT NULL,
+	standard_units TEXT NOT NULL,
+	always_stocked BOOLEAN NOT NULL DEFAULT FALSE,
+	CHECK ((owner_id IS NULL) <> (household_id IS NULL))
+);
+CREATE UNIQUE INDEX pantry_items_owner_id_idx ON pantry_items (owner_id, canonical_ingredient_id) WHERE owner_id IS NOT NULL;
+CREATE UNIQUE INDEX pantry_items_household_id_idx ON pantry_items (household_id, canonical_ingredient_id) WHERE household_id IS NOT NULL;
+
+-- meal_pantry_uses are the amounts meals count on taking from the pantry, so
+-- that two meals are not both netted against the same stock
+CREATE TABLE meal_pantry_uses (
+	meal_id INTEGER NOT NULL REFERENCES meals (id) ON DELETE CASCADE,
+	pantry_item_id INTEGER NOT NULL REFERENCES pantry_items (id) ON DELETE CASCADE,
+	standard_amount REAL NOT NULL,
+	PRIMARY KEY (meal_id, pantry_item_id)
+);
+CREATE INDEX meal_pantry_uses_pantry_item_id_idx ON meal_pantry_uses (pantry_item_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE meal_pantry_uses;
+DROP TABLE pantry_items;
+-- +goose StatementEnd
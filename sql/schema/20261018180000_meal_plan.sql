@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- planned_meals are recipes planned for a day and a slot of it. Custom slots
+-- carry their own name, like "snack". meal_id is the meal the planned meal was
+-- last put on a grocery list as, so that building the list again updates it
+-- instead of adding it twice. Dates are written as YYYY-MM-DD.
+CREATE TABLE planned_meals (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	household_id INTEGER REFERENCES households (id) ON DELETE SET NULL,
+	date TEXT NOT NULL,
+	slot TEXT NOT NULL CHECK (slot IN ('breakfast', 'lunch', 'dinner', 'custom')),
+	slot_name TEXT,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id),
+	servings INTEGER,
+	meal_id INTEGER REFERENCES meals (id) ON DELETE SET NULL
+);
+CREATE INDEX planned_meals_owner_id_date_idx ON planned_meals (owner_id, date);
+CREATE INDEX planned_meals_household_id_date_idx ON planned_meals (household_id, date);
+CREATE INDEX planned_meals_recipe_id_idx ON planned_meals (recipe_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE planned_meals;
+-- +goose StatementEnd
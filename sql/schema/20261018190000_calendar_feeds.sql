@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- calendar_feeds hold the secret token a user's meal plan calendar is served
+-- under, for calendar apps that cannot send an Authorization header. Each user
+-- has at most one; only its hash is stored.
+CREATE TABLE calendar_feeds (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	owner_id INTEGER NOT NULL UNIQUE REFERENCES users (id) ON DELETE CASCADE,
+	token_hash TEXT NOT NULL UNIQUE,
+	last_used_at TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE calendar_feeds;
+-- +goose StatementEnd
@@ -0,0 +1,129 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Recipe times were stored as text: Go durations like "1h30m0s" for imported
+-- recipes, and whatever was sent for others. They become whole seconds, NULL
+-- when the recipe does not say. The old columns are dropped in place rather
+-- than rebuilding the table, which keeps the recipe_search triggers.
+ALTER TABLE recipes ADD COLUMN prep_time_seconds INTEGER CHECK (prep_time_seconds > 0);
+ALTER TABLE recipes ADD COLUMN cook_time_seconds INTEGER CHECK (cook_time_seconds > 0);
+ALTER TABLE recipes ADD COLUMN total_time_seconds INTEGER CHECK (total_time_seconds > 0);
+
+-- parse the old text a character at a time. Go durations and ISO 8601
+-- durations such as PT1H30M are both sequences of numbers with units once the
+-- P and T of the latter are removed, as long as only days come before the T
+-- and only hours, minutes and seconds after it, so that P1M, a month, is not
+-- read as a minute. Like misc.ParseISO8601Duration, years, months and weeks
+-- are only accepted when they are zero. They are put back in capitals, which
+-- the lowercased text cannot otherwise hold, to tell months apart from
+-- minutes; anything else is dropped.
+CREATE TEMP TABLE recipe_durations AS
+WITH RECURSIVE
+	old_times (id, kind, rest) AS (
+		SELECT id, 'prep', lower(trim(prep_time)) FROM recipes WHERE prep_time IS NOT NULL
+		UNION ALL
+		SELECT id, 'cook', lower(trim(cook_time)) FROM recipes WHERE cook_time IS NOT NULL
+		UNION ALL
+		SELECT id, 'total', lower(trim(total_time)) FROM recipes WHERE total_time IS NOT NULL
+	),
+	iso_times (id, kind, days, time, has_time) AS (
+		SELECT id, kind,
+			substr(rest, 2, instr(rest || 't', 't') - 2),
+			substr(rest, instr(rest || 't', 't') + 1),
+			instr(rest, 't') > 0
+		FROM old_times
+		WHERE rest GLOB 'p*'
+	),
+	durations (id, kind, rest, bad) AS (
+		SELECT id, kind, rest, rest = ''
+		FROM old_times
+		WHERE rest NOT GLOB 'p*'
+		UNION ALL
+		SELECT id, kind, replace(replace(replace(days, 'y', 'Y'), 'm', 'M'), 'w', 'W') || time,
+			days || time = ''
+			OR (has_time AND time = '')
+			OR days GLOB '*[^0-9ymwd]*'
+			OR days GLOB '*[ymwd][ymwd]*'
+			OR time GLOB '*[^0-9.hms]*'
+			OR time GLOB '*[hms][hms]*'
+		FROM iso_times
+	),
+	steps (id, kind, rest, number, seconds, bad) AS (
+		SELECT id, kind, rest, '', 0.0, bad
+		FROM durations
+		UNION ALL
+		SELECT id, kind,
+			CASE
+				WHEN substr(rest, 1, 1) GLOB '[0-9.]' THEN substr(rest, 2)
+				WHEN substr(rest, 1, 2) IN ('ms', 'us', 'µs', 'ns') THEN substr(rest, 3)
+				ELSE substr(rest, 2)
+			END,
+			CASE
+				WHEN substr(rest, 1, 1) GLOB '[0-9.]' THEN number || substr(rest, 1, 1)
+				ELSE ''
+			END,
+			seconds + CASE
+				WHEN substr(rest, 1, 1) GLOB '[0-9.]' OR number = '' THEN 0
+				WHEN substr(rest, 1, 1) IN ('Y', 'M', 'W') THEN 0
+				WHEN substr(rest, 1, 2) = 'ms' THEN CAST(number AS REAL) / 1000
+				WHEN substr(rest, 1, 2) IN ('us', 'µs', 'ns') THEN 0
+				WHEN substr(rest, 1, 1) = 'd' THEN CAST(number AS REAL) * 86400
+				WHEN substr(rest, 1, 1) = 'h' THEN CAST(number AS REAL) * 3600
+				WHEN substr(rest, 1, 1) = 'm' THEN CAST(number AS REAL) * 60
+				WHEN substr(rest, 1, 1) = 's' THEN CAST(number AS REAL)
+				ELSE 0
+			END,
+			NOT (substr(rest, 1, 1) GLOB '[0-9.]'
+				OR (number != '' AND (substr(rest, 1, 2) IN ('ms', 'us', 'µs', 'ns') OR substr(rest, 1, 1) IN ('d', 'h', 'm', 's')))
+				OR (number != '' AND CAST(number AS REAL) = 0 AND substr(rest, 1, 1) IN ('Y', 'M', 'W')))
+		FROM steps
+		WHERE rest != '' AND NOT bad
+	)
+SELECT id, kind, CAST(round(seconds) AS INTEGER) AS seconds
+FROM steps
+WHERE rest = '' AND number = '' AND NOT bad AND round(seconds) > 0;
+
+UPDATE recipes SET
+	prep_time_seconds = (SELECT seconds FROM recipe_durations d WHERE d.id = recipes.id AND d.kind = 'prep'),
+	cook_time_seconds = (SELECT seconds FROM recipe_durations d WHERE d.id = recipes.id AND d.kind = 'cook'),
+	total_time_seconds = (SELECT seconds FROM recipe_durations d WHERE d.id = recipes.id AND d.kind = 'total');
+
+DROP TABLE recipe_durations;
+
+ALTER TABLE recipes DROP COLUMN prep_time;
+ALTER TABLE recipes DROP COLUMN cook_time;
+ALTER TABLE recipes DROP COLUMN total_time;
+
+-- time_seconds is how long a recipe takes, to filter and sort by: its total
+-- time, or else its prep and cook times added up
+ALTER TABLE recipes ADD COLUMN time_seconds INTEGER GENERATED ALWAYS AS (coalesce(total_time_seconds, prep_time_seconds + cook_time_seconds, prep_time_seconds, cook_time_seconds)) VIRTUAL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipes DROP COLUMN time_seconds;
+ALTER TABLE recipes ADD COLUMN prep_time TEXT;
+ALTER TABLE recipes ADD COLUMN cook_time TEXT;
+ALTER TABLE recipes ADD COLUMN total_time TEXT;
+
+-- written back as Go durations, the way imported recipes stored them
+UPDATE recipes SET
+	prep_time = CASE
+		WHEN prep_time_seconds >= 3600 THEN printf('%dh%dm%ds', prep_time_seconds / 3600, prep_time_seconds % 3600 / 60, prep_time_seconds % 60)
+		WHEN prep_time_seconds >= 60 THEN printf('%dm%ds', prep_time_seconds / 60, prep_time_seconds % 60)
+		WHEN prep_time_seconds > 0 THEN printf('%ds', prep_time_seconds)
+	END,
+	cook_time = CASE
+		WHEN cook_time_seconds >= 3600 THEN printf('%dh%dm%ds', cook_time_seconds / 3600, cook_time_seconds % 3600 / 60, cook_time_seconds % 60)
+		WHEN cook_time_seconds >= 60 THEN printf('%dm%ds', cook_time_seconds / 60, cook_time_seconds % 60)
+		WHEN cook_time_seconds > 0 THEN printf('%ds', cook_time_seconds)
+	END,
+	total_time = CASE
+		WHEN total_time_seconds >= 3600 THEN printf('%dh%dm%ds', total_time_seconds / 3600, total_time_seconds % 3600 / 60, total_time_seconds % 60)
+		WHEN total_time_seconds >= 60 THEN printf('%dm%ds', total_time_seconds / 60, total_time_seconds % 60)
+		WHEN total_time_seconds > 0 THEN printf('%ds', total_time_seconds)
+	END;
+
+ALTER TABLE recipes DROP COLUMN prep_time_seconds;
+ALTER TABLE recipes DROP COLUMN cook_time_seconds;
+ALTER TABLE recipes DROP COLUMN total_time_seconds;
+-- +goose StatementEnd
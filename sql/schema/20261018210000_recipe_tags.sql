@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- recipe_tags label recipes. Tags taken from the categories, cuisines and
+-- keywords of scraped recipes keep where they came from as their kind; tags
+-- users add are of kind 'user'. Names are stored lower case, so a recipe has
+-- each tag at most once.
+CREATE TABLE recipe_tags (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	kind TEXT NOT NULL CHECK (kind IN ('category', 'cuisine', 'keyword', 'user')),
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (recipe_id, name)
+);
+
+CREATE INDEX recipe_tags_name ON recipe_tags (name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE recipe_tags;
+-- +goose StatementEnd
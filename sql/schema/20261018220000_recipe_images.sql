@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- recipe_images hold where the image of a recipe is kept in the blob store.
+-- Each upload is stored under a new random key, so a key always names the same
+-- bytes and can be used as an ETag. source_url is set for images downloaded
+-- from the page a recipe was scraped from.
+CREATE TABLE recipe_images (
+	recipe_id INTEGER PRIMARY KEY REFERENCES recipes (id) ON DELETE CASCADE,
+	created_at TIMESTAMP NOT NULL,
+	blob_key TEXT NOT NULL UNIQUE,
+	content_type TEXT NOT NULL,
+	size INTEGER NOT NULL,
+	source_url TEXT
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE recipe_images;
+-- +goose StatementEnd
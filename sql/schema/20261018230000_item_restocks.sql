@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- item_restocks are what marking an item complete added to the pantry, so that
+-- marking it incomplete again can take it back out, and marking it complete
+-- twice does not add it twice
+CREATE TABLE item_restocks (
+	item_id INTEGER PRIMARY KEY REFERENCES items (id) ON DELETE CASCADE,
+	pantry_item_id INTEGER NOT NULL REFERENCES pantry_items (id) ON DELETE CASCADE,
+	standard_amount REAL NOT NULL
+);
+CREATE INDEX item_restocks_pantry_item_id_idx ON item_restocks (pantry_item_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE item_restocks;
+-- +goose StatementEnd